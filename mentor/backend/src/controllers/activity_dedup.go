@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// activityDedupWindow returns the ingest deduplication window for activities
+// from ACTIVITY_DEDUP_WINDOW_SECONDS. Zero (the default) disables dedup so
+// existing deployments are unaffected until they opt in.
+func activityDedupWindow() time.Duration {
+	if raw := os.Getenv("ACTIVITY_DEDUP_WINDOW_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func postActivity(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/activity", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	Activity(c)
+	return w
+}
+
+func TestActivityDedupSuppressesDuplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("ACTIVITY_DEDUP_WINDOW_SECONDS", "60")
+
+	deviceID := uuid.New()
+	body := `{"deviceid": "` + deviceID.String() + `", "activity_type": "app_launch", "app": "firefox", "description": "launched"}`
+
+	w := postActivity(t, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var first models.DeviceActivity
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("failed to unmarshal activity: %v", err)
+	}
+
+	// The duplicate report within the window returns the existing row
+	w = postActivity(t, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for duplicate, got %d", w.Code)
+	}
+	var second models.DeviceActivity
+	if err := json.Unmarshal(w.Body.Bytes(), &second); err != nil {
+		t.Fatalf("failed to unmarshal duplicate response: %v", err)
+	}
+	if second.ActivityID != first.ActivityID {
+		t.Errorf("expected duplicate to return existing activity %s, got %s", first.ActivityID, second.ActivityID)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceActivity{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count activities: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 stored activity, got %d", count)
+	}
+
+	// A different app within the window is not a duplicate
+	other := `{"deviceid": "` + deviceID.String() + `", "activity_type": "app_launch", "app": "chrome"}`
+	if w := postActivity(t, other); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for distinct activity, got %d", w.Code)
+	}
+	if err := database.DB.Model(&models.DeviceActivity{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count activities: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 stored activities after distinct app, got %d", count)
+	}
+}
+
+func TestActivityDedupDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("ACTIVITY_DEDUP_WINDOW_SECONDS", "")
+
+	deviceID := uuid.New()
+	body := `{"deviceid": "` + deviceID.String() + `", "activity_type": "app_launch", "app": "firefox"}`
+
+	for i := 0; i < 2; i++ {
+		if w := postActivity(t, body); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceActivity{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count activities: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected both activities stored with dedup off, got %d", count)
+	}
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/logging"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAdminConfig returns a sanitized view of the effective runtime configuration
+// @Summary Get effective runtime configuration
+// @Description Report which integrations are wired up (command forwarding, MinIO, CORS) and the effective thresholds and timeouts. Credentials are reported as booleans only and never echoed back.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/config [get]
+func GetAdminConfig(c *gin.Context) {
+	// Only report presence of secrets, never their values, so this endpoint is
+	// safe to expose to operators debugging a deployment.
+	config := gin.H{
+		"service": "mentor-backend",
+		"forwarding": gin.H{
+			"enabled": os.Getenv("DEVICES_API_URL") != "",
+		},
+		"database": gin.H{
+			"host_configured":     os.Getenv("POSTGRES_HOST") != "",
+			"password_configured": os.Getenv("POSTGRES_PASSWORD") != "",
+		},
+		"minio": gin.H{
+			"endpoint_configured":   os.Getenv("MINIO_ENDPOINT") != "",
+			"access_key_configured": os.Getenv("MINIO_ACCESS_KEY") != "",
+			"secret_key_configured": os.Getenv("MINIO_SECRET_KEY") != "",
+			"bucket":                os.Getenv("MINIO_BUCKET_NAME"),
+		},
+		"cors": gin.H{
+			"origins_configured": os.Getenv("FRONTEND_ORIGINS") != "" || os.Getenv("FRONTEND_ORIGIN") != "",
+			"regex_configured":   os.Getenv("FRONTEND_ORIGIN_REGEX") != "",
+		},
+		"features": gin.H{
+			"enabled": EnabledFeatures(),
+		},
+		"logging": loggingStatus(),
+		"thresholds": gin.H{
+			"device_offline": deviceOfflineThreshold().String(),
+		},
+		"defaults": gin.H{
+			"metric_interval":     (time.Duration(DefaultMetricIntervalSeconds) * time.Second).String(),
+			"screenshot_interval": (time.Duration(DefaultScreenshotIntervalSeconds) * time.Second).String(),
+			"cpu_threshold":       DefaultCPUThreshold,
+			"memory_threshold":    DefaultMemoryThreshold,
+			"disk_threshold":      DefaultDiskThreshold,
+		},
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// loggingStatus reports whether structured logging is running as configured
+// or degraded to its stdout fallback, with the failure reason when degraded.
+func loggingStatus() gin.H {
+	degraded, reason := logging.Degraded()
+	status := gin.H{"degraded": degraded}
+	if degraded {
+		status["reason"] = reason
+	}
+	return status
+}
+
+// statTables maps the reported table name to the model it stores.
+// Kept in sync with the migration list in the database package.
+var statTables = []struct {
+	Name  string
+	Model interface{}
+}{
+	{"devices", &models.Device{}},
+	{"device_metrics", &models.DeviceMetric{}},
+	{"device_processes", &models.DeviceProcess{}},
+	{"device_activities", &models.DeviceActivity{}},
+	{"device_alerts", &models.DeviceAlert{}},
+	{"device_remote_commands", &models.DeviceRemoteCommand{}},
+	{"device_screenshots", &models.DeviceScreenshot{}},
+}
+
+// tableRowCount returns the row count for a table, preferring the planner's
+// cheap estimate from pg_class over a full scan. Freshly created tables (and
+// non-Postgres test databases) report no usable estimate, in which case we
+// fall back to an exact COUNT.
+func tableRowCount(name string, model interface{}) (int64, error) {
+	var estimate int64 = -1
+	if err := database.DB.Raw(
+		"SELECT reltuples::bigint FROM pg_class WHERE relname = ?", name,
+	).Scan(&estimate).Error; err == nil && estimate > 0 {
+		return estimate, nil
+	}
+
+	var exact int64
+	if err := database.DB.Model(model).Count(&exact).Error; err != nil {
+		return 0, err
+	}
+	return exact, nil
+}
+
+// GetTableStats reports row counts per model table
+// @Summary Get table row counts
+// @Description Report row counts for each model table, using fast approximate counts where available. Useful for watching table growth against retention policies.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]int64
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/stats/tables [get]
+func GetTableStats(c *gin.Context) {
+	stats := gin.H{}
+	for _, table := range statTables {
+		count, err := tableRowCount(table.Name, table.Model)
+		if err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		stats[table.Name] = count
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// orphanTables lists the telemetry tables scanned for device IDs that have no
+// matching row in devices. Kept in sync with the migration list in the
+// database package.
+var orphanTables = []string{
+	"device_metrics",
+	"device_processes",
+	"device_activities",
+	"device_alerts",
+	"device_remote_commands",
+	"device_screenshots",
+	"device_logs",
+}
+
+// OrphanedDevice is a device ID found in telemetry but absent from devices,
+// with the tables it appears in.
+type OrphanedDevice struct {
+	DeviceID uuid.UUID `json:"deviceid"`
+	Sources  []string  `json:"sources"`
+}
+
+// findOrphanedDevices scans every telemetry table for device IDs with no
+// registered device, merging the sources per ID.
+func findOrphanedDevices() ([]OrphanedDevice, error) {
+	sources := map[uuid.UUID][]string{}
+	for _, table := range orphanTables {
+		var ids []uuid.UUID
+		query := fmt.Sprintf(
+			"SELECT DISTINCT deviceid FROM %s WHERE deviceid NOT IN (SELECT deviceid FROM devices)", table)
+		if err := database.DB.Raw(query).Scan(&ids).Error; err != nil {
+			return nil, fmt.Errorf("failed to scan %s for orphans: %w", table, err)
+		}
+		for _, id := range ids {
+			sources[id] = append(sources[id], table)
+		}
+	}
+
+	orphans := make([]OrphanedDevice, 0, len(sources))
+	for id, tables := range sources {
+		orphans = append(orphans, OrphanedDevice{DeviceID: id, Sources: tables})
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].DeviceID.String() < orphans[j].DeviceID.String()
+	})
+	return orphans, nil
+}
+
+// GetOrphanedDevices lists telemetry device IDs with no registered device
+// @Summary List orphaned telemetry device IDs
+// @Description Return distinct device IDs present in telemetry tables (metrics, processes, activities, alerts, commands, screenshots, logs) but absent from the devices table, usually caused by agent misconfiguration.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/orphans [get]
+func GetOrphanedDevices(c *gin.Context) {
+	orphans, err := findOrphanedDevices()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orphans": orphans, "count": len(orphans)})
+}
+
+// RegisterOrphanedDevices creates stub device records for orphaned telemetry
+// @Summary Register stub devices for orphaned telemetry
+// @Description Auto-create a stub device record for every device ID found in telemetry but missing from the devices table, so the dangling data becomes reachable through the normal per-device endpoints.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/orphans/register [post]
+func RegisterOrphanedDevices(c *gin.Context) {
+	orphans, err := findOrphanedDevices()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	registered := make([]uuid.UUID, 0, len(orphans))
+	for _, orphan := range orphans {
+		stub := models.Device{
+			DeviceID:   orphan.DeviceID,
+			DeviceName: fmt.Sprintf("recovered-%s", orphan.DeviceID.String()[:8]),
+			DeviceType: "unknown",
+		}
+		if err := database.DB.Create(&stub).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		registered = append(registered, orphan.DeviceID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"registered": registered, "count": len(registered)})
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func listOrphans(t *testing.T) []OrphanedDevice {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/orphans", nil)
+
+	GetOrphanedDevices(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Orphans []OrphanedDevice `json:"orphans"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal orphans: %v", err)
+	}
+	return resp.Orphans
+}
+
+func TestOrphanDetectionAndReconciliation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// A registered device with telemetry must not be reported
+	registered := models.Device{DeviceID: uuid.New(), DeviceName: "registered", DeviceType: "server"}
+	if err := database.DB.Create(&registered).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+	if err := database.DB.Create(&models.DeviceMetric{
+		MetricID: uuid.New(), DeviceID: registered.DeviceID, CPUUsage: 10,
+	}).Error; err != nil {
+		t.Fatalf("failed to create metric: %v", err)
+	}
+
+	// Telemetry rows pointing at devices that were never registered
+	orphanA := uuid.New()
+	orphanB := uuid.New()
+	if err := database.DB.Create(&models.DeviceMetric{
+		MetricID: uuid.New(), DeviceID: orphanA, CPUUsage: 50,
+	}).Error; err != nil {
+		t.Fatalf("failed to create orphan metric: %v", err)
+	}
+	if err := database.DB.Create(&models.DeviceAlert{
+		AlertID: uuid.New(), DeviceID: orphanB, Level: "warning", AlertType: "cpu", Message: "m",
+	}).Error; err != nil {
+		t.Fatalf("failed to create orphan alert: %v", err)
+	}
+
+	orphans := listOrphans(t)
+	found := map[uuid.UUID][]string{}
+	for _, o := range orphans {
+		found[o.DeviceID] = o.Sources
+	}
+	if _, ok := found[registered.DeviceID]; ok {
+		t.Error("registered device reported as orphan")
+	}
+	if sources, ok := found[orphanA]; !ok || len(sources) != 1 || sources[0] != "device_metrics" {
+		t.Errorf("expected orphanA in device_metrics, got %v", found[orphanA])
+	}
+	if sources, ok := found[orphanB]; !ok || len(sources) != 1 || sources[0] != "device_alerts" {
+		t.Errorf("expected orphanB in device_alerts, got %v", found[orphanB])
+	}
+
+	// Registering creates stub devices for every orphan
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/admin/orphans/register", nil)
+
+	RegisterOrphanedDevices(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stub models.Device
+	if err := database.DB.Where("deviceid = ?", orphanA).First(&stub).Error; err != nil {
+		t.Fatalf("expected stub device for orphanA: %v", err)
+	}
+	if stub.DeviceType != "unknown" {
+		t.Errorf("expected stub device type 'unknown', got %q", stub.DeviceType)
+	}
+
+	if remaining := listOrphans(t); len(remaining) != 0 {
+		t.Errorf("expected no orphans after reconciliation, got %v", remaining)
+	}
+}
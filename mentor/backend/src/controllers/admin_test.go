@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentor-backend/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetAdminConfigRedactsSecrets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Setenv("DEVICES_API_URL", "http://devices-backend:8080")
+	t.Setenv("POSTGRES_PASSWORD", "super-secret-password")
+	t.Setenv("MINIO_SECRET_KEY", "super-secret-minio-key")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/config", nil)
+
+	GetAdminConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, secret := range []string{"super-secret-password", "super-secret-minio-key", "http://devices-backend:8080"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("config response leaked secret value %q", secret)
+		}
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+
+	forwarding, ok := config["forwarding"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected forwarding section in config")
+	}
+	if forwarding["enabled"] != true {
+		t.Errorf("expected forwarding.enabled true when DEVICES_API_URL is set, got %v", forwarding["enabled"])
+	}
+
+	database, ok := config["database"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected database section in config")
+	}
+	if database["password_configured"] != true {
+		t.Errorf("expected database.password_configured true, got %v", database["password_configured"])
+	}
+
+	thresholds, ok := config["thresholds"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected thresholds section in config")
+	}
+	if thresholds["device_offline"] != deviceOfflineThreshold().String() {
+		t.Errorf("expected device_offline %q, got %v", deviceOfflineThreshold().String(), thresholds["device_offline"])
+	}
+}
+
+func TestGetAdminConfigForwardingDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Setenv("DEVICES_API_URL", "")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/config", nil)
+
+	GetAdminConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	forwarding := config["forwarding"].(map[string]interface{})
+	if forwarding["enabled"] != false {
+		t.Errorf("expected forwarding.enabled false when DEVICES_API_URL is unset, got %v", forwarding["enabled"])
+	}
+}
+
+func TestGetTableStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/stats/tables", nil)
+
+	GetTableStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats map[string]int64
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+
+	expected := []string{
+		"devices", "device_metrics", "device_processes", "device_activities",
+		"device_alerts", "device_remote_commands", "device_screenshots",
+	}
+	for _, table := range expected {
+		if _, found := stats[table]; !found {
+			t.Errorf("expected row count for table %q", table)
+		}
+	}
+}
+
+func TestGetAdminConfigReportsDegradedLogging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Point structured logging at an unwritable path to force the fallback.
+	t.Setenv("LOG_FILE", "/proc/nonexistent/app.log")
+	if err := logging.InitLogger(); err == nil {
+		t.Fatal("expected InitLogger to fail for an unwritable path")
+	}
+	t.Cleanup(func() {
+		t.Setenv("LOG_FILE", "")
+		if err := logging.InitLogger(); err != nil {
+			t.Fatalf("failed to reset logger: %v", err)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/config", nil)
+
+	GetAdminConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	loggingSection, ok := config["logging"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected logging section in config")
+	}
+	if loggingSection["degraded"] != true {
+		t.Errorf("expected logging.degraded true, got %v", loggingSection["degraded"])
+	}
+	if reason, _ := loggingSection["reason"].(string); reason == "" {
+		t.Error("expected a degradation reason")
+	}
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AcknowledgeAlert marks an alert as handled by an operator
+// @Summary Acknowledge an alert
+// @Description Mark the alert as handled. Acknowledged alerts drop out of open-alert filters and the fleet summary's critical count, but keep their data. The optional by query parameter records who acknowledged.
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Alert ID (UUID)"
+// @Param by query string false "Operator acknowledging the alert"
+// @Success 200 {object} models.DeviceAlert
+// @Failure 400 {object} map[string]string "Bad request - invalid alert ID"
+// @Failure 404 {object} map[string]string "Alert not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /alerts/{id}/acknowledge [put]
+func AcknowledgeAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid alert ID")
+		return
+	}
+
+	var alert models.DeviceAlert
+	if err := database.DB.First(&alert, "alertid = ?", alertID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "alert not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	alert.Acknowledged = true
+	alert.AcknowledgedAt = time.Now()
+	alert.AcknowledgedBy = c.Query("by")
+	if err := database.DB.Model(&alert).Updates(map[string]interface{}{
+		"acknowledged":    true,
+		"acknowledged_at": alert.AcknowledgedAt,
+		"acknowledged_by": alert.AcknowledgedBy,
+	}).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func acknowledgeAlert(t *testing.T, alertID string, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: alertID}}
+	c.Request, _ = http.NewRequest("PUT", "/alerts/"+alertID+"/acknowledge"+query, nil)
+
+	AcknowledgeAlert(c)
+	return w
+}
+
+func TestAcknowledgeAlert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	open := models.DeviceAlert{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now(), Level: "critical", AlertType: "cpu"}
+	handled := models.DeviceAlert{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now(), Level: "critical", AlertType: "disk"}
+	for _, alert := range []models.DeviceAlert{open, handled} {
+		if err := database.DB.Create(&alert).Error; err != nil {
+			t.Fatalf("failed to create alert: %v", err)
+		}
+	}
+
+	if w := acknowledgeAlert(t, handled.AlertID.String(), "?by=sam"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stored models.DeviceAlert
+	if err := database.DB.First(&stored, "alertid = ?", handled.AlertID).Error; err != nil {
+		t.Fatalf("failed to load alert: %v", err)
+	}
+	if !stored.Acknowledged || stored.AcknowledgedBy != "sam" || stored.AcknowledgedAt.IsZero() {
+		t.Errorf("expected acknowledgement recorded, got %+v", stored)
+	}
+
+	// acknowledged=false narrows the listing to the open alert.
+	alerts := listDeviceAlerts(t, deviceID, "?acknowledged=false")
+	if len(alerts) != 1 || alerts[0].AlertID != open.AlertID {
+		t.Errorf("expected only the open alert, got %+v", alerts)
+	}
+
+	// The fleet summary's critical count skips acknowledged alerts.
+	if summary := getFleetSummary(t); summary.CriticalAlerts != 1 {
+		t.Errorf("expected 1 unacknowledged critical alert in summary, got %d", summary.CriticalAlerts)
+	}
+}
+
+func TestAcknowledgeAlertNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := acknowledgeAlert(t, uuid.New().String(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown alert, got %d", w.Code)
+	}
+	if w := acknowledgeAlert(t, "not-a-uuid", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed ID, got %d", w.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultAlertContextWindow is how far around the alert timestamp metrics are
+// fetched when the caller does not pass a window.
+const DefaultAlertContextWindow = 10 * time.Minute
+
+// GetAlertContext returns an alert together with the surrounding metrics
+// @Summary Get alert investigation context
+// @Description Fetch the alert and the device's metrics from one window before to one window after the alert timestamp, so analysts see what the device was doing when the alert fired.
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Alert ID (UUID)"
+// @Param window query string false "Context window around the alert timestamp, e.g. 10m (default)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid alert ID or window"
+// @Failure 404 {object} map[string]string "Alert not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /alerts/{id}/context [get]
+func GetAlertContext(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid alert ID")
+		return
+	}
+
+	window := DefaultAlertContextWindow
+	if raw := c.Query("window"); raw != "" {
+		parsed, err := parseRangeDuration(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid window parameter, e.g. 10m")
+			return
+		}
+		window = parsed
+	}
+
+	var alert models.DeviceAlert
+	if err := database.Read().Where("alertid = ?", alertID).First(&alert).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "alert not found")
+		} else {
+			RespondInternalError(c, err)
+		}
+		return
+	}
+
+	from := alert.Timestamp.Add(-window)
+	to := alert.Timestamp.Add(window)
+	metrics := make([]models.DeviceMetric, 0)
+	if err := database.Read().
+		Where("deviceid = ? AND timestamp >= ? AND timestamp <= ?", alert.DeviceID, from, to).
+		Order("timestamp asc").
+		Find(&metrics).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"alert":   alert,
+		"window":  window.String(),
+		"from":    from,
+		"to":      to,
+		"metrics": metrics,
+	})
+}
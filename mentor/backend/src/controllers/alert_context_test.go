@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getAlertContext(t *testing.T, alertID string, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: alertID}}
+	c.Request, _ = http.NewRequest("GET", "/alerts/"+alertID+"/context"+query, nil)
+
+	GetAlertContext(c)
+	return w
+}
+
+func TestGetAlertContextReturnsSurroundingMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	alertAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	alert := models.DeviceAlert{
+		AlertID: uuid.New(), DeviceID: deviceID,
+		Timestamp: alertAt, Level: "critical", AlertType: "cpu", Message: "cpu spike",
+	}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to seed alert: %v", err)
+	}
+
+	// Two metrics inside the 10m window, two outside
+	offsets := []time.Duration{-30 * time.Minute, -5 * time.Minute, 5 * time.Minute, 30 * time.Minute}
+	for _, off := range offsets {
+		m := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: alertAt.Add(off), CPUUsage: 90,
+		}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := getAlertContext(t, alert.AlertID.String(), "?window=10m")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Alert   models.DeviceAlert    `json:"alert"`
+		Metrics []models.DeviceMetric `json:"metrics"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Alert.AlertID != alert.AlertID {
+		t.Errorf("expected alert %s, got %s", alert.AlertID, resp.Alert.AlertID)
+	}
+	if len(resp.Metrics) != 2 {
+		t.Fatalf("expected 2 metrics inside the window, got %d", len(resp.Metrics))
+	}
+	for _, m := range resp.Metrics {
+		if m.Timestamp.Before(alertAt.Add(-10*time.Minute)) || m.Timestamp.After(alertAt.Add(10*time.Minute)) {
+			t.Errorf("metric at %v falls outside the window around %v", m.Timestamp, alertAt)
+		}
+	}
+}
+
+func TestGetAlertContextValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := getAlertContext(t, uuid.New().String(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown alert, got %d", w.Code)
+	}
+	if w := getAlertContext(t, "not-a-uuid", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed ID, got %d", w.Code)
+	}
+
+	alert := models.DeviceAlert{AlertID: uuid.New(), DeviceID: uuid.New(), Timestamp: time.Now()}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to seed alert: %v", err)
+	}
+	if w := getAlertContext(t, alert.AlertID.String(), "?window=bogus"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for bad window, got %d", w.Code)
+	}
+}
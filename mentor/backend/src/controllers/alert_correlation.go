@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCorrelatedAlerts fetches all alerts sharing a correlation ID
+// @Summary Get correlated alerts
+// @Description Fetch every alert tagged with the given correlation ID across all devices, oldest first. Useful for reconstructing an incident from its cascade of alerts.
+// @Tags alerts
+// @Produce json
+// @Param correlation_id query string true "Correlation ID shared by the incident's alerts"
+// @Success 200 {array} models.DeviceAlert
+// @Failure 400 {object} map[string]string "Bad request - missing correlation_id"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /alerts [get]
+func GetCorrelatedAlerts(c *gin.Context) {
+	correlationID := strings.TrimSpace(c.Query("correlation_id"))
+	if correlationID == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "correlation_id is required")
+		return
+	}
+
+	alerts := make([]models.DeviceAlert, 0)
+	if err := database.Read().Where("correlation_id = ?", correlationID).
+		Order("timestamp asc").
+		Find(&alerts).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
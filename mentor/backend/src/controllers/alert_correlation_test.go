@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetCorrelatedAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceA := uuid.New()
+	deviceB := uuid.New()
+	correlationID := uuid.New().String()
+
+	alerts := []models.DeviceAlert{
+		{AlertID: uuid.New(), DeviceID: deviceA, Level: "critical", AlertType: "cpu", Message: "cpu pegged", CorrelationID: correlationID},
+		{AlertID: uuid.New(), DeviceID: deviceB, Level: "error", AlertType: "memory", Message: "oom cascade", CorrelationID: correlationID},
+		{AlertID: uuid.New(), DeviceID: deviceA, Level: "warning", AlertType: "disk", Message: "unrelated"},
+	}
+	for _, a := range alerts {
+		if err := database.DB.Create(&a).Error; err != nil {
+			t.Fatalf("failed to seed alert: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts?correlation_id="+correlationID, nil)
+
+	GetCorrelatedAlerts(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var fetched []models.DeviceAlert
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to unmarshal alerts: %v", err)
+	}
+	if len(fetched) != 2 {
+		t.Fatalf("expected 2 correlated alerts, got %d", len(fetched))
+	}
+	devices := map[uuid.UUID]bool{}
+	for _, a := range fetched {
+		if a.CorrelationID != correlationID {
+			t.Errorf("expected correlation ID %s, got %s", correlationID, a.CorrelationID)
+		}
+		devices[a.DeviceID] = true
+	}
+	if !devices[deviceA] || !devices[deviceB] {
+		t.Error("expected correlated alerts spanning both devices")
+	}
+}
+
+func TestGetCorrelatedAlertsMissingID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts", nil)
+
+	GetCorrelatedAlerts(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without correlation_id, got %d", w.Code)
+	}
+}
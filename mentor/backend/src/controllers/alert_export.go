@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"mentor-backend/database"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ExportedAlert is a device alert enriched with device identity for SIEM ingestion
+type ExportedAlert struct {
+	AlertID        uuid.UUID `json:"alertid"`
+	DeviceID       uuid.UUID `json:"deviceid"`
+	DeviceName     string    `json:"device_name"`
+	DeviceLocation string    `json:"device_location"`
+	Timestamp      time.Time `json:"timestamp"`
+	Level          string    `json:"level"`
+	AlertType      string    `json:"alert_type"`
+	Message        string    `json:"message"`
+	Value          float64   `json:"value"`
+	Threshold      float64   `json:"threshold"`
+}
+
+// cefSeverity maps alert levels to the 0-10 CEF severity scale
+func cefSeverity(level string) int {
+	switch strings.ToLower(level) {
+	case "critical":
+		return 10
+	case "error":
+		return 8
+	case "warning":
+		return 6
+	default:
+		return 3
+	}
+}
+
+// cefEscape escapes the characters that are significant in CEF header fields
+func cefEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return s
+}
+
+// ExportAlerts streams alerts in a SIEM-friendly format
+// @Summary Export alerts for SIEM ingestion
+// @Description Stream alerts enriched with device name and location as NDJSON (default) or CEF. Supports time-range, level, and type filters.
+// @Tags alerts
+// @Produce plain
+// @Param format query string false "Export format: ndjson or cef" default(ndjson)
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Param level query string false "Filter by alert level"
+// @Param type query string false "Filter by alert type"
+// @Success 200 {string} string "Streamed alert lines"
+// @Failure 400 {object} map[string]string "Bad request - invalid format or time range"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /alerts/export [get]
+func ExportAlerts(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", "ndjson"))
+	if format != "ndjson" && format != "cef" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid format: must be ndjson or cef")
+		return
+	}
+
+	query := database.DB.Table("device_alerts").
+		Select("device_alerts.alertid, device_alerts.deviceid, devices.device_name, devices.device_location, " +
+			"device_alerts.timestamp, device_alerts.level, device_alerts.alert_type, device_alerts.message, " +
+			"device_alerts.value, device_alerts.threshold").
+		Joins("LEFT JOIN devices ON devices.deviceid = device_alerts.deviceid").
+		Order("device_alerts.timestamp asc")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("device_alerts.timestamp >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("device_alerts.timestamp <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_EXPORT"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if level := c.Query("level"); level != "" {
+		query = query.Where("LOWER(device_alerts.level) = ?", strings.ToLower(level))
+	}
+	if alertType := c.Query("type"); alertType != "" {
+		query = query.Where("LOWER(device_alerts.alert_type) = ?", strings.ToLower(alertType))
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("Error closing alert export rows: %v\n", err)
+		}
+	}()
+
+	if format == "cef" {
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+	}
+	c.Status(http.StatusOK)
+
+	// Stream one line per alert rather than buffering the full result set,
+	// so large exports don't hold every row in memory
+	encoder := json.NewEncoder(c.Writer)
+	for rows.Next() {
+		var alert ExportedAlert
+		if err := database.DB.ScanRows(rows, &alert); err != nil {
+			fmt.Printf("Error scanning alert row during export: %v\n", err)
+			return
+		}
+
+		if format == "cef" {
+			line := fmt.Sprintf("CEF:0|Raqeem|Mentor|1.0|%s|%s|%d|dvc=%s dvchost=%s rt=%s cs1=%s cs1Label=level cfp1=%.2f cfp1Label=value cfp2=%.2f cfp2Label=threshold\n",
+				cefEscape(alert.AlertType), cefEscape(alert.Message), cefSeverity(alert.Level),
+				alert.DeviceID, cefEscape(alert.DeviceName), alert.Timestamp.Format(time.RFC3339),
+				alert.Level, alert.Value, alert.Threshold)
+			if _, err := c.Writer.WriteString(line); err != nil {
+				return
+			}
+		} else {
+			if err := encoder.Encode(alert); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
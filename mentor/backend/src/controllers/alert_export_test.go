@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func seedExportAlerts(t *testing.T, deviceID uuid.UUID) {
+	t.Helper()
+
+	device := models.Device{DeviceID: deviceID, DeviceName: "export-device", DeviceLocation: "lab"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	alerts := []models.DeviceAlert{
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now().Add(-time.Minute), Level: "critical", AlertType: "cpu", Message: "CPU pegged", Value: 99, Threshold: 90},
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now().Add(-time.Minute), Level: "info", AlertType: "disk", Message: "Disk ok", Value: 10, Threshold: 95},
+	}
+	for i := range alerts {
+		if err := database.DB.Create(&alerts[i]).Error; err != nil {
+			t.Fatalf("failed to seed alert: %v", err)
+		}
+	}
+}
+
+func TestExportAlertsNDJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seedExportAlerts(t, deviceID)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts/export?format=ndjson", nil)
+
+	ExportAlerts(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected at least 2 NDJSON lines, got %d", len(lines))
+	}
+	// Each line must be a standalone JSON object with the enriched fields
+	for _, line := range lines {
+		var alert ExportedAlert
+		if err := json.Unmarshal([]byte(line), &alert); err != nil {
+			t.Fatalf("line is not valid JSON: %v, line: %s", err, line)
+		}
+		if alert.DeviceID == deviceID && alert.DeviceName != "export-device" {
+			t.Errorf("expected enriched device_name 'export-device', got %q", alert.DeviceName)
+		}
+	}
+}
+
+func TestExportAlertsLevelFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seedExportAlerts(t, deviceID)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts/export?level=critical", nil)
+
+	ExportAlerts(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(w.Body.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var alert ExportedAlert
+		if err := json.Unmarshal([]byte(line), &alert); err != nil {
+			t.Fatalf("line is not valid JSON: %v", err)
+		}
+		if !strings.EqualFold(alert.Level, "critical") {
+			t.Errorf("expected only critical alerts, got level %q", alert.Level)
+		}
+	}
+}
+
+func TestExportAlertsCEF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seedExportAlerts(t, deviceID)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts/export?format=cef&level=critical", nil)
+
+	ExportAlerts(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	body := strings.TrimSpace(w.Body.String())
+	if body == "" {
+		t.Fatal("expected CEF output, got empty body")
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(line, "CEF:0|Raqeem|Mentor|") {
+			t.Errorf("expected CEF header prefix, got line: %s", line)
+		}
+	}
+}
+
+func TestExportAlertsInvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/alerts/export?format=xml", nil)
+
+	ExportAlerts(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid format, got %d", w.Code)
+	}
+}
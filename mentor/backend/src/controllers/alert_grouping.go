@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AlertGroup is one collapsed entry in the grouped alert view: all alerts of
+// one type for the device, summarized by count and first/last occurrence.
+type AlertGroup struct {
+	AlertType string    `json:"alert_type"`
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// MaxLevel is the most severe level seen in the group, so a flood of
+	// warnings does not bury one critical.
+	MaxLevel string `json:"max_level"`
+}
+
+// alertLevelRank orders levels for MaxLevel selection; unknown levels rank
+// lowest so they never displace a known severity.
+func alertLevelRank(level string) int {
+	switch level {
+	case "critical":
+		return 3
+	case "error":
+		return 2
+	case "warning":
+		return 1
+	}
+	return 0
+}
+
+// respondGroupedAlerts collapses the already-filtered alert query into one
+// row per alert type. The snooze/acknowledgement/time filters applied by the
+// caller carry over, so the grouped view and the flat view always agree on
+// which alerts are in scope.
+func respondGroupedAlerts(c *gin.Context, query *gorm.DB) {
+	var rows []struct {
+		AlertType string
+		Level     string
+		Timestamp time.Time
+	}
+	if err := query.Model(&models.DeviceAlert{}).
+		Select("alert_type, level, timestamp").
+		Find(&rows).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Aggregate in Go rather than SQL: MaxLevel is a rank over strings, which
+	// portable SQL cannot express cleanly.
+	byType := map[string]*AlertGroup{}
+	order := []string{}
+	for _, row := range rows {
+		group, ok := byType[row.AlertType]
+		if !ok {
+			group = &AlertGroup{
+				AlertType: row.AlertType,
+				FirstSeen: row.Timestamp,
+				LastSeen:  row.Timestamp,
+				MaxLevel:  row.Level,
+			}
+			byType[row.AlertType] = group
+			order = append(order, row.AlertType)
+		}
+		group.Count++
+		if row.Timestamp.Before(group.FirstSeen) {
+			group.FirstSeen = row.Timestamp
+		}
+		if row.Timestamp.After(group.LastSeen) {
+			group.LastSeen = row.Timestamp
+		}
+		if alertLevelRank(row.Level) > alertLevelRank(group.MaxLevel) {
+			group.MaxLevel = row.Level
+		}
+	}
+
+	groups := make([]AlertGroup, 0, len(order))
+	for _, alertType := range order {
+		groups = append(groups, *byType[alertType])
+	}
+	// Most recently active groups first, matching the flat list's ordering.
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].LastSeen.After(groups[j].LastSeen)
+	})
+
+	c.JSON(http.StatusOK, groups)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceAlertGroupedByType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	base := time.Now().Add(-time.Hour)
+	seed := []models.DeviceAlert{
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: base, Level: "warning", AlertType: "cpu"},
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: base.Add(10 * time.Minute), Level: "critical", AlertType: "cpu"},
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: base.Add(20 * time.Minute), Level: "warning", AlertType: "cpu"},
+		{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: base.Add(30 * time.Minute), Level: "warning", AlertType: "disk"},
+	}
+	for _, alert := range seed {
+		if err := database.DB.Create(&alert).Error; err != nil {
+			t.Fatalf("failed to create alert: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/alerts?group_by=type", nil)
+
+	GetDeviceAlert(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var groups []AlertGroup
+	if err := json.Unmarshal(w.Body.Bytes(), &groups); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d: %+v", len(groups), groups)
+	}
+	// disk fired last, so it leads.
+	if groups[0].AlertType != "disk" || groups[0].Count != 1 {
+		t.Errorf("expected disk group first with count 1, got %+v", groups[0])
+	}
+	cpu := groups[1]
+	if cpu.AlertType != "cpu" || cpu.Count != 3 {
+		t.Fatalf("expected cpu group with count 3, got %+v", cpu)
+	}
+	if !cpu.FirstSeen.Equal(seed[0].Timestamp) || !cpu.LastSeen.Equal(seed[2].Timestamp) {
+		t.Errorf("expected first/last timestamps of the cpu alerts, got %+v", cpu)
+	}
+	if cpu.MaxLevel != "critical" {
+		t.Errorf("expected the critical to surface as max level, got %q", cpu.MaxLevel)
+	}
+
+	// Expanding a group via ?type= returns its raw alerts.
+	if alerts := listDeviceAlerts(t, deviceID, "?type=cpu"); len(alerts) != 3 {
+		t.Errorf("expected 3 cpu alerts when expanding, got %d", len(alerts))
+	}
+}
+
+func TestGetDeviceAlertGroupByValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/alerts?group_by=level", nil)
+
+	GetDeviceAlert(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unsupported group_by, got %d", w.Code)
+	}
+}
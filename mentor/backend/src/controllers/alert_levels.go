@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// alertTypeLevels parses the ALERT_TYPE_LEVELS environment variable, a
+// comma-separated list of type=level pairs (e.g. "disk_full=critical,
+// cpu=warning") mapping known alert types to their canonical severity.
+func alertTypeLevels() map[string]string {
+	levels := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("ALERT_TYPE_LEVELS"), ",") {
+		alertType, level, ok := strings.Cut(pair, "=")
+		alertType = strings.ToLower(strings.TrimSpace(alertType))
+		level = strings.ToLower(strings.TrimSpace(level))
+		if !ok || alertType == "" || level == "" {
+			continue
+		}
+		levels[alertType] = level
+	}
+	return levels
+}
+
+// normalizeAlertLevel returns the canonical level for an alert type when one
+// is configured, otherwise the agent-supplied level. Overrides are logged so
+// miscalibrated agents can be tracked down.
+func normalizeAlertLevel(alertType, level string) string {
+	canonical, ok := alertTypeLevels()[strings.ToLower(alertType)]
+	if !ok || strings.EqualFold(canonical, level) {
+		return level
+	}
+	fmt.Printf("Overriding alert level for type %s: agent sent %q, mapping says %q\n", alertType, level, canonical)
+	return canonical
+}
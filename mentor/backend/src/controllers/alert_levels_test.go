@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func submitAlert(t *testing.T, alert models.DeviceAlert) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(alert)
+	if err != nil {
+		t.Fatalf("failed to marshal alert: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: alert.DeviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+alert.DeviceID.String()+"/alerts", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	ReportAlert(c)
+	return w
+}
+
+func TestNormalizeAlertLevel(t *testing.T) {
+	t.Setenv("ALERT_TYPE_LEVELS", "disk_full=critical, cpu=warning")
+
+	if got := normalizeAlertLevel("disk_full", "info"); got != "critical" {
+		t.Errorf("expected mapped type overridden to critical, got %q", got)
+	}
+	if got := normalizeAlertLevel("DISK_FULL", "critical"); got != "critical" {
+		t.Errorf("expected case-insensitive match to keep critical, got %q", got)
+	}
+	if got := normalizeAlertLevel("memory", "info"); got != "info" {
+		t.Errorf("expected unknown type to keep agent level, got %q", got)
+	}
+}
+
+func TestReportAlertNormalizesMappedLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("ALERT_TYPE_LEVELS", "disk_full=critical")
+
+	alert := models.DeviceAlert{
+		DeviceID:  uuid.New(),
+		AlertType: "disk_full",
+		Level:     "info",
+		Message:   "disk is full",
+	}
+	w := submitAlert(t, alert)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stored models.DeviceAlert
+	if err := database.DB.First(&stored, "deviceid = ?", alert.DeviceID).Error; err != nil {
+		t.Fatalf("failed to load stored alert: %v", err)
+	}
+	if stored.Level != "critical" {
+		t.Errorf("expected stored level normalized to critical, got %q", stored.Level)
+	}
+}
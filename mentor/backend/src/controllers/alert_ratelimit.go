@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// alertRateLimit returns the allowed alert rate per (device, alert type) in
+// alerts per minute, from ALERT_RATE_LIMIT. Zero (the default) disables rate
+// limiting.
+func alertRateLimit() int {
+	if raw := os.Getenv("ALERT_RATE_LIMIT"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// alertBucket is a token bucket; capacity and refill rate both equal the
+// configured per-minute limit.
+type alertBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type alertBucketKey struct {
+	DeviceID  uuid.UUID
+	AlertType string
+}
+
+var (
+	alertBucketsMu sync.Mutex
+	alertBuckets   = map[alertBucketKey]*alertBucket{}
+)
+
+// allowAlert consumes one token from the (device, alert type) bucket,
+// reporting whether the alert is within the configured rate. Always true when
+// rate limiting is disabled.
+func allowAlert(deviceID uuid.UUID, alertType string) bool {
+	limit := alertRateLimit()
+	if limit == 0 {
+		return true
+	}
+
+	alertBucketsMu.Lock()
+	defer alertBucketsMu.Unlock()
+
+	key := alertBucketKey{DeviceID: deviceID, AlertType: alertType}
+	now := time.Now()
+	bucket, ok := alertBuckets[key]
+	if !ok {
+		bucket = &alertBucket{tokens: float64(limit), last: now}
+		alertBuckets[key] = bucket
+	}
+
+	// Refill proportionally to elapsed time, capped at the burst size
+	bucket.tokens += now.Sub(bucket.last).Minutes() * float64(limit)
+	if bucket.tokens > float64(limit) {
+		bucket.tokens = float64(limit)
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
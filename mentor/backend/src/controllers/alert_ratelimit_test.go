@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func reportAlert(t *testing.T, deviceID uuid.UUID, alertType string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	body := `{"deviceid": "` + deviceID.String() + `", "level": "warning", "alert_type": "` + alertType + `", "message": "m"}`
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/alerts", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	ReportAlert(c)
+	return w
+}
+
+func TestAlertRateLimitSuppressesFlood(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("ALERT_RATE_LIMIT", "3")
+
+	deviceID := uuid.New()
+	accepted, suppressed := 0, 0
+	for i := 0; i < 6; i++ {
+		switch w := reportAlert(t, deviceID, "cpu"); w.Code {
+		case http.StatusOK:
+			accepted++
+		case http.StatusTooManyRequests:
+			suppressed++
+		default:
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+	}
+	if accepted != 3 || suppressed != 3 {
+		t.Errorf("expected 3 accepted and 3 suppressed, got %d/%d", accepted, suppressed)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceAlert{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count alerts: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 stored alerts, got %d", count)
+	}
+
+	// A different alert type has its own bucket
+	if w := reportAlert(t, deviceID, "disk"); w.Code != http.StatusOK {
+		t.Errorf("expected different alert type to pass, got %d", w.Code)
+	}
+}
+
+func TestAlertRateLimitOffByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("ALERT_RATE_LIMIT", "")
+
+	deviceID := uuid.New()
+	for i := 0; i < 5; i++ {
+		if w := reportAlert(t, deviceID, "cpu"); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 with rate limiting off, got %d", w.Code)
+		}
+	}
+}
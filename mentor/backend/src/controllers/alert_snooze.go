@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SnoozeAlert silences one alert until a given time
+// @Summary Snooze an alert
+// @Description Hide the alert from default listings until the given time, after which it re-surfaces as active. Snoozing is not resolution: the alert keeps its data and comes back on its own. The until time must be in the future.
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Alert ID (UUID)"
+// @Param until query string true "Snooze expiry in RFC3339 format, must be in the future"
+// @Success 200 {object} models.DeviceAlert
+// @Failure 400 {object} map[string]string "Bad request - invalid ID or until time"
+// @Failure 404 {object} map[string]string "Alert not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /alerts/{id}/snooze [post]
+func SnoozeAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid alert ID")
+		return
+	}
+
+	until, err := time.Parse(time.RFC3339, c.Query("until"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "until must be a valid RFC3339 timestamp")
+		return
+	}
+	if !until.After(time.Now()) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "until must be in the future")
+		return
+	}
+
+	var alert models.DeviceAlert
+	if err := database.DB.First(&alert, "alertid = ?", alertID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "alert not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	alert.SnoozedUntil = &until
+	if err := database.DB.Model(&alert).Update("snoozed_until", until).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
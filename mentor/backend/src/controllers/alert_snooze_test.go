@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func snoozeAlert(t *testing.T, alertID string, until string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: alertID}}
+	c.Request, _ = http.NewRequest("POST", "/alerts/"+alertID+"/snooze?until="+until, nil)
+
+	SnoozeAlert(c)
+	return w
+}
+
+func listDeviceAlerts(t *testing.T, deviceID uuid.UUID, query string) []models.DeviceAlert {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/alerts"+query, nil)
+
+	GetDeviceAlert(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 listing alerts, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var alerts []models.DeviceAlert
+	if err := json.Unmarshal(w.Body.Bytes(), &alerts); err != nil {
+		t.Fatalf("failed to unmarshal alerts: %v", err)
+	}
+	return alerts
+}
+
+func TestSnoozeAlertHidesAndResurfaces(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	alert := models.DeviceAlert{
+		AlertID:   uuid.New(),
+		DeviceID:  deviceID,
+		Timestamp: time.Now(),
+		Level:     "warning",
+		AlertType: "cpu",
+		Message:   "CPU high",
+	}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to create alert: %v", err)
+	}
+
+	until := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	if w := snoozeAlert(t, alert.AlertID.String(), until); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 snoozing, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	if alerts := listDeviceAlerts(t, deviceID, ""); len(alerts) != 0 {
+		t.Errorf("expected snoozed alert hidden from default list, got %d alerts", len(alerts))
+	}
+	if alerts := listDeviceAlerts(t, deviceID, "?include_snoozed=true"); len(alerts) != 1 {
+		t.Errorf("expected include_snoozed to list the alert, got %d alerts", len(alerts))
+	} else if alerts[0].SnoozedUntil == nil {
+		t.Error("expected snoozed_until set on the listed alert")
+	}
+
+	// Simulate the snooze expiring: the alert re-surfaces as active.
+	expired := time.Now().Add(-time.Minute)
+	if err := database.DB.Model(&models.DeviceAlert{}).
+		Where("alertid = ?", alert.AlertID).
+		Update("snoozed_until", expired).Error; err != nil {
+		t.Fatalf("failed to expire snooze: %v", err)
+	}
+	if alerts := listDeviceAlerts(t, deviceID, ""); len(alerts) != 1 {
+		t.Errorf("expected expired snooze to re-surface the alert, got %d alerts", len(alerts))
+	}
+}
+
+func TestSnoozeAlertValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	alert := models.DeviceAlert{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now(), Level: "warning", AlertType: "cpu"}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to create alert: %v", err)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	if w := snoozeAlert(t, alert.AlertID.String(), past); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for past until, got %d", w.Code)
+	}
+	if w := snoozeAlert(t, alert.AlertID.String(), "not-a-time"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed until, got %d", w.Code)
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	if w := snoozeAlert(t, uuid.New().String(), future); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown alert, got %d", w.Code)
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Alert trend defaults and bounds
+const (
+	// DefaultAlertTrendWindow is the lookback used when from/to are omitted
+	DefaultAlertTrendWindow = 7 * 24 * time.Hour
+	// DefaultAlertTrendInterval is the bucket width used when interval is omitted
+	DefaultAlertTrendInterval = 24 * time.Hour
+	// MaxAlertTrendBuckets caps the number of buckets a single query may produce
+	MaxAlertTrendBuckets = 500
+)
+
+// AlertTrendBucket is one time bucket of the fleet-wide alert trend
+type AlertTrendBucket struct {
+	BucketStart time.Time        `json:"bucket_start"`
+	Counts      map[string]int64 `json:"counts"`
+	Total       int64            `json:"total"`
+}
+
+// GetAlertTrend returns fleet-wide alert counts bucketed over time
+// @Summary Get fleet alert trend
+// @Description Count alerts across all devices per time bucket, broken down by level. Buckets with no alerts are returned as zeros so charts render continuous series.
+// @Tags alerts
+// @Produce json
+// @Param from query string false "Start of time range (RFC3339, default 7 days ago)"
+// @Param to query string false "End of time range (RFC3339, default now)"
+// @Param interval query string false "Bucket width (e.g. 1d, 6h)" default(1d)
+// @Success 200 {array} controllers.AlertTrendBucket
+// @Failure 400 {object} map[string]string "Bad request - invalid time range or interval"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dashboard/alerts/trend [get]
+func GetAlertTrend(c *gin.Context) {
+	to := time.Now()
+	if raw := c.Query("to"); raw != "" {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		to = ts
+	}
+	from := to.Add(-DefaultAlertTrendWindow)
+	if raw := c.Query("from"); raw != "" {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		from = ts
+	}
+	if !from.Before(to) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "from must be before to")
+		return
+	}
+	if err := checkQueryRange(from, to, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	interval := DefaultAlertTrendInterval
+	if raw := c.Query("interval"); raw != "" {
+		parsed, err := parseRangeDuration(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid interval parameter: must be a positive duration like 1d or 6h")
+			return
+		}
+		interval = parsed
+	}
+
+	bucketCount := int(to.Sub(from)/interval) + 1
+	if bucketCount > MaxAlertTrendBuckets {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "too many buckets: widen the interval or narrow the time range")
+		return
+	}
+
+	// One grouped pass over the range; zero-filling happens in Go below
+	var rows []struct {
+		Bucket int64
+		Level  string
+		Count  int64
+	}
+	if err := database.Read().Table("device_alerts").
+		Select("FLOOR(EXTRACT(EPOCH FROM (timestamp - ?::timestamptz)) / ?) AS bucket, LOWER(level) AS level, COUNT(*) AS count",
+			from, interval.Seconds()).
+		Where("timestamp >= ? AND timestamp < ?", from, to).
+		Group("bucket, LOWER(level)").
+		Scan(&rows).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	levels := []string{"info", "warning", "error", "critical"}
+	trend := make([]AlertTrendBucket, bucketCount)
+	for i := range trend {
+		counts := make(map[string]int64, len(levels))
+		for _, l := range levels {
+			counts[l] = 0
+		}
+		trend[i] = AlertTrendBucket{
+			BucketStart: from.Add(time.Duration(i) * interval),
+			Counts:      counts,
+		}
+	}
+	for _, row := range rows {
+		if row.Bucket < 0 || row.Bucket >= int64(bucketCount) {
+			continue
+		}
+		trend[row.Bucket].Counts[row.Level] += row.Count
+		trend[row.Bucket].Total += row.Count
+	}
+
+	c.JSON(http.StatusOK, trend)
+}
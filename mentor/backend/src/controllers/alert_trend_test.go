@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetAlertTrend(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceA := uuid.New()
+	deviceB := uuid.New()
+	base := time.Now().Add(-3 * 24 * time.Hour).Truncate(time.Hour)
+
+	alerts := []models.DeviceAlert{
+		// Day 0: two criticals across devices, one warning
+		{AlertID: uuid.New(), DeviceID: deviceA, Timestamp: base.Add(time.Hour), Level: "critical", AlertType: "cpu", Message: "a"},
+		{AlertID: uuid.New(), DeviceID: deviceB, Timestamp: base.Add(2 * time.Hour), Level: "critical", AlertType: "memory", Message: "b"},
+		{AlertID: uuid.New(), DeviceID: deviceA, Timestamp: base.Add(3 * time.Hour), Level: "warning", AlertType: "disk", Message: "c"},
+		// Day 2: one error
+		{AlertID: uuid.New(), DeviceID: deviceB, Timestamp: base.Add(49 * time.Hour), Level: "error", AlertType: "network", Message: "d"},
+	}
+	for _, a := range alerts {
+		if err := database.DB.Create(&a).Error; err != nil {
+			t.Fatalf("failed to seed alert: %v", err)
+		}
+	}
+
+	from := base.Format(time.RFC3339)
+	to := base.Add(3 * 24 * time.Hour).Format(time.RFC3339)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/dashboard/alerts/trend?from="+from+"&to="+to+"&interval=1d", nil)
+
+	GetAlertTrend(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var trend []AlertTrendBucket
+	if err := json.Unmarshal(w.Body.Bytes(), &trend); err != nil {
+		t.Fatalf("failed to unmarshal trend: %v", err)
+	}
+	if len(trend) < 3 {
+		t.Fatalf("expected at least 3 daily buckets, got %d", len(trend))
+	}
+
+	if trend[0].Counts["critical"] != 2 || trend[0].Counts["warning"] != 1 || trend[0].Total != 3 {
+		t.Errorf("unexpected day-0 bucket: %+v", trend[0])
+	}
+	// Day 1 is empty and must report explicit zeros
+	if trend[1].Total != 0 || trend[1].Counts["info"] != 0 || trend[1].Counts["critical"] != 0 {
+		t.Errorf("expected zero-filled empty bucket, got %+v", trend[1])
+	}
+	if trend[2].Counts["error"] != 1 || trend[2].Total != 1 {
+		t.Errorf("unexpected day-2 bucket: %+v", trend[2])
+	}
+}
+
+func TestGetAlertTrendInvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	cases := []string{
+		"?interval=bogus",
+		"?from=not-a-time",
+		"?from=2026-01-02T00:00:00Z&to=2026-01-01T00:00:00Z",
+		"?from=2026-01-01T00:00:00Z&to=2026-01-08T00:00:00Z&interval=1m",
+	}
+	for _, q := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/dashboard/alerts/trend"+q, nil)
+
+		GetAlertTrend(c)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
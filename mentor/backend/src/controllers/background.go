@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+)
+
+// DefaultBackgroundJobInterval is how often periodic maintenance jobs run
+// when BACKGROUND_JOB_INTERVAL is not set.
+const DefaultBackgroundJobInterval = time.Hour
+
+func backgroundJobInterval() time.Duration {
+	if raw := os.Getenv("BACKGROUND_JOB_INTERVAL"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultBackgroundJobInterval
+}
+
+// StartBackgroundJobs launches the periodic maintenance loop. Individual jobs
+// are opt-in via their own configuration and no-op when disabled, so the loop
+// itself is always safe to run.
+func StartBackgroundJobs() {
+	interval := backgroundJobInterval()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			runBackgroundJobs()
+		}
+	}()
+}
+
+// runBackgroundJobs executes one pass of every periodic maintenance job.
+func runBackgroundJobs() {
+	if n, err := ArchiveOldCommands(); err != nil {
+		fmt.Printf("Error archiving old commands: %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("Archived %d old commands to object storage\n", n)
+	}
+
+	if n, err := RecomputeOfflineDevices(); err != nil {
+		fmt.Printf("Error recomputing offline devices: %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("Marked %d devices offline\n", n)
+	}
+
+	if n, err := PurgeExpiredMetrics(); err != nil {
+		fmt.Printf("Error purging expired metrics: %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("Purged %d expired metrics\n", n)
+	}
+
+	if n, err := PurgeExpiredScreenshots(); err != nil {
+		fmt.Printf("Error purging expired screenshots: %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("Purged %d expired screenshots\n", n)
+	}
+
+	if n, err := GenerateDailyReports(); err != nil {
+		fmt.Printf("Error generating daily reports: %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("Generated %d daily device reports\n", n)
+	}
+}
+
+// RecomputeOfflineDevices reconciles the stored online flag with last_seen.
+// ListDevices computes online status in Go for its responses; this keeps the
+// persisted flag correct for consumers that query the table directly, without
+// putting a fleet-wide UPDATE on the read path.
+func RecomputeOfflineDevices() (int64, error) {
+	result := database.DB.Model(&models.Device{}).
+		Where("is_online AND last_seen < ?", time.Now().Add(-deviceOfflineThreshold())).
+		Update("is_online", false)
+	return result.RowsAffected, result.Error
+}
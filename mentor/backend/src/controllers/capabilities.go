@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Capability names devices declare at registration. A device with an empty
+// capability set is treated as supporting all of them.
+const (
+	CapabilityMetrics     = "metrics"
+	CapabilityScreenshots = "screenshots"
+	CapabilityCommands    = "commands"
+)
+
+// errCapabilityMissing distinguishes a declared-but-unsupported action from
+// database errors so handlers can map it to 409.
+var errCapabilityMissing = errors.New("capability not supported")
+
+// checkDeviceCapability verifies the device supports the named capability.
+// Unknown devices pass: endpoints that accept data for not-yet-registered
+// devices keep doing so, and ones that require registration check separately.
+func checkDeviceCapability(deviceID uuid.UUID, capability string) error {
+	var device models.Device
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !device.HasCapability(capability) {
+		return fmt.Errorf("%w: device does not support %s", errCapabilityMissing, capability)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestTriggerScreenshotRejectsMissingCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{
+		DeviceID: uuid.New(), DeviceName: "headless-sensor", DeviceType: "iot",
+		Capabilities: []string{CapabilityMetrics},
+	}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	if w := triggerScreenshot(t, device.DeviceID.String()); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for device without screenshot capability, got %d", w.Code)
+	}
+}
+
+func TestCreateRemoteCommandRejectsMissingCapability(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{
+		DeviceID: uuid.New(), DeviceName: "headless-sensor", DeviceType: "iot",
+		Capabilities: []string{CapabilityMetrics, CapabilityScreenshots},
+	}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"deviceid": "` + device.DeviceID.String() + `", "command_text": "ls"}`
+	c.Request, _ = http.NewRequest("POST", "/devices/commands", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	CreateRemoteCommand(c)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 for device without command capability, got %d", w.Code)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", device.DeviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count commands: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no command stored, got %d", count)
+	}
+}
+
+func TestCapabilitiesDefaultToAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// A device registered without capabilities keeps full functionality
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "legacy", DeviceType: "laptop"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	if w := triggerScreenshot(t, device.DeviceID.String()); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for capability-less device, got %d, body: %s", w.Code, w.Body.String())
+	}
+}
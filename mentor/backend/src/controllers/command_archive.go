@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Seams for tests to swap in an in-memory object store.
+var (
+	archiveUploadObject = s3.PutObject
+	archiveFetchObject  = s3.GetObject
+)
+
+// commandArchiveAge returns the age past which terminal commands are moved to
+// object storage, from COMMAND_ARCHIVE_AGE (e.g. "30d"). Zero (the default)
+// disables archival.
+func commandArchiveAge() time.Duration {
+	if raw := os.Getenv("COMMAND_ARCHIVE_AGE"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// archiveBatchLimit caps how many commands a single archival pass exports so
+// one NDJSON object stays a manageable size.
+const archiveBatchLimit = 1000
+
+// ArchiveOldCommands exports terminal commands older than the configured age
+// to object storage as one NDJSON batch, records the batch in the archive
+// index, and deletes the rows from the database. Returns how many commands
+// were archived. A no-op when archival is disabled or nothing qualifies.
+func ArchiveOldCommands() (int, error) {
+	age := commandArchiveAge()
+	if age <= 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().Add(-age)
+
+	commands := make([]models.DeviceRemoteCommand, 0)
+	if err := database.DB.
+		Where("status IN ? AND created_at < ?", []string{"completed", "failed"}, cutoff).
+		Order("created_at asc").
+		Limit(archiveBatchLimit).
+		Find(&commands).Error; err != nil {
+		return 0, err
+	}
+	// Archives store plain text so they stay readable regardless of the
+	// compression setting at the time the command completed
+	if err := decompressCommandResults(commands); err != nil {
+		return 0, fmt.Errorf("failed to select commands for archival: %v", err)
+	}
+	if len(commands) == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	ids := make([]uuid.UUID, 0, len(commands))
+	for _, cmd := range commands {
+		if err := encoder.Encode(cmd); err != nil {
+			return 0, fmt.Errorf("failed to encode command %s: %v", cmd.CommandID, err)
+		}
+		ids = append(ids, cmd.CommandID)
+	}
+
+	archive := models.CommandArchive{
+		ArchiveID:    uuid.New(),
+		Path:         fmt.Sprintf("command-archives/%d-%s.ndjson", time.Now().Unix(), uuid.New().String()[:8]),
+		CommandCount: len(commands),
+		OldestAt:     commands[0].CreatedAt,
+		NewestAt:     commands[len(commands)-1].CreatedAt,
+		CreatedAt:    time.Now(),
+	}
+
+	// Upload before deleting: a failed transaction leaves at worst an orphaned
+	// object, never lost commands
+	if err := archiveUploadObject(archive.Path, &buf, int64(buf.Len())); err != nil {
+		return 0, fmt.Errorf("failed to upload archive batch: %v", err)
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&archive).Error; err != nil {
+			return err
+		}
+		return tx.Where("commandid IN ?", ids).
+			Delete(&models.DeviceRemoteCommand{}).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to index archive batch: %v", err)
+	}
+	return len(commands), nil
+}
+
+// GetArchivedCommands reads commands back from the object-storage archive
+// @Summary List archived commands
+// @Description Read terminal commands previously exported to object storage. Batches overlapping the requested time range are fetched and decoded; results can be narrowed to one device.
+// @Tags commands
+// @Produce json
+// @Param deviceid query string false "Filter by device ID (UUID)"
+// @Param from query string false "Only commands created at or after this time (RFC3339)"
+// @Param to query string false "Only commands created at or before this time (RFC3339)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /commands/archived [get]
+func GetArchivedCommands(c *gin.Context) {
+	var deviceID uuid.UUID
+	if raw := c.Query("deviceid"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid deviceid parameter")
+			return
+		}
+		deviceID = parsed
+	}
+
+	var fromTime, toTime time.Time
+	if raw := c.Query("from"); raw != "" {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+	}
+	if raw := c.Query("to"); raw != "" {
+		ts, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+	}
+
+	// Select index rows whose batch could overlap the requested range
+	query := database.Read().Model(&models.CommandArchive{}).Order("oldest_at asc")
+	if !fromTime.IsZero() {
+		query = query.Where("newest_at >= ?", fromTime)
+	}
+	if !toTime.IsZero() {
+		query = query.Where("oldest_at <= ?", toTime)
+	}
+	archives := make([]models.CommandArchive, 0)
+	if err := query.Find(&archives).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	commands := make([]models.DeviceRemoteCommand, 0)
+	for _, archive := range archives {
+		batch, err := readArchiveBatch(archive.Path)
+		if err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		for _, cmd := range batch {
+			if deviceID != uuid.Nil && cmd.DeviceID != deviceID {
+				continue
+			}
+			if !fromTime.IsZero() && cmd.CreatedAt.Before(fromTime) {
+				continue
+			}
+			if !toTime.IsZero() && cmd.CreatedAt.After(toTime) {
+				continue
+			}
+			commands = append(commands, cmd)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"archives": len(archives),
+		"commands": commands,
+	})
+}
+
+func readArchiveBatch(path string) ([]models.DeviceRemoteCommand, error) {
+	reader, err := archiveFetchObject(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %q: %v", path, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			fmt.Printf("Error closing archive object %q: %v\n", path, err)
+		}
+	}()
+
+	commands := make([]models.DeviceRemoteCommand, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var cmd models.DeviceRemoteCommand
+		if err := json.Unmarshal(line, &cmd); err != nil {
+			return nil, fmt.Errorf("corrupt archive %q: %v", path, err)
+		}
+		commands = append(commands, cmd)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read archive %q: %v", path, err)
+	}
+	return commands, nil
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// installArchiveStore swaps the archive object seams for an in-memory map and
+// restores them when the test finishes.
+func installArchiveStore(t *testing.T) map[string][]byte {
+	t.Helper()
+	store := make(map[string][]byte)
+	origUpload, origFetch := archiveUploadObject, archiveFetchObject
+	archiveUploadObject = func(filename string, data io.Reader, size int64) error {
+		content, err := io.ReadAll(data)
+		if err != nil {
+			return err
+		}
+		store[filename] = content
+		return nil
+	}
+	archiveFetchObject = func(filename string) (io.ReadCloser, error) {
+		content, ok := store[filename]
+		if !ok {
+			return nil, fmt.Errorf("object %q not found", filename)
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	t.Cleanup(func() {
+		archiveUploadObject, archiveFetchObject = origUpload, origFetch
+	})
+	return store
+}
+
+func seedArchivableCommand(t *testing.T, deviceID uuid.UUID, status string, age time.Duration) models.DeviceRemoteCommand {
+	t.Helper()
+	cmd := models.DeviceRemoteCommand{
+		CommandID: uuid.New(), DeviceID: deviceID,
+		CommandText: "ls", Status: status,
+		CreatedAt: time.Now().Add(-age),
+	}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to seed command: %v", err)
+	}
+	return cmd
+}
+
+func TestArchiveOldCommandsRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("COMMAND_ARCHIVE_AGE", "1h")
+	store := installArchiveStore(t)
+
+	deviceID := uuid.New()
+	oldDone := seedArchivableCommand(t, deviceID, "completed", 3*time.Hour)
+	oldFailed := seedArchivableCommand(t, deviceID, "failed", 2*time.Hour)
+	oldPending := seedArchivableCommand(t, deviceID, "pending", 3*time.Hour)
+	recent := seedArchivableCommand(t, deviceID, "completed", time.Minute)
+
+	n, err := ArchiveOldCommands()
+	if err != nil {
+		t.Fatalf("archival failed: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 archived commands, got %d", n)
+	}
+	if len(store) != 1 {
+		t.Fatalf("expected 1 archive object, got %d", len(store))
+	}
+
+	// Archived rows are gone from the database; pending and recent survive
+	var remaining []models.DeviceRemoteCommand
+	if err := database.DB.Where("deviceid = ?", deviceID).Find(&remaining).Error; err != nil {
+		t.Fatalf("failed to list remaining commands: %v", err)
+	}
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 remaining commands, got %d", len(remaining))
+	}
+	for _, cmd := range remaining {
+		if cmd.CommandID != oldPending.CommandID && cmd.CommandID != recent.CommandID {
+			t.Errorf("unexpected survivor %s with status %q", cmd.CommandID, cmd.Status)
+		}
+	}
+
+	var archive models.CommandArchive
+	if err := database.DB.First(&archive).Error; err != nil {
+		t.Fatalf("failed to load archive index row: %v", err)
+	}
+	if archive.CommandCount != 2 {
+		t.Errorf("expected archive count 2, got %d", archive.CommandCount)
+	}
+
+	// Read the batch back through the archive endpoint
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/commands/archived?deviceid="+deviceID.String(), nil)
+	GetArchivedCommands(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Archives int                          `json:"archives"`
+		Commands []models.DeviceRemoteCommand `json:"commands"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(resp.Commands) != 2 {
+		t.Fatalf("expected 2 archived commands in response, got %d", len(resp.Commands))
+	}
+	got := map[uuid.UUID]bool{}
+	for _, cmd := range resp.Commands {
+		got[cmd.CommandID] = true
+	}
+	if !got[oldDone.CommandID] || !got[oldFailed.CommandID] {
+		t.Errorf("archive read-back missing expected commands: %v", got)
+	}
+}
+
+func TestArchiveOldCommandsDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("COMMAND_ARCHIVE_AGE", "")
+	installArchiveStore(t)
+
+	deviceID := uuid.New()
+	seedArchivableCommand(t, deviceID, "completed", 100*time.Hour)
+
+	n, err := ArchiveOldCommands()
+	if err != nil {
+		t.Fatalf("archival failed: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected no archival with feature off, got %d", n)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count commands: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected command to survive, got %d rows", count)
+	}
+}
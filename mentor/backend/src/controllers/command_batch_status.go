@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BatchCommandStatusUpdate is one entry in a batch status report: the command
+// it targets plus the outcome fields the agent observed.
+type BatchCommandStatusUpdate struct {
+	CommandID uuid.UUID `json:"command_id"`
+	Status    string    `json:"status"`
+	Result    string    `json:"result"`
+	ExitCode  int       `json:"exit_code"`
+}
+
+// BatchCommandStatusResult reports per-command whether the update matched a
+// stored command.
+type BatchCommandStatusResult struct {
+	CommandID uuid.UUID `json:"command_id"`
+	Updated   bool      `json:"updated"`
+}
+
+// BatchUpdateCommandStatus applies several command status updates at once
+// @Summary Batch-update command statuses
+// @Description Apply an array of command status updates in one transaction, so an agent reconnecting after offline execution reports every result in a single round-trip. Each entry needs a command_id and an allowed status (pending, running, completed, or failed); the response lists per command whether it was found and updated. Unknown IDs do not fail the batch.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Param updates body []controllers.BatchCommandStatusUpdate true "Command status updates"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON, empty batch, or disallowed status"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /commands/status/batch [put]
+func BatchUpdateCommandStatus(c *gin.Context) {
+	var updates []BatchCommandStatusUpdate
+	if err := c.BindJSON(&updates); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if len(updates) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "batch is empty")
+		return
+	}
+
+	// Validate the whole batch before touching the database so a bad entry
+	// never leaves a partially applied report
+	for i, update := range updates {
+		if update.CommandID == uuid.Nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation,
+				fmt.Sprintf("entry %d: command_id is required", i))
+			return
+		}
+		if !models.IsValidCommandStatus(update.Status) {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation,
+				fmt.Sprintf("entry %d: invalid command status (must be: pending, running, completed, or failed)", i))
+			return
+		}
+	}
+
+	now := time.Now()
+	results := make([]BatchCommandStatusResult, 0, len(updates))
+	updated := 0
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, update := range updates {
+			// Optionally gzip large results at write time; reads decompress
+			// transparently so clients always see plain text
+			resultText, resultBlob, resultCompressed := storedCommandResult(update.Result)
+			fields := map[string]interface{}{
+				"status":            update.Status,
+				"result":            resultText,
+				"compressed_result": resultBlob,
+				"compressed":        resultCompressed,
+				"exit_code":         update.ExitCode,
+			}
+			if update.Status == "completed" || update.Status == "failed" {
+				fields["completed_at"] = now
+			}
+
+			result := tx.Model(&models.DeviceRemoteCommand{}).
+				Where("commandid = ?", update.CommandID).
+				Updates(fields)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				updated++
+			}
+			results = append(results, BatchCommandStatusResult{
+				CommandID: update.CommandID,
+				Updated:   result.RowsAffected > 0,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated, "results": results})
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func batchUpdateCommandStatus(t *testing.T, updates []BatchCommandStatusUpdate) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(updates)
+	if err != nil {
+		t.Fatalf("failed to marshal batch: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("PUT", "/commands/status/batch", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	BatchUpdateCommandStatus(c)
+	return w
+}
+
+func TestBatchUpdateCommandStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	first := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_info", Status: "pending"}
+	second := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "pending"}
+	for _, cmd := range []models.DeviceRemoteCommand{first, second} {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to create command: %v", err)
+		}
+	}
+
+	unknown := uuid.New()
+	w := batchUpdateCommandStatus(t, []BatchCommandStatusUpdate{
+		{CommandID: first.CommandID, Status: "completed", Result: "ok", ExitCode: 0},
+		{CommandID: second.CommandID, Status: "failed", Result: "timeout", ExitCode: 1},
+		{CommandID: unknown, Status: "completed"},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Updated int                        `json:"updated"`
+		Results []BatchCommandStatusResult `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Updated != 2 {
+		t.Errorf("expected 2 commands updated, got %d", response.Updated)
+	}
+	if len(response.Results) != 3 {
+		t.Fatalf("expected a result per entry, got %d", len(response.Results))
+	}
+	for _, result := range response.Results {
+		wantUpdated := result.CommandID != unknown
+		if result.Updated != wantUpdated {
+			t.Errorf("command %s: expected updated=%v, got %v", result.CommandID, wantUpdated, result.Updated)
+		}
+	}
+
+	var stored models.DeviceRemoteCommand
+	database.DB.First(&stored, "commandid = ?", first.CommandID)
+	if stored.Status != "completed" || stored.Result != "ok" || stored.CompletedAt.IsZero() {
+		t.Errorf("expected first command completed with result, got %+v", stored)
+	}
+	database.DB.First(&stored, "commandid = ?", second.CommandID)
+	if stored.Status != "failed" || stored.ExitCode != 1 {
+		t.Errorf("expected second command failed with exit code 1, got %+v", stored)
+	}
+}
+
+func TestBatchUpdateCommandStatusRejectsInvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	cmd := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: uuid.New(), CommandText: "get_info", Status: "pending"}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	w := batchUpdateCommandStatus(t, []BatchCommandStatusUpdate{
+		{CommandID: cmd.CommandID, Status: "completed"},
+		{CommandID: uuid.New(), Status: "exploded"},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a disallowed status, got %d", w.Code)
+	}
+
+	// The valid entry must not have been applied before the batch was rejected
+	var stored models.DeviceRemoteCommand
+	database.DB.First(&stored, "commandid = ?", cmd.CommandID)
+	if stored.Status != "pending" {
+		t.Errorf("expected rejected batch to leave commands untouched, got status %q", stored.Status)
+	}
+}
+
+func TestBatchUpdateCommandStatusEmptyBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := batchUpdateCommandStatus(t, []BatchCommandStatusUpdate{}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an empty batch, got %d", w.Code)
+	}
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"mentor-backend/models"
+)
+
+// CommandCompressMinBytes is the smallest result worth compressing; below
+// this the gzip header overhead outweighs any savings.
+const CommandCompressMinBytes = 256
+
+// commandResultCompressionEnabled reports whether command results are gzipped
+// at write time (COMMAND_RESULT_COMPRESS env). Off by default.
+func commandResultCompressionEnabled() bool {
+	v := strings.ToLower(os.Getenv("COMMAND_RESULT_COMPRESS"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
+// storedCommandResult returns the column values to persist for a result:
+// either the plain text, or a gzipped blob with the text column empty when
+// compression is enabled and the result is large enough to benefit.
+func storedCommandResult(result string) (text string, blob []byte, compressed bool) {
+	if !commandResultCompressionEnabled() || len(result) < CommandCompressMinBytes {
+		return result, nil, false
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(result)); err != nil {
+		return result, nil, false
+	}
+	if err := writer.Close(); err != nil {
+		return result, nil, false
+	}
+	return "", buf.Bytes(), true
+}
+
+// decompressCommandResult restores a command's plain-text result in place.
+// Commands stored uncompressed pass through untouched.
+func decompressCommandResult(cmd *models.DeviceRemoteCommand) error {
+	if !cmd.Compressed {
+		return nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(cmd.CompressedResult))
+	if err != nil {
+		return err
+	}
+	defer reader.Close() //nolint:errcheck
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	cmd.Result = string(plain)
+	cmd.CompressedResult = nil
+	cmd.Compressed = false
+	return nil
+}
+
+// decompressCommandResults restores results for a whole listing.
+func decompressCommandResults(commands []models.DeviceRemoteCommand) error {
+	for i := range commands {
+		if err := decompressCommandResult(&commands[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
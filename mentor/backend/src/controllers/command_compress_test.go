@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func updateCommandStatus(t *testing.T, cmd models.DeviceRemoteCommand) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(cmd)
+	if err != nil {
+		t.Fatalf("failed to marshal command: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/commands/status", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	UpdateCommandStatus(c)
+	return w
+}
+
+func TestCommandResultCompressionRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("COMMAND_RESULT_COMPRESS", "true")
+
+	deviceID := uuid.New()
+	cmd := models.DeviceRemoteCommand{
+		CommandID: uuid.New(), DeviceID: deviceID,
+		CommandText: "journalctl", Status: "pending",
+	}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	// Large repetitive text, exactly what compression is for.
+	largeResult := strings.Repeat("line of verbose log output\n", 500)
+	update := models.DeviceRemoteCommand{CommandID: cmd.CommandID, Status: "completed", Result: largeResult, ExitCode: 0}
+	if w := updateCommandStatus(t, update); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 updating command, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// Storage holds the gzipped blob, not the plain text.
+	var stored models.DeviceRemoteCommand
+	if err := database.DB.First(&stored, "commandid = ?", cmd.CommandID).Error; err != nil {
+		t.Fatalf("failed to load stored command: %v", err)
+	}
+	if !stored.Compressed || stored.Result != "" {
+		t.Errorf("expected compressed storage, got compressed=%v result len %d", stored.Compressed, len(stored.Result))
+	}
+	if len(stored.CompressedResult) == 0 || len(stored.CompressedResult) >= len(largeResult) {
+		t.Errorf("expected compressed blob smaller than input, got %d bytes", len(stored.CompressedResult))
+	}
+
+	// Reads decompress transparently.
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands", nil)
+	GetDeviceCommands(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 listing commands, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var listed []models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("failed to unmarshal commands: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Result != largeResult {
+		t.Errorf("expected read to return the original result, got %d commands, result len %d", len(listed), len(listed[0].Result))
+	}
+}
+
+func TestCommandResultCompressionSkipsSmallAndDisabled(t *testing.T) {
+	t.Setenv("COMMAND_RESULT_COMPRESS", "true")
+	if text, blob, compressed := storedCommandResult("short"); compressed || text != "short" || blob != nil {
+		t.Errorf("expected small results stored as plain text, got compressed=%v", compressed)
+	}
+
+	t.Setenv("COMMAND_RESULT_COMPRESS", "")
+	large := strings.Repeat("x", CommandCompressMinBytes*2)
+	if text, _, compressed := storedCommandResult(large); compressed || text != large {
+		t.Errorf("expected plain storage when compression is disabled, got compressed=%v", compressed)
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+)
+
+const (
+	// DefaultCommandTTL is how long a command may wait for pickup when
+	// COMMAND_TTL is not set.
+	DefaultCommandTTL = 24 * time.Hour
+	// DefaultCommandExpirySweepInterval is how often the expiry sweeper runs
+	// when COMMAND_EXPIRY_SWEEP_INTERVAL is not set.
+	DefaultCommandExpirySweepInterval = time.Minute
+)
+
+// commandTTL returns the configured pickup TTL for new commands (COMMAND_TTL
+// env, e.g. "1h" or "2d").
+func commandTTL() time.Duration {
+	if raw := os.Getenv("COMMAND_TTL"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultCommandTTL
+}
+
+// commandExpirySweepInterval returns how often the sweeper marks expired
+// commands (COMMAND_EXPIRY_SWEEP_INTERVAL env).
+func commandExpirySweepInterval() time.Duration {
+	if raw := os.Getenv("COMMAND_EXPIRY_SWEEP_INTERVAL"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultCommandExpirySweepInterval
+}
+
+// ExpirePendingCommands marks pending commands past their ExpiresAt as
+// expired, so command history distinguishes "never picked up" from "still
+// waiting". Commands with a zero ExpiresAt predate expiry support and are
+// left alone. Returns the number of commands expired.
+func ExpirePendingCommands() (int64, error) {
+	result := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("status = ? AND expires_at > ? AND expires_at <= ?", "pending", time.Time{}, time.Now()).
+		Update("status", "expired")
+	return result.RowsAffected, result.Error
+}
+
+// StartCommandExpirySweeper launches the periodic expiry sweep and returns a
+// stop function for graceful shutdown. The sweep runs more often than the
+// hourly maintenance loop because a freshly-expired command should stop being
+// handed to agents promptly.
+func StartCommandExpirySweeper() func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(commandExpirySweepInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if n, err := ExpirePendingCommands(); err != nil {
+					fmt.Printf("Error expiring pending commands: %v\n", err)
+				} else if n > 0 {
+					fmt.Printf("Expired %d pending commands\n", n)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func pendingCommands(t *testing.T, deviceID uuid.UUID) []models.DeviceRemoteCommand {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands/pending", nil)
+
+	GetPendingCommands(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var commands []models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &commands); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return commands
+}
+
+func TestCommandExpiry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	expired := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    deviceID,
+		CommandText: "get_info",
+		Status:      "pending",
+		CreatedAt:   time.Now().Add(-2 * time.Hour),
+		ExpiresAt:   time.Now().Add(-time.Hour),
+	}
+	live := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    deviceID,
+		CommandText: "get_info",
+		Status:      "pending",
+		CreatedAt:   time.Now(),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	// Zero ExpiresAt predates expiry support and must never expire.
+	legacy := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    deviceID,
+		CommandText: "get_info",
+		Status:      "pending",
+		CreatedAt:   time.Now().Add(-48 * time.Hour),
+	}
+	for _, cmd := range []models.DeviceRemoteCommand{expired, live, legacy} {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to create command: %v", err)
+		}
+	}
+
+	// The expired command is withheld from agents even before the sweep.
+	got := pendingCommands(t, deviceID)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 deliverable commands, got %d", len(got))
+	}
+	for _, cmd := range got {
+		if cmd.CommandID == expired.CommandID {
+			t.Error("expected the expired command to be withheld from pending delivery")
+		}
+	}
+
+	n, err := ExpirePendingCommands()
+	if err != nil {
+		t.Fatalf("failed to expire commands: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 command expired, got %d", n)
+	}
+
+	var stored models.DeviceRemoteCommand
+	if err := database.DB.First(&stored, "commandid = ?", expired.CommandID).Error; err != nil {
+		t.Fatalf("failed to load command: %v", err)
+	}
+	if stored.Status != "expired" {
+		t.Errorf("expected status expired, got %q", stored.Status)
+	}
+	for _, id := range []uuid.UUID{live.CommandID, legacy.CommandID} {
+		if err := database.DB.First(&stored, "commandid = ?", id).Error; err != nil {
+			t.Fatalf("failed to load command: %v", err)
+		}
+		if stored.Status != "pending" {
+			t.Errorf("expected command %s to stay pending, got %q", id, stored.Status)
+		}
+	}
+}
+
+func TestCommandTTLConfig(t *testing.T) {
+	if got := commandTTL(); got != DefaultCommandTTL {
+		t.Errorf("expected default TTL %v, got %v", DefaultCommandTTL, got)
+	}
+	t.Setenv("COMMAND_TTL", "2h")
+	if got := commandTTL(); got != 2*time.Hour {
+		t.Errorf("expected 2h TTL, got %v", got)
+	}
+	t.Setenv("COMMAND_EXPIRY_SWEEP_INTERVAL", "30s")
+	if got := commandExpirySweepInterval(); got != 30*time.Second {
+		t.Errorf("expected 30s sweep interval, got %v", got)
+	}
+}
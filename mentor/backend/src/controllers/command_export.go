@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MaxExportedResultLength truncates command results so one chatty command
+// cannot bloat the whole export.
+const MaxExportedResultLength = 500
+
+// ExportDeviceCommands streams a device's command history as CSV
+// @Summary Export device command history
+// @Description Stream the full command history of a device as CSV for audits. Supports a time-range filter on creation time. Rows are streamed to bound memory on large histories.
+// @Tags commands
+// @Produce text/csv
+// @Param id path string true "Device ID (UUID)"
+// @Param format query string false "Export format (only csv)" default(csv)
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Success 200 {string} string "Streamed CSV rows"
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID, format, or time range"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/commands/export [get]
+func ExportDeviceCommands(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	if format := strings.ToLower(c.DefaultQuery("format", "csv")); format != "csv" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid format: must be csv")
+		return
+	}
+
+	query := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", deviceID).
+		Order("created_at asc")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("created_at >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("created_at <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_EXPORT"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("Error closing command export rows: %v\n", err)
+		}
+	}()
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=commands-%s.csv", deviceID))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	header := []string{"commandid", "command_text", "status", "created_at", "scheduled_at", "completed_at", "exit_code", "result"}
+	if err := writer.Write(header); err != nil {
+		fmt.Printf("Error writing command export header: %v\n", err)
+		return
+	}
+
+	for rows.Next() {
+		var cmd models.DeviceRemoteCommand
+		if err := database.DB.ScanRows(rows, &cmd); err != nil {
+			fmt.Printf("Error scanning command export row: %v\n", err)
+			return
+		}
+
+		scheduledAt := ""
+		if cmd.ScheduledAt != nil {
+			scheduledAt = cmd.ScheduledAt.Format(time.RFC3339)
+		}
+		completedAt := ""
+		if !cmd.CompletedAt.IsZero() {
+			completedAt = cmd.CompletedAt.Format(time.RFC3339)
+		}
+		if err := decompressCommandResult(&cmd); err != nil {
+			fmt.Printf("Error decompressing command export row: %v\n", err)
+			return
+		}
+		result := cmd.Result
+		if len(result) > MaxExportedResultLength {
+			result = result[:MaxExportedResultLength] + "..."
+		}
+
+		record := []string{
+			cmd.CommandID.String(),
+			cmd.CommandText,
+			cmd.Status,
+			cmd.CreatedAt.Format(time.RFC3339),
+			scheduledAt,
+			completedAt,
+			strconv.Itoa(cmd.ExitCode),
+			result,
+		}
+		if err := writer.Write(record); err != nil {
+			fmt.Printf("Error writing command export row: %v\n", err)
+			return
+		}
+		// Flush per row so large exports stream instead of buffering
+		writer.Flush()
+	}
+	writer.Flush()
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestExportDeviceCommandsCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	commands := []models.DeviceRemoteCommand{
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_info", Status: "completed", CreatedAt: now.Add(-2 * time.Hour), CompletedAt: now.Add(-2*time.Hour + time.Minute), Result: "ok", ExitCode: 0},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "restart", Status: "failed", CreatedAt: now.Add(-time.Hour), ExitCode: 1, Result: strings.Repeat("x", MaxExportedResultLength+100)},
+		// Another device's commands must not leak into the export
+		{CommandID: uuid.New(), DeviceID: uuid.New(), CommandText: "status", Status: "completed", CreatedAt: now},
+	}
+	for _, cmd := range commands {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands/export?format=csv", nil)
+
+	ExportDeviceCommands(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("expected text/csv content type, got %q", ct)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header plus 2 rows, got %d records", len(records))
+	}
+
+	header := strings.Join(records[0], ",")
+	if header != "commandid,command_text,status,created_at,scheduled_at,completed_at,exit_code,result" {
+		t.Errorf("unexpected CSV header: %s", header)
+	}
+
+	// Rows come back oldest first
+	if records[1][1] != "get_info" || records[1][2] != "completed" {
+		t.Errorf("unexpected first row: %v", records[1])
+	}
+	if records[2][1] != "restart" || records[2][6] != "1" {
+		t.Errorf("unexpected second row: %v", records[2])
+	}
+	if len(records[2][7]) > MaxExportedResultLength+3 {
+		t.Errorf("expected truncated result, got %d chars", len(records[2][7]))
+	}
+}
+
+func TestExportDeviceCommandsInvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/commands/export?format=xml", nil)
+
+	ExportDeviceCommands(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid format, got %d", w.Code)
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/util"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCommandForwardTimeout bounds each forwarding attempt to the devices backend
+const DefaultCommandForwardTimeout = 5 * time.Second
+
+// commandForwardTimeout returns the per-attempt forwarding timeout, overridable
+// via COMMAND_FORWARD_TIMEOUT_SECONDS.
+func commandForwardTimeout() time.Duration {
+	if raw := os.Getenv("COMMAND_FORWARD_TIMEOUT_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return DefaultCommandForwardTimeout
+}
+
+// commandForwardSyncEnabled reports whether forwarding defaults to synchronous
+// mode fleet-wide (COMMAND_FORWARD_SYNC env). Per-request ?sync=true overrides.
+func commandForwardSyncEnabled() bool {
+	v := strings.ToLower(os.Getenv("COMMAND_FORWARD_SYNC"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
+// Forward statuses recorded on commands
+const (
+	ForwardStatusPending = "pending"
+	ForwardStatusSent    = "sent"
+	ForwardStatusFailed  = "failed"
+)
+
+// recordForwardStatus persists the delivery outcome on the command so failed
+// forwards can be replayed later. Errors are logged, not surfaced: the status
+// column is best-effort bookkeeping.
+func recordForwardStatus(commandID uuid.UUID, status string) {
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("commandid = ?", commandID).
+		Update("forward_status", status).Error; err != nil {
+		fmt.Printf("Error recording forward status for %s: %v\n", commandID, err)
+	}
+}
+
+// forwardTargetAllowed checks a forward URL's host against the
+// FORWARD_URL_ALLOWLIST env (comma-separated hostnames, optionally with
+// port). An empty allowlist allows every target for back-compat; this guard
+// exists to prevent SSRF if forward URLs ever come from untrusted config.
+func forwardTargetAllowed(rawURL string) bool {
+	raw := os.Getenv("FORWARD_URL_ALLOWLIST")
+	if strings.TrimSpace(raw) == "" {
+		return true
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.EqualFold(entry, parsed.Hostname()) || strings.EqualFold(entry, parsed.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardCommand posts a command to the devices backend and returns an error
+// when delivery fails after retries or the backend rejects it.
+func forwardCommand(devicesAPIURL string, cmd *models.DeviceRemoteCommand) error {
+	if !forwardTargetAllowed(devicesAPIURL) {
+		return fmt.Errorf("forward target %q is not in FORWARD_URL_ALLOWLIST; skipping dispatch", devicesAPIURL)
+	}
+
+	payload := map[string]interface{}{
+		"command_text": cmd.CommandText,
+	}
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal command payload: %w", err)
+	}
+
+	// Use retry client for forwarding to devices backend
+	retryClient := util.NewHTTPClientWithRetry(commandForwardTimeout(), 3)
+	resp, err := retryClient.Post(
+		fmt.Sprintf("%s/devices/%s/commands", devicesAPIURL, cmd.DeviceID),
+		"application/json",
+		bytes.NewBuffer(jsonData),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to forward command after retries: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("devices backend returned error status: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func createCommandSync(t *testing.T, cmd models.DeviceRemoteCommand) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(cmd)
+	c.Request, _ = http.NewRequest("POST", "/devices/commands?sync=true", bytes.NewReader(b))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	CreateRemoteCommand(c)
+	return w
+}
+
+func TestCreateRemoteCommandSyncForwardSuccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+	t.Setenv("DEVICES_API_URL", mockServer.URL)
+
+	w := createCommandSync(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "get_info",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp["forwarded"] != true {
+		t.Errorf("expected forwarded=true, got %v", resp["forwarded"])
+	}
+	if resp["command"] == nil {
+		t.Error("expected the created command in the response")
+	}
+}
+
+func TestCreateRemoteCommandSyncForwardFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer mockServer.Close()
+	t.Setenv("DEVICES_API_URL", mockServer.URL)
+
+	w := createCommandSync(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "restart",
+	})
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected status 502 on forwarding failure, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp["forwarded"] != false {
+		t.Errorf("expected forwarded=false, got %v", resp["forwarded"])
+	}
+	if resp["forward_error"] == nil || resp["forward_error"] == "" {
+		t.Error("expected forward_error to describe the failure")
+	}
+	// The command itself is still queued even when forwarding fails
+	if resp["command"] == nil {
+		t.Error("expected the created command in the response")
+	}
+}
+
+func TestForwardTargetAllowed(t *testing.T) {
+	// Unset allowlist keeps back-compat: everything is allowed
+	t.Setenv("FORWARD_URL_ALLOWLIST", "")
+	if !forwardTargetAllowed("http://anywhere.example.com:8080") {
+		t.Error("expected all targets allowed with no allowlist")
+	}
+
+	t.Setenv("FORWARD_URL_ALLOWLIST", "devices-backend, backup.example.com:9000")
+	if !forwardTargetAllowed("http://devices-backend:8080/devices") {
+		t.Error("expected allowlisted hostname to be allowed")
+	}
+	if !forwardTargetAllowed("https://backup.example.com:9000") {
+		t.Error("expected allowlisted host:port to be allowed")
+	}
+	if forwardTargetAllowed("http://attacker.example.com/devices") {
+		t.Error("expected non-allowlisted host to be blocked")
+	}
+	if forwardTargetAllowed("not-a-url") {
+		t.Error("expected unparsable target to be blocked")
+	}
+}
+
+func TestCreateRemoteCommandSyncForwardBlockedTarget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("blocked target must never be contacted")
+	}))
+	defer mockServer.Close()
+	t.Setenv("DEVICES_API_URL", mockServer.URL)
+	t.Setenv("FORWARD_URL_ALLOWLIST", "devices-backend")
+
+	w := createCommandSync(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "get_info",
+	})
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("expected status 502 for blocked target, got %d, body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCommandForwardSyncEnabledEnv(t *testing.T) {
+	t.Setenv("COMMAND_FORWARD_SYNC", "true")
+	if !commandForwardSyncEnabled() {
+		t.Error("expected sync mode enabled when COMMAND_FORWARD_SYNC=true")
+	}
+
+	t.Setenv("COMMAND_FORWARD_SYNC", "")
+	if commandForwardSyncEnabled() {
+		t.Error("expected sync mode disabled by default")
+	}
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// maxCommandsPerDevice returns the per-device command history cap from
+// MAX_COMMANDS_PER_DEVICE. Zero (the default) means unlimited.
+func maxCommandsPerDevice() int {
+	if raw := os.Getenv("MAX_COMMANDS_PER_DEVICE"); raw != "" {
+		if cap, err := strconv.Atoi(raw); err == nil && cap > 0 {
+			return cap
+		}
+	}
+	return 0
+}
+
+// trimCommandHistory enforces the per-device command history cap after a new
+// command is queued. Only terminal commands (completed or failed) are eligible
+// for trimming, oldest first; pending and running commands always survive so
+// in-flight work is never lost.
+func trimCommandHistory(deviceID uuid.UUID) {
+	cap := maxCommandsPerDevice()
+	if cap <= 0 {
+		return
+	}
+
+	var total int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", deviceID).
+		Count(&total).Error; err != nil {
+		fmt.Printf("Error counting commands for history trim: %v\n", err)
+		return
+	}
+	if total <= int64(cap) {
+		return
+	}
+
+	excess := int(total - int64(cap))
+	var victims []uuid.UUID
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ? AND status IN ?", deviceID, []string{"completed", "failed"}).
+		Order("created_at asc").
+		Limit(excess).
+		Pluck("commandid", &victims).Error; err != nil {
+		fmt.Printf("Error selecting commands for history trim: %v\n", err)
+		return
+	}
+	if len(victims) == 0 {
+		return
+	}
+
+	if err := database.DB.Where("commandid IN ?", victims).
+		Delete(&models.DeviceRemoteCommand{}).Error; err != nil {
+		fmt.Printf("Error trimming command history: %v\n", err)
+	}
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestTrimCommandHistoryCapsTerminalCommands(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("MAX_COMMANDS_PER_DEVICE", "3")
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	// Oldest two completed, then a failed, a pending, and a running command
+	seed := []models.DeviceRemoteCommand{
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "completed", CreatedAt: now.Add(-50 * time.Minute)},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "completed", CreatedAt: now.Add(-40 * time.Minute)},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "failed", CreatedAt: now.Add(-30 * time.Minute)},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "pending", CreatedAt: now.Add(-20 * time.Minute)},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "running", CreatedAt: now.Add(-10 * time.Minute)},
+	}
+	for i := range seed {
+		if err := database.DB.Create(&seed[i]).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	trimCommandHistory(deviceID)
+
+	var remaining []models.DeviceRemoteCommand
+	if err := database.DB.Where("deviceid = ?", deviceID).Find(&remaining).Error; err != nil {
+		t.Fatalf("failed to list commands: %v", err)
+	}
+	if len(remaining) != 3 {
+		t.Fatalf("expected 3 commands after trim, got %d", len(remaining))
+	}
+
+	byID := map[uuid.UUID]models.DeviceRemoteCommand{}
+	for _, cmd := range remaining {
+		byID[cmd.CommandID] = cmd
+	}
+	// The two oldest completed commands should be gone
+	if _, found := byID[seed[0].CommandID]; found {
+		t.Error("expected oldest completed command to be trimmed")
+	}
+	if _, found := byID[seed[1].CommandID]; found {
+		t.Error("expected second oldest completed command to be trimmed")
+	}
+	// Pending and running commands must always survive
+	if _, found := byID[seed[3].CommandID]; !found {
+		t.Error("expected pending command to survive trim")
+	}
+	if _, found := byID[seed[4].CommandID]; !found {
+		t.Error("expected running command to survive trim")
+	}
+}
+
+func TestTrimCommandHistoryUnlimitedByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("MAX_COMMANDS_PER_DEVICE", "")
+
+	deviceID := uuid.New()
+	for i := 0; i < 5; i++ {
+		cmd := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "completed", CreatedAt: time.Now()}
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	trimCommandHistory(deviceID)
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", deviceID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count commands: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("expected all 5 commands to survive with no cap, got %d", count)
+	}
+}
+
+func TestTrimCommandHistoryNeverDeletesNonTerminal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("MAX_COMMANDS_PER_DEVICE", "1")
+
+	deviceID := uuid.New()
+	for i := 0; i < 4; i++ {
+		cmd := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "pending", CreatedAt: time.Now()}
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	trimCommandHistory(deviceID)
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ?", deviceID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count commands: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected all pending commands to survive, got %d", count)
+	}
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxCommandNackRetries is how many delivery failures a command survives
+// before it is marked failed instead of being re-queued.
+const MaxCommandNackRetries = 3
+
+// NackCommand records that an agent could not apply a dispatched command
+// @Summary Report command receipt failure
+// @Description Record a delivery failure (agent could not apply the command, distinct from execution failure) with a reason. The command is re-queued to pending while under the retry cap, and marked failed once the cap is exhausted.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Param id path string true "Command ID (UUID)"
+// @Param nack body object true "Reason, e.g. {\"reason\": \"unsupported command\"}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid command ID or missing reason"
+// @Failure 404 {object} map[string]string "Command not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /commands/{id}/nack [post]
+func NackCommand(c *gin.Context) {
+	commandID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid command ID")
+		return
+	}
+
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.BindJSON(&payload); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Reason == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "reason is required")
+		return
+	}
+
+	var cmd models.DeviceRemoteCommand
+	if err := database.DB.Where("commandid = ?", commandID).First(&cmd).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "command not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	cmd.NackCount++
+	cmd.LastNackReason = payload.Reason
+
+	// Under the cap the command goes back to pending (and back to the forward
+	// queue); past it, delivery is considered permanently broken
+	requeued := cmd.NackCount < MaxCommandNackRetries
+	if requeued {
+		cmd.Status = "pending"
+		cmd.ForwardStatus = ForwardStatusPending
+	} else {
+		cmd.Status = "failed"
+		cmd.Result = "delivery failed: " + payload.Reason
+	}
+
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("commandid = ?", commandID).
+		Updates(map[string]interface{}{
+			"nack_count":       cmd.NackCount,
+			"last_nack_reason": cmd.LastNackReason,
+			"status":           cmd.Status,
+			"forward_status":   cmd.ForwardStatus,
+			"result":           cmd.Result,
+		}).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"command": cmd, "requeued": requeued})
+}
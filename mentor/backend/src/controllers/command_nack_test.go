@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func nackCommand(t *testing.T, commandID uuid.UUID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: commandID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/commands/"+commandID.String()+"/nack", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	NackCommand(c)
+	return w
+}
+
+func TestNackCommandRequeues(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	cmd := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    uuid.New(),
+		CommandText: "get_info",
+		Status:      "dispatched",
+	}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	w := nackCommand(t, cmd.CommandID, `{"reason": "agent busy"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Command  models.DeviceRemoteCommand `json:"command"`
+		Requeued bool                       `json:"requeued"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if !resp.Requeued {
+		t.Error("expected first nack to requeue the command")
+	}
+
+	var reloaded models.DeviceRemoteCommand
+	if err := database.DB.Where("commandid = ?", cmd.CommandID).First(&reloaded).Error; err != nil {
+		t.Fatalf("failed to reload command: %v", err)
+	}
+	if reloaded.Status != "pending" || reloaded.ForwardStatus != ForwardStatusPending {
+		t.Errorf("expected command back to pending, got status=%q forward=%q", reloaded.Status, reloaded.ForwardStatus)
+	}
+	if reloaded.NackCount != 1 || reloaded.LastNackReason != "agent busy" {
+		t.Errorf("unexpected nack bookkeeping: count=%d reason=%q", reloaded.NackCount, reloaded.LastNackReason)
+	}
+}
+
+func TestNackCommandExhaustsRetries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	cmd := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    uuid.New(),
+		CommandText: "get_info",
+		Status:      "dispatched",
+	}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	for i := 1; i <= MaxCommandNackRetries; i++ {
+		w := nackCommand(t, cmd.CommandID, `{"reason": "still broken"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("nack %d: expected status 200, got %d", i, w.Code)
+		}
+	}
+
+	var reloaded models.DeviceRemoteCommand
+	if err := database.DB.Where("commandid = ?", cmd.CommandID).First(&reloaded).Error; err != nil {
+		t.Fatalf("failed to reload command: %v", err)
+	}
+	if reloaded.Status != "failed" {
+		t.Errorf("expected command failed after %d nacks, got %q", MaxCommandNackRetries, reloaded.Status)
+	}
+	if reloaded.NackCount != MaxCommandNackRetries {
+		t.Errorf("expected nack count %d, got %d", MaxCommandNackRetries, reloaded.NackCount)
+	}
+
+	// Validation errors
+	if w := nackCommand(t, cmd.CommandID, `{"reason": "  "}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty reason, got %d", w.Code)
+	}
+	if w := nackCommand(t, uuid.New(), `{"reason": "x"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown command, got %d", w.Code)
+	}
+}
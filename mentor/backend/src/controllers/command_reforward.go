@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MaxReforwardConcurrency bounds parallel deliveries during a replay so a
+// large backlog cannot overwhelm the devices backend right after it recovers.
+const MaxReforwardConcurrency = 4
+
+// ReforwardResult reports the delivery outcome for one replayed command
+type ReforwardResult struct {
+	CommandID uuid.UUID `json:"commandid"`
+	Forwarded bool      `json:"forwarded"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ReforwardDeviceCommands replays forwarding for undelivered pending commands
+// @Summary Reforward pending commands
+// @Description Re-send a device's pending commands whose forwarding never succeeded (forward status pending or failed) to the devices backend. Used after the backend recovers from an outage. Deliveries run with bounded concurrency and per-command results are returned.
+// @Tags commands
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID or forwarding not configured"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/commands/reforward [post]
+func ReforwardDeviceCommands(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	devicesAPIURL := os.Getenv("DEVICES_API_URL")
+	if devicesAPIURL == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "forwarding is not configured (DEVICES_API_URL is unset)")
+		return
+	}
+
+	// Only still-pending commands that are already due; scheduled-for-later
+	// commands are withheld exactly as on the normal dispatch path
+	commands := make([]models.DeviceRemoteCommand, 0)
+	if err := database.DB.
+		Where("deviceid = ? AND status = 'pending' AND forward_status IN ('pending', 'failed')", deviceID).
+		Where("scheduled_at IS NULL OR scheduled_at <= ?", time.Now()).
+		Order("created_at asc").
+		Find(&commands).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	results := make([]ReforwardResult, len(commands))
+	sem := make(chan struct{}, MaxReforwardConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range commands {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cmd := commands[i]
+			result := ReforwardResult{CommandID: cmd.CommandID}
+			if err := forwardCommand(devicesAPIURL, &cmd); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Forwarded = true
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	// Statuses are recorded after the fan-out so all database writes happen on
+	// the request goroutine
+	for _, r := range results {
+		if r.Forwarded {
+			recordForwardStatus(r.CommandID, ForwardStatusSent)
+		} else {
+			recordForwardStatus(r.CommandID, ForwardStatusFailed)
+		}
+	}
+
+	forwarded := 0
+	for _, r := range results {
+		if r.Forwarded {
+			forwarded++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attempted": len(results),
+		"forwarded": forwarded,
+		"results":   results,
+	})
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func reforwardCommands(t *testing.T, deviceID uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/commands/reforward", nil)
+
+	ReforwardDeviceCommands(c)
+	return w
+}
+
+func TestReforwardDeviceCommands(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// Backend fails until healed, like a recovering outage
+	var healed atomic.Bool
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if healed.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer mockServer.Close()
+	t.Setenv("DEVICES_API_URL", mockServer.URL)
+
+	deviceID := uuid.New()
+	commands := []models.DeviceRemoteCommand{
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_info", Status: "pending", ForwardStatus: ForwardStatusFailed},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "pending", ForwardStatus: ForwardStatusPending},
+		// Already delivered or terminal commands must not be replayed
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "restart", Status: "pending", ForwardStatus: ForwardStatusSent},
+		{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_logs", Status: "completed", ForwardStatus: ForwardStatusFailed},
+	}
+	for _, cmd := range commands {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	// While the backend is still down, replay attempts fail
+	w := reforwardCommands(t, deviceID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var down struct {
+		Attempted int               `json:"attempted"`
+		Forwarded int               `json:"forwarded"`
+		Results   []ReforwardResult `json:"results"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &down); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if down.Attempted != 2 || down.Forwarded != 0 {
+		t.Errorf("expected 2 attempted, 0 forwarded while down, got %+v", down)
+	}
+
+	// After the backend heals, the same replay delivers everything
+	healed.Store(true)
+	w2 := reforwardCommands(t, deviceID)
+	var up struct {
+		Attempted int               `json:"attempted"`
+		Forwarded int               `json:"forwarded"`
+		Results   []ReforwardResult `json:"results"`
+	}
+	if err := json.Unmarshal(w2.Body.Bytes(), &up); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if up.Attempted != 2 || up.Forwarded != 2 {
+		t.Errorf("expected 2 attempted and forwarded after recovery, got %+v", up)
+	}
+
+	var sent int64
+	if err := database.DB.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ? AND forward_status = ?", deviceID, ForwardStatusSent).
+		Count(&sent).Error; err != nil {
+		t.Fatalf("failed to count sent commands: %v", err)
+	}
+	if sent != 3 {
+		t.Errorf("expected 3 commands marked sent, got %d", sent)
+	}
+}
+
+func TestReforwardDeviceCommandsUnconfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("DEVICES_API_URL", "")
+
+	w := reforwardCommands(t, uuid.New())
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without DEVICES_API_URL, got %d", w.Code)
+	}
+}
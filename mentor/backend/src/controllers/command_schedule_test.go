@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func listPendingCommands(t *testing.T, deviceID uuid.UUID) []models.DeviceRemoteCommand {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands/pending", nil)
+
+	GetPendingCommands(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var commands []models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &commands); err != nil {
+		t.Fatalf("failed to unmarshal commands: %v", err)
+	}
+	return commands
+}
+
+func TestScheduledCommandWithheldUntilDue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Minute)
+
+	scheduled := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "restart", Status: "pending", ScheduledAt: &future, CreatedAt: time.Now()}
+	due := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "status", Status: "pending", ScheduledAt: &past, CreatedAt: time.Now()}
+	immediate := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_info", Status: "pending", CreatedAt: time.Now()}
+	for _, cmd := range []models.DeviceRemoteCommand{scheduled, due, immediate} {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	pending := listPendingCommands(t, deviceID)
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 visible pending commands, got %d", len(pending))
+	}
+	for _, cmd := range pending {
+		if cmd.CommandID == scheduled.CommandID {
+			t.Error("future-scheduled command should be withheld from the pending queue")
+		}
+	}
+}
+
+func TestCreateCommandRejectsPastSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	past := time.Now().Add(-time.Hour)
+	cmd := models.DeviceRemoteCommand{DeviceID: uuid.New(), CommandText: "restart", ScheduledAt: &past}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(cmd)
+	c.Request, _ = http.NewRequest("POST", "/devices/commands", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	CreateRemoteCommand(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for past scheduled_at, got %d", w.Code)
+	}
+}
+
+func TestCreateCommandAcceptsFutureSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	future := time.Now().Add(time.Hour)
+	cmd := models.DeviceRemoteCommand{DeviceID: uuid.New(), CommandText: "restart", ScheduledAt: &future}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(cmd)
+	c.Request, _ = http.NewRequest("POST", "/devices/commands", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	CreateRemoteCommand(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var created models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal command: %v", err)
+	}
+	if created.ScheduledAt == nil {
+		t.Error("expected scheduled_at to be preserved on the created command")
+	}
+	if created.Status != "pending" {
+		t.Errorf("expected scheduled command to be pending, got %q", created.Status)
+	}
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CommandStats aggregates outcomes for one normalized command text
+type CommandStats struct {
+	CommandText string  `json:"command_text"`
+	Completed   int64   `json:"completed"`
+	Failed      int64   `json:"failed"`
+	Total       int64   `json:"total"`
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// GetCommandStats reports success rates grouped by command text
+// @Summary Get command success rates
+// @Description Aggregate terminal (completed or failed) commands grouped by normalized command text, with per-command success rates. Helps operators spot commands that reliably fail.
+// @Tags commands
+// @Produce json
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Param device_id query string false "Restrict to a single device (UUID)"
+// @Success 200 {array} controllers.CommandStats
+// @Failure 400 {object} map[string]string "Bad request - invalid time range or device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /commands/stats [get]
+func GetCommandStats(c *gin.Context) {
+	// Only terminal commands count toward the rate; pending/running say nothing
+	// about whether a command succeeds
+	query := database.Read().Table("device_remote_commands").
+		Select("LOWER(TRIM(command_text)) AS command_text, " +
+			"COUNT(*) FILTER (WHERE LOWER(status) = 'completed') AS completed, " +
+			"COUNT(*) FILTER (WHERE LOWER(status) = 'failed') AS failed, " +
+			"COUNT(*) AS total").
+		Where("LOWER(status) IN ('completed', 'failed')").
+		Group("LOWER(TRIM(command_text))").
+		Order("total DESC")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("created_at >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("created_at <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if rawID := c.Query("device_id"); rawID != "" {
+		deviceID, err := uuid.Parse(rawID)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device_id parameter")
+			return
+		}
+		query = query.Where("deviceid = ?", deviceID)
+	}
+
+	stats := make([]CommandStats, 0)
+	if err := query.Scan(&stats).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	for i := range stats {
+		if stats[i].Total > 0 {
+			stats[i].SuccessRate = float64(stats[i].Completed) / float64(stats[i].Total)
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
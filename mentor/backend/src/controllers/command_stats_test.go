@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getCommandStats(t *testing.T, query string) []CommandStats {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/commands/stats"+query, nil)
+
+	GetCommandStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats []CommandStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	return stats
+}
+
+func TestGetCommandStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceA := uuid.New()
+	deviceB := uuid.New()
+
+	commands := []models.DeviceRemoteCommand{
+		{CommandID: uuid.New(), DeviceID: deviceA, CommandText: "restart", Status: "completed"},
+		{CommandID: uuid.New(), DeviceID: deviceA, CommandText: "restart", Status: "failed"},
+		{CommandID: uuid.New(), DeviceID: deviceB, CommandText: "Restart", Status: "completed"},
+		{CommandID: uuid.New(), DeviceID: deviceA, CommandText: "get_info", Status: "completed"},
+		// Non-terminal commands must not affect the rates
+		{CommandID: uuid.New(), DeviceID: deviceA, CommandText: "restart", Status: "pending"},
+	}
+	for _, cmd := range commands {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	stats := getCommandStats(t, "")
+	byText := map[string]CommandStats{}
+	for _, s := range stats {
+		byText[s.CommandText] = s
+	}
+
+	restart, ok := byText["restart"]
+	if !ok {
+		t.Fatalf("expected restart stats, got %v", stats)
+	}
+	if restart.Completed != 2 || restart.Failed != 1 || restart.Total != 3 {
+		t.Errorf("expected restart 2/1 of 3, got %+v", restart)
+	}
+	if restart.SuccessRate < 0.66 || restart.SuccessRate > 0.67 {
+		t.Errorf("expected restart success rate ~0.667, got %f", restart.SuccessRate)
+	}
+
+	info, ok := byText["get_info"]
+	if !ok {
+		t.Fatalf("expected get_info stats, got %v", stats)
+	}
+	if info.SuccessRate != 1.0 {
+		t.Errorf("expected get_info success rate 1.0, got %f", info.SuccessRate)
+	}
+
+	// Device filter restricts the aggregation
+	filtered := getCommandStats(t, "?device_id="+deviceA.String())
+	for _, s := range filtered {
+		if s.CommandText == "restart" && s.Total != 2 {
+			t.Errorf("expected 2 terminal restarts for device A, got %d", s.Total)
+		}
+	}
+}
+
+func TestGetCommandStatsInvalidDeviceID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/commands/stats?device_id=not-a-uuid", nil)
+
+	GetCommandStats(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid device_id, got %d", w.Code)
+	}
+}
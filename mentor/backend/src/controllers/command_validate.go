@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// disruptiveCommands are allowed but interrupt users or service availability,
+// so the UI warns operators before sending them.
+var disruptiveCommands = map[string]bool{
+	"restart":         true,
+	"restart_service": true,
+}
+
+// CommandValidationResult is the verdict for a command the UI has not sent yet
+type CommandValidationResult struct {
+	OK       bool     `json:"ok"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+}
+
+// ValidateCommand checks a command without queuing it
+// @Summary Validate a command
+// @Description Run the same validation as command creation (required fields, whitelist, condition syntax, scheduling) and return the verdict without persisting anything. Lets the UI give real-time feedback in the command box.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Param command body models.DeviceRemoteCommand true "Command to validate"
+// @Success 200 {object} controllers.CommandValidationResult
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON"
+// @Router /commands/validate [post]
+func ValidateCommand(c *gin.Context) {
+	var cmd models.DeviceRemoteCommand
+	if err := c.BindJSON(&cmd); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	result := CommandValidationResult{
+		Warnings: make([]string, 0),
+		Errors:   make([]string, 0),
+	}
+
+	// Same checks as CreateRemoteCommand, collected instead of rejected
+	if cmd.DeviceID == uuid.Nil || strings.TrimSpace(cmd.CommandText) == "" {
+		result.Errors = append(result.Errors, "deviceid and command_text are required")
+	} else {
+		result.Errors = append(result.Errors, cmd.ValidateRemoteCommand()...)
+	}
+
+	if cmd.ScheduledAt != nil && cmd.ScheduledAt.Before(time.Now()) {
+		result.Errors = append(result.Errors, "scheduled_at must be in the future")
+	}
+
+	commandBase := strings.ToLower(strings.TrimSpace(strings.Split(cmd.CommandText, " ")[0]))
+	if disruptiveCommands[commandBase] {
+		result.Warnings = append(result.Warnings, "this command is disruptive: it may interrupt the device user or running services")
+	}
+
+	result.OK = len(result.Errors) == 0
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func validateCommand(t *testing.T, cmd models.DeviceRemoteCommand) CommandValidationResult {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(cmd)
+	c.Request, _ = http.NewRequest("POST", "/commands/validate", bytes.NewReader(b))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	ValidateCommand(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var result CommandValidationResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+	return result
+}
+
+func TestValidateCommandClean(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	result := validateCommand(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "get_info",
+	})
+	if !result.OK {
+		t.Errorf("expected clean command to validate, got errors: %v", result.Errors)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("expected no warnings for get_info, got %v", result.Warnings)
+	}
+}
+
+func TestValidateCommandDenied(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	result := validateCommand(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "rm -rf /",
+	})
+	if result.OK {
+		t.Error("expected denied command to fail validation")
+	}
+	if len(result.Errors) == 0 {
+		t.Error("expected errors describing the denial")
+	}
+}
+
+func TestValidateCommandWarned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	result := validateCommand(t, models.DeviceRemoteCommand{
+		DeviceID:    uuid.New(),
+		CommandText: "restart",
+	})
+	if !result.OK {
+		t.Errorf("expected restart to be allowed, got errors: %v", result.Errors)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("expected a disruptive-command warning, got %v", result.Warnings)
+	}
+}
+
+func TestValidateCommandMissingFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	result := validateCommand(t, models.DeviceRemoteCommand{})
+	if result.OK {
+		t.Error("expected empty command to fail validation")
+	}
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func submitMetric(t *testing.T, metric models.DeviceMetric) models.DeviceMetric {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(metric)
+	c.Request, _ = http.NewRequest("POST", "/devices/metrics", bytes.NewReader(b))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	UpdateDeviceMetric(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stored models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("failed to unmarshal metric: %v", err)
+	}
+	return stored
+}
+
+func TestCounterResetDetection(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("COUNTER_RESET_DETECTION", "true")
+
+	deviceID := uuid.New()
+
+	first := submitMetric(t, models.DeviceMetric{DeviceID: deviceID, NetBytesIn: 1000, NetBytesOut: 500})
+	if first.CounterReset {
+		t.Error("expected no reset flag on the first sample")
+	}
+
+	// Normal increment: counters grew
+	grown := submitMetric(t, models.DeviceMetric{DeviceID: deviceID, NetBytesIn: 2000, NetBytesOut: 900})
+	if grown.CounterReset {
+		t.Error("expected no reset flag when counters increase")
+	}
+
+	// Reboot: counters dropped back toward zero
+	reset := submitMetric(t, models.DeviceMetric{DeviceID: deviceID, NetBytesIn: 50, NetBytesOut: 10})
+	if !reset.CounterReset {
+		t.Error("expected reset flag when counters drop")
+	}
+}
+
+func TestCounterResetDetectionDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("COUNTER_RESET_DETECTION", "")
+
+	deviceID := uuid.New()
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, NetBytesIn: 1000, NetBytesOut: 500})
+	dropped := submitMetric(t, models.DeviceMetric{DeviceID: deviceID, NetBytesIn: 10, NetBytesOut: 5})
+	if dropped.CounterReset {
+		t.Error("expected no reset flag when detection is disabled")
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// reportSampleInterval is the assumed spacing between metric samples, used to
+// turn a day's sample count into an uptime estimate.
+const reportSampleInterval = time.Minute
+
+// ComputeDailyReport aggregates one device's raw telemetry for the given day
+// into a DeviceDailyReport. The day is normalized to UTC midnight; the report
+// is computed but not stored so callers (the nightly job, tests) decide
+// persistence.
+func ComputeDailyReport(deviceID uuid.UUID, day time.Time) (models.DeviceDailyReport, error) {
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.Add(24 * time.Hour)
+
+	report := models.DeviceDailyReport{
+		DeviceID: deviceID,
+		Day:      dayStart,
+	}
+
+	db := database.Read()
+	var stats struct {
+		Samples int64
+		AvgCPU  float64
+		PeakCPU float64
+	}
+	if err := db.Model(&models.DeviceMetric{}).
+		Select("COUNT(*) as samples, COALESCE(AVG(cpu_usage), 0) as avg_cpu, COALESCE(MAX(cpu_usage), 0) as peak_cpu").
+		Where("deviceid = ? AND timestamp >= ? AND timestamp < ?", deviceID, dayStart, dayEnd).
+		Scan(&stats).Error; err != nil {
+		return report, err
+	}
+	report.AvgCPU = stats.AvgCPU
+	report.PeakCPU = stats.PeakCPU
+
+	uptime := time.Duration(stats.Samples) * reportSampleInterval
+	if uptime > 24*time.Hour {
+		uptime = 24 * time.Hour
+	}
+	report.UptimeSeconds = int64(uptime.Seconds())
+
+	if err := db.Model(&models.DeviceAlert{}).
+		Where("deviceid = ? AND timestamp >= ? AND timestamp < ?", deviceID, dayStart, dayEnd).
+		Count(&report.AlertCount).Error; err != nil {
+		return report, err
+	}
+
+	if err := db.Model(&models.DeviceRemoteCommand{}).
+		Where("deviceid = ? AND created_at >= ? AND created_at < ?", deviceID, dayStart, dayEnd).
+		Count(&report.CommandCount).Error; err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// GenerateDailyReports computes and stores yesterday's report for every
+// device, replacing any existing report for that day so reruns are safe.
+// Returns the number of reports written.
+func GenerateDailyReports() (int64, error) {
+	day := time.Now().UTC().AddDate(0, 0, -1)
+
+	var deviceIDs []uuid.UUID
+	if err := database.Read().Model(&models.Device{}).Pluck("deviceid", &deviceIDs).Error; err != nil {
+		return 0, err
+	}
+
+	var written int64
+	for _, deviceID := range deviceIDs {
+		report, err := ComputeDailyReport(deviceID, day)
+		if err != nil {
+			fmt.Printf("Error computing daily report for device %s: %v\n", deviceID, err)
+			continue
+		}
+		report.GeneratedAt = time.Now()
+		if err := database.DB.
+			Where("deviceid = ? AND day = ?", report.DeviceID, report.Day).
+			Assign(report).
+			FirstOrCreate(&models.DeviceDailyReport{}).Error; err != nil {
+			fmt.Printf("Error storing daily report for device %s: %v\n", deviceID, err)
+			continue
+		}
+		written++
+	}
+	return written, nil
+}
+
+// GetDeviceReports returns stored daily reports for a device
+// @Summary Get device daily reports
+// @Description Return the precomputed daily summaries (avg/peak CPU, alert and command counts, uptime estimate) for a device over a date range. Reports are written by the nightly job, so very recent days may not be present yet.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID"
+// @Param from query string false "Start of date range (RFC3339)"
+// @Param to query string false "End of date range (RFC3339)"
+// @Success 200 {array} models.DeviceDailyReport
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/reports [get]
+func GetDeviceReports(c *gin.Context) {
+	deviceID := c.Param("id")
+
+	query := database.Read().Where("deviceid = ?", deviceID)
+	if from := c.Query("from"); from != "" {
+		fromTime, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		query = query.Where("day >= ?", fromTime)
+	}
+	if to := c.Query("to"); to != "" {
+		toTime, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		query = query.Where("day <= ?", toTime)
+	}
+
+	reports := make([]models.DeviceDailyReport, 0)
+	if err := query.Order("day asc").Find(&reports).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestComputeDailyReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	day := time.Date(2026, 8, 20, 0, 0, 0, 0, time.UTC)
+
+	for i, cpu := range []float64{20, 40, 90} {
+		metric := models.DeviceMetric{
+			MetricID:  uuid.New(),
+			DeviceID:  deviceID,
+			Timestamp: day.Add(time.Duration(i) * time.Hour),
+			CPUUsage:  cpu,
+		}
+		if err := database.DB.Create(&metric).Error; err != nil {
+			t.Fatalf("failed to create metric: %v", err)
+		}
+	}
+	// Outside the day: must not count.
+	outside := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: day.Add(25 * time.Hour), CPUUsage: 100}
+	if err := database.DB.Create(&outside).Error; err != nil {
+		t.Fatalf("failed to create metric: %v", err)
+	}
+
+	alert := models.DeviceAlert{AlertID: uuid.New(), DeviceID: deviceID, Timestamp: day.Add(2 * time.Hour), Level: "warning", AlertType: "cpu"}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to create alert: %v", err)
+	}
+	cmd := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "uptime", Status: "completed", CreatedAt: day.Add(3 * time.Hour)}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+
+	report, err := ComputeDailyReport(deviceID, day.Add(10*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to compute report: %v", err)
+	}
+	if !report.Day.Equal(day) {
+		t.Errorf("expected day normalized to %v, got %v", day, report.Day)
+	}
+	if report.AvgCPU != 50 {
+		t.Errorf("expected avg CPU 50, got %v", report.AvgCPU)
+	}
+	if report.PeakCPU != 90 {
+		t.Errorf("expected peak CPU 90, got %v", report.PeakCPU)
+	}
+	if report.AlertCount != 1 || report.CommandCount != 1 {
+		t.Errorf("expected 1 alert and 1 command, got %d and %d", report.AlertCount, report.CommandCount)
+	}
+	if report.UptimeSeconds != int64(3*reportSampleInterval.Seconds()) {
+		t.Errorf("expected uptime from 3 samples, got %d", report.UptimeSeconds)
+	}
+}
+
+func TestGetDeviceReports(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for dayOffset := 0; dayOffset < 3; dayOffset++ {
+		report := models.DeviceDailyReport{
+			ReportID: uuid.New(),
+			DeviceID: deviceID,
+			Day:      time.Date(2026, 8, 18+dayOffset, 0, 0, 0, 0, time.UTC),
+			AvgCPU:   float64(10 * dayOffset),
+		}
+		if err := database.DB.Create(&report).Error; err != nil {
+			t.Fatalf("failed to create report: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/reports?from=2026-08-19T00:00:00Z", nil)
+
+	GetDeviceReports(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var reports []models.DeviceDailyReport
+	if err := json.Unmarshal(w.Body.Bytes(), &reports); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports from the 19th onward, got %d", len(reports))
+	}
+	if !reports[0].Day.Before(reports[1].Day) {
+		t.Errorf("expected reports ordered by day ascending, got %+v", reports)
+	}
+}
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultDashboardLiveInterval is how often the live gauges are recomputed
+// and pushed when DASHBOARD_LIVE_INTERVAL is not set.
+const DefaultDashboardLiveInterval = 5 * time.Second
+
+func dashboardLiveInterval() time.Duration {
+	if raw := os.Getenv("DASHBOARD_LIVE_INTERVAL"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultDashboardLiveInterval
+}
+
+// DashboardGauges is one live frame of fleet-wide numbers for the overview
+// header: computed once per tick and fanned out to every subscriber.
+type DashboardGauges struct {
+	Timestamp     time.Time `json:"timestamp"`
+	OnlineDevices int64     `json:"online_devices"`
+	AvgCPUUsage   float64   `json:"avg_cpu_usage"`
+	ActiveAlerts  int64     `json:"active_alerts"`
+}
+
+// dashboardHub fans live gauge frames out to SSE subscribers. One shared
+// ticker recomputes the gauges for all subscribers, so a hundred open
+// dashboards cost the same three queries as one. The ticker runs only while
+// someone is subscribed.
+type dashboardHub struct {
+	mu          sync.Mutex
+	subscribers map[chan DashboardGauges]struct{}
+	stop        chan struct{}
+}
+
+// dashboardLive is the process-wide hub feeding /dashboard/live streams
+var dashboardLive = &dashboardHub{
+	subscribers: make(map[chan DashboardGauges]struct{}),
+}
+
+// Subscribe registers a new subscriber and starts the shared ticker if this
+// is the first one.
+func (h *dashboardHub) Subscribe() chan DashboardGauges {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan DashboardGauges, 4)
+	h.subscribers[ch] = struct{}{}
+	if h.stop == nil {
+		h.stop = make(chan struct{})
+		go h.run(h.stop)
+	}
+	return ch
+}
+
+// Unsubscribe removes a subscriber and stops the ticker with the last one.
+func (h *dashboardHub) Unsubscribe(ch chan DashboardGauges) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, ch)
+	close(ch)
+	if len(h.subscribers) == 0 && h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+}
+
+// Broadcast delivers a frame to every subscriber without blocking; a stalled
+// subscriber misses frames rather than delaying the rest.
+func (h *dashboardHub) Broadcast(gauges DashboardGauges) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- gauges:
+		default:
+		}
+	}
+}
+
+// run recomputes and broadcasts the gauges on the shared ticker until the
+// last subscriber leaves.
+func (h *dashboardHub) run(stop chan struct{}) {
+	ticker := time.NewTicker(dashboardLiveInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			gauges, err := computeDashboardGauges()
+			if err != nil {
+				fmt.Printf("Error computing dashboard gauges: %v\n", err)
+				continue
+			}
+			h.Broadcast(gauges)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// computeDashboardGauges runs the fleet-wide aggregate queries for one frame.
+func computeDashboardGauges() (DashboardGauges, error) {
+	gauges := DashboardGauges{Timestamp: time.Now()}
+	db := database.Read()
+
+	if err := db.Model(&models.Device{}).
+		Where("last_seen > ?", time.Now().Add(-deviceOfflineThreshold())).
+		Count(&gauges.OnlineDevices).Error; err != nil {
+		return gauges, err
+	}
+
+	// Average CPU over the most recent samples: the last live interval's
+	// worth of metrics approximates "current" without a per-device lookup.
+	var avg struct{ Avg float64 }
+	if err := db.Model(&models.DeviceMetric{}).
+		Select("COALESCE(AVG(cpu_usage), 0) as avg").
+		Where("timestamp >= ?", time.Now().Add(-5*time.Minute)).
+		Scan(&avg).Error; err != nil {
+		return gauges, err
+	}
+	gauges.AvgCPUUsage = avg.Avg
+
+	if err := db.Model(&models.DeviceAlert{}).
+		Where("timestamp >= ? AND NOT acknowledged", time.Now().Add(-24*time.Hour)).
+		Count(&gauges.ActiveAlerts).Error; err != nil {
+		return gauges, err
+	}
+
+	return gauges, nil
+}
+
+// StreamDashboardGauges streams live fleet gauges via SSE
+// @Summary Stream live fleet gauges
+// @Description Server-sent events stream pushing fleet-wide gauges (online device count, average CPU over the last five minutes, unacknowledged alerts from the last day) every few seconds. All subscribers share one recompute ticker; the interval comes from DASHBOARD_LIVE_INTERVAL.
+// @Tags dashboard
+// @Produce text/event-stream
+// @Success 200 {string} string "SSE stream of gauge frames"
+// @Router /dashboard/live [get]
+func StreamDashboardGauges(c *gin.Context) {
+	ch := dashboardLive.Subscribe()
+	defer dashboardLive.Unsubscribe(ch)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	// Immediate first frame so dashboards render without waiting for a tick
+	if gauges, err := computeDashboardGauges(); err == nil {
+		c.SSEvent("gauges", gauges)
+		c.Writer.Flush()
+	}
+
+	clientGone := c.Request.Context().Done()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case gauges, ok := <-ch:
+			if !ok {
+				return false
+			}
+			c.SSEvent("gauges", gauges)
+			return true
+		case <-clientGone:
+			return false
+		}
+	})
+}
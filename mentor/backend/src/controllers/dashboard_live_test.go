@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDashboardHubBroadcast(t *testing.T) {
+	hub := &dashboardHub{subscribers: make(map[chan DashboardGauges]struct{})}
+
+	first := hub.Subscribe()
+	second := hub.Subscribe()
+
+	frame := DashboardGauges{Timestamp: time.Now(), OnlineDevices: 7}
+	hub.Broadcast(frame)
+
+	for _, ch := range []chan DashboardGauges{first, second} {
+		select {
+		case got := <-ch:
+			if got.OnlineDevices != 7 {
+				t.Errorf("expected broadcast frame, got %+v", got)
+			}
+		default:
+			t.Error("expected every subscriber to receive the frame")
+		}
+	}
+
+	// Unsubscribing the last subscriber stops the shared ticker.
+	hub.Unsubscribe(first)
+	hub.Unsubscribe(second)
+	hub.mu.Lock()
+	if len(hub.subscribers) != 0 || hub.stop != nil {
+		t.Errorf("expected hub idle after last unsubscribe, got %d subscribers", len(hub.subscribers))
+	}
+	hub.mu.Unlock()
+}
+
+func TestStreamDashboardGauges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("DASHBOARD_LIVE_INTERVAL", "50ms")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/dashboard/live", nil)
+	c.Request = req
+
+	// Blocks until the client context expires, collecting frames meanwhile.
+	StreamDashboardGauges(c)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected SSE content type, got %q", ct)
+	}
+	frames := strings.Count(w.Body.String(), "event:gauges")
+	if frames < 2 {
+		t.Errorf("expected at least the initial frame plus one tick, got %d frames: %s", frames, w.Body.String())
+	}
+}
@@ -1,15 +1,15 @@
 package controllers
 
 import (
-	"bytes"
-	"encoding/json"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"mentor-backend/database"
 	"mentor-backend/models"
 	"mentor-backend/s3"
-	"mentor-backend/util"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,16 +20,48 @@ import (
 
 // Constants for device and metrics handling
 const (
-	// DeviceOfflineThreshold is the time in minutes after which a device is considered offline
-	DeviceOfflineThreshold = 5 * time.Minute
+	// DefaultDeviceOfflineThreshold is how long a device may go unseen before
+	// it is considered offline when OFFLINE_THRESHOLD_SECONDS is not set
+	DefaultDeviceOfflineThreshold = 5 * time.Minute
 	// DefaultMetricsLimit is the default number of metrics to return
 	DefaultMetricsLimit = 60
 	// DefaultProcessesLimit is the default number of processes to return
 	DefaultProcessesLimit = 100
 	// MaxScreenshotsLimit is the maximum number of screenshots to return
 	MaxScreenshotsLimit = 1000
+	// DefaultDeviceUUIDNamespace is the UUIDv5 namespace used to derive device IDs
+	// from hardware IDs when DEVICE_UUID_NAMESPACE is not set
+	DefaultDeviceUUIDNamespace = "5f5e9b1e-9c4b-4f7a-8d2e-3a1b6c8d9e0f"
 )
 
+// deviceOfflineThreshold returns the configured offline cutoff, honoring the
+// OFFLINE_THRESHOLD_SECONDS environment variable so fleets with slower
+// check-in intervals aren't all flagged offline. Unparseable values log a
+// warning and fall back to the default rather than aborting startup.
+func deviceOfflineThreshold() time.Duration {
+	raw := os.Getenv("OFFLINE_THRESHOLD_SECONDS")
+	if raw == "" {
+		return DefaultDeviceOfflineThreshold
+	}
+	seconds, err := strconv.Atoi(raw)
+	if err != nil || seconds <= 0 {
+		fmt.Printf("warning: invalid OFFLINE_THRESHOLD_SECONDS %q, using default %s\n", raw, DefaultDeviceOfflineThreshold)
+		return DefaultDeviceOfflineThreshold
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// deviceUUIDNamespace returns the namespace UUID used for deterministic device
+// ID generation, honoring the DEVICE_UUID_NAMESPACE environment variable.
+func deviceUUIDNamespace() uuid.UUID {
+	if ns := os.Getenv("DEVICE_UUID_NAMESPACE"); ns != "" {
+		if parsed, err := uuid.Parse(ns); err == nil {
+			return parsed
+		}
+	}
+	return uuid.MustParse(DefaultDeviceUUIDNamespace)
+}
+
 // RegisterDevice registers a new device or updates existing device info
 // @Summary Register a device
 // @Description Register a new device or update existing device information
@@ -44,13 +76,19 @@ const (
 func RegisterDevice(c *gin.Context) {
 	var device models.Device
 	if err := c.BindJSON(&device); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid request body: "+err.Error())
 		return
 	}
 
-	// Generate UUID if not provided
+	// Generate UUID if not provided. Devices that cannot generate UUIDs may send
+	// a stable hardware ID instead; derive a deterministic UUIDv5 from it so
+	// re-registration always yields the same device UUID.
 	if device.DeviceID == uuid.Nil {
-		device.DeviceID = uuid.New()
+		if device.HardwareID != "" {
+			device.DeviceID = uuid.NewSHA1(deviceUUIDNamespace(), []byte(device.HardwareID))
+		} else {
+			device.DeviceID = uuid.New()
+		}
 	}
 
 	device.LastSeen = time.Now()
@@ -62,13 +100,27 @@ func RegisterDevice(c *gin.Context) {
 		FirstOrCreate(&device)
 
 	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "database operation failed: " + result.Error.Error()})
+		RespondInternalError(c, result.Error)
 		return
 	}
 
+	// Keep the session history in step with the reported user; a failure here
+	// should not fail the registration itself
+	if err := trackUserSession(device.DeviceID, device.CurrentUser); err != nil {
+		fmt.Printf("Error tracking user session for device %s: %v\n", device.DeviceID, err)
+	}
+
 	c.JSON(http.StatusOK, device)
 }
 
+// counterResetDetectionEnabled reports whether ingest compares monotonic
+// counters against the previous sample (COUNTER_RESET_DETECTION env). Off by
+// default: it costs one extra lookup per metric submission.
+func counterResetDetectionEnabled() bool {
+	v := strings.ToLower(os.Getenv("COUNTER_RESET_DETECTION"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
 // UpdateDeviceMetric stores new device metrics
 // @Summary Submit device metrics
 // @Description Store performance metrics for a device (CPU, memory, disk, network)
@@ -83,7 +135,7 @@ func RegisterDevice(c *gin.Context) {
 func UpdateDeviceMetric(c *gin.Context) {
 	var metrics models.DeviceMetric
 	if err := c.BindJSON(&metrics); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -94,18 +146,53 @@ func UpdateDeviceMetric(c *gin.Context) {
 
 	metrics.Timestamp = time.Now()
 
+	// Flag monotonic counter resets (reboot or wrap) when detection is enabled,
+	// so rate computations can treat the drop as a restart instead of a
+	// negative delta
+	if counterResetDetectionEnabled() {
+		var prev models.DeviceMetric
+		if err := database.DB.Where("deviceid = ?", metrics.DeviceID).
+			Order("timestamp desc").
+			First(&prev).Error; err == nil {
+			if metrics.NetBytesIn < prev.NetBytesIn || metrics.NetBytesOut < prev.NetBytesOut {
+				metrics.CounterReset = true
+			}
+		}
+	}
+
+	// With write-ahead buffering enabled the metric is accepted now and
+	// persisted by the next batch flush; the rollup is updated at flush time
+	if bufferMetric(metrics) {
+		if shouldWriteLastSeen(metrics.DeviceID) {
+			database.DB.Model(&models.Device{}).
+				Where("deviceid = ?", metrics.DeviceID).
+				Updates(map[string]interface{}{
+					"last_seen": time.Now(),
+					"is_online": true,
+				})
+		}
+		c.JSON(http.StatusOK, metrics)
+		return
+	}
+
 	if err := database.DB.Create(&metrics).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
-	// Update device last seen
-	database.DB.Model(&models.Device{}).
-		Where("deviceid = ?", metrics.DeviceID).
-		Updates(map[string]interface{}{
-			"last_seen": time.Now(),
-			"is_online": true,
-		})
+	// Keep the hourly rollup current so long-range queries stay cheap
+	updateMetricRollup(metrics.DeviceID, metrics.Timestamp)
+
+	// Update device last seen, unless a recent metric already did and the
+	// debounce interval has not elapsed
+	if shouldWriteLastSeen(metrics.DeviceID) {
+		database.DB.Model(&models.Device{}).
+			Where("deviceid = ?", metrics.DeviceID).
+			Updates(map[string]interface{}{
+				"last_seen": time.Now(),
+				"is_online": true,
+			})
+	}
 
 	c.JSON(http.StatusOK, metrics)
 }
@@ -124,10 +211,24 @@ func UpdateDeviceMetric(c *gin.Context) {
 func Activity(c *gin.Context) {
 	var activity models.DeviceActivity
 	if err := c.BindJSON(&activity); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
+	// Agents double-report activities on flaky connections; when dedup is
+	// enabled, return the recent duplicate instead of inserting another row
+	if window := activityDedupWindow(); window > 0 {
+		var existing models.DeviceActivity
+		if err := database.DB.Where(
+			"deviceid = ? AND activity_type = ? AND app = ? AND timestamp >= ?",
+			activity.DeviceID, activity.ActivityType, activity.App, time.Now().Add(-window)).
+			Order("timestamp desc").
+			First(&existing).Error; err == nil {
+			c.JSON(http.StatusOK, existing)
+			return
+		}
+	}
+
 	// Ensure primary key is set; rely on application generation instead of DB default
 	if activity.ActivityID == uuid.Nil {
 		activity.ActivityID = uuid.New()
@@ -135,7 +236,7 @@ func Activity(c *gin.Context) {
 	activity.Timestamp = time.Now()
 
 	if err := database.DB.Create(&activity).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -156,7 +257,7 @@ func Activity(c *gin.Context) {
 func UpdateProcessList(c *gin.Context) {
 	var processes []models.DeviceProcess
 	if err := c.BindJSON(&processes); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -165,7 +266,7 @@ func UpdateProcessList(c *gin.Context) {
 	if id := c.Param("id"); id != "" {
 		parsedID, err := uuid.Parse(id)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid device ID"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "Invalid device ID")
 			return
 		}
 		deviceID = parsedID
@@ -201,7 +302,7 @@ func UpdateProcessList(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -213,32 +314,80 @@ func UpdateProcessList(c *gin.Context) {
 // @Description Get a list of all registered devices with their current status
 // @Tags devices
 // @Produce json
+// @Param q query string false "Case-insensitive substring match against name, current user, IP address and location"
+// @Param limit query int false "Number of records to return (all when omitted)"
+// @Param offset query int false "Number of records to skip"
+// @Param envelope query bool false "Wrap the response as {data, pagination} instead of a bare array"
 // @Success 200 {array} models.Device
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /devices [get]
 func ListDevices(c *gin.Context) {
-	devices := make([]models.Device, 0)
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter: must be a positive integer")
+			return
+		}
+	}
+	offset, err := parseOffsetParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	search := c.Query("q")
 
-	// Mark devices as offline if not seen in last 5 minutes
-	database.DB.Model(&models.Device{}).
-		Where("last_seen < ?", time.Now().Add(-DeviceOfflineThreshold)).
-		Update("is_online", false)
+	devices := make([]models.Device, 0)
 
-	if err := database.DB.Find(&devices).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve devices: " + err.Error()})
+	query := database.Read().Order("device_name asc")
+	if search != "" {
+		query = applyDeviceSearch(query, search)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Find(&devices).Error; err != nil {
+		RespondInternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, devices)
+	// Online status is computed from last_seen for the response; the stored
+	// flag is reconciled by the periodic background job, keeping this read
+	// path free of fleet-wide writes
+	threshold := time.Now().Add(-deviceOfflineThreshold())
+	for i := range devices {
+		devices[i].IsOnline = devices[i].LastSeen.After(threshold)
+	}
+
+	applyDeviceMasking(c, devices)
+
+	respondList(c, devices, limit, offset, func() (int64, error) {
+		var total int64
+		countQuery := database.Read().Model(&models.Device{})
+		if search != "" {
+			countQuery = applyDeviceSearch(countQuery, search)
+		}
+		err := countQuery.Count(&total).Error
+		return total, err
+	})
 }
 
 // GetDeviceMetric returns metrics for a specific device
 // @Summary Get device metrics
-// @Description Get recent metrics for a specific device
+// @Description Get recent metrics for a specific device. format=csv streams the same rows as text/csv with RFC3339 timestamps; the limit and time-range filters apply either way, while precision and smooth only shape the JSON response.
 // @Tags devices
-// @Produce json
+// @Produce json,text/csv
 // @Param id path string true "Device ID"
 // @Param limit query int false "Number of records to return" default(60)
+// @Param format query string false "Response format: json (default) or csv"
+// @Param last query string false "Relative window expanded server-side (e.g. 1h, 30m); mutually exclusive with from/to"
+// @Param from query string false "Start of time range (RFC3339); may be combined with to"
+// @Param to query string false "End of time range (RFC3339); may be combined with from"
+// @Param precision query int false "Round float fields to this many decimal places in the response (storage stays full-precision)"
+// @Param smooth query int false "Replace float fields with a trailing moving average over this many points (storage stays raw)"
 // @Success 200 {array} models.DeviceMetric
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -248,30 +397,109 @@ func GetDeviceMetric(c *gin.Context) {
 	limit := DefaultMetricsLimit
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter: must be a positive integer"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter: must be a positive integer")
 			return
 		}
 		if limit <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be greater than 0"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "limit must be greater than 0")
 			return
 		}
 	}
-	// Parse device id as UUID; if invalid, return empty array
-	if _, err := uuid.Parse(c.Param("id")); err != nil {
+	since, hasLast, err := parseLastParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	precision, err := parsePrecisionParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	smooth, err := parseSmoothParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid format: must be json or csv")
+		return
+	}
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		if fromTime, err = time.Parse(time.RFC3339, from); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if toTime, err = time.Parse(time.RFC3339, to); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	// Parse device id as UUID; if invalid, return an empty result
+	deviceUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		if format == "csv" {
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Status(http.StatusOK)
+			writer := csv.NewWriter(c.Writer)
+			if err := writer.Write(metricCSVHeader); err != nil {
+				fmt.Printf("Error writing metric CSV header: %v\n", err)
+			}
+			writer.Flush()
+			return
+		}
 		c.JSON(http.StatusOK, []models.DeviceMetric{})
 		return
 	}
-	deviceID := c.Param("id")
+	deviceID := deviceUUID.String()
+
+	query := database.Read().Model(&models.DeviceMetric{}).Where("deviceid = ?", deviceID)
+	if hasLast {
+		query = query.Where("timestamp >= ?", since)
+	}
+	if !fromTime.IsZero() {
+		query = query.Where("timestamp >= ?", fromTime)
+	}
+	if !toTime.IsZero() {
+		query = query.Where("timestamp <= ?", toTime)
+	}
+	query = query.Order("timestamp desc").Limit(limit)
+
+	// CSV streams straight off the row cursor so large exports never sit fully
+	// in memory; precision and smoothing stay JSON-only
+	if format == "csv" {
+		rows, err := query.Rows()
+		if err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		defer func() {
+			if err := rows.Close(); err != nil {
+				fmt.Printf("Error closing metric CSV rows: %v\n", err)
+			}
+		}()
+		exportMetricsCSV(c, deviceUUID, rows)
+		return
+	}
 
 	metrics := make([]models.DeviceMetric, 0)
-	if err := database.DB.Where("deviceid = ?", deviceID).
-		Order("timestamp desc").
-		Limit(limit).
-		Find(&metrics).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to retrieve metrics: " + err.Error()})
+	if err := query.Find(&metrics).Error; err != nil {
+		RespondInternalError(c, err)
 		return
 	}
 
+	// Optional smoothing then output rounding; both only touch the response
+	// copy, stored values stay raw and full-precision
+	applyMetricSmoothing(metrics, smooth)
+	applyMetricPrecision(metrics, precision)
+
 	c.JSON(http.StatusOK, metrics)
 }
 
@@ -290,11 +518,11 @@ func GetDeviceProcesses(c *gin.Context) {
 	limit := DefaultProcessesLimit
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter: must be a positive integer"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter: must be a positive integer")
 			return
 		}
 		if limit <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be greater than 0"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "limit must be greater than 0")
 			return
 		}
 	}
@@ -306,11 +534,11 @@ func GetDeviceProcesses(c *gin.Context) {
 
 	processes := make([]models.DeviceProcess, 0)
 	// Return most recent snapshot of processes for device (ordered by cpu desc, then timestamp desc)
-	if err := database.DB.Where("deviceid = ?", deviceID).
+	if err := database.Read().Where("deviceid = ?", deviceID).
 		Order("timestamp desc, cpu desc").
 		Limit(limit).
 		Find(&processes).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -324,6 +552,7 @@ func GetDeviceProcesses(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Device ID (UUID)"
 // @Param limit query int false "Number of records to return" default(100)
+// @Param last query string false "Relative window expanded server-side (e.g. 1h, 30m); mutually exclusive with from/to"
 // @Success 200 {array} models.DeviceActivity
 // @Failure 400 {object} map[string]string "Bad request - invalid limit parameter"
 // @Failure 500 {object} map[string]string "Internal server error"
@@ -332,10 +561,15 @@ func GetDeviceActivity(c *gin.Context) {
 	limit := 100
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
 			return
 		}
 	}
+	since, hasLast, err := parseLastParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
 	if _, err := uuid.Parse(c.Param("id")); err != nil {
 		c.JSON(http.StatusOK, []models.DeviceActivity{})
 		return
@@ -343,11 +577,15 @@ func GetDeviceActivity(c *gin.Context) {
 	deviceID := c.Param("id")
 
 	logs := make([]models.DeviceActivity, 0)
-	if err := database.DB.Where("deviceid = ?", deviceID).
+	query := database.Read().Where("deviceid = ?", deviceID)
+	if hasLast {
+		query = query.Where("timestamp >= ?", since)
+	}
+	if err := query.
 		Order("timestamp desc").
 		Limit(limit).
 		Find(&logs).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -361,6 +599,11 @@ func GetDeviceActivity(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Device ID"
 // @Param limit query int false "Number of records to return" default(100)
+// @Param last query string false "Relative window expanded server-side (e.g. 1h, 30m); mutually exclusive with from/to"
+// @Param include_snoozed query bool false "Include alerts whose snooze has not expired yet" default(false)
+// @Param acknowledged query bool false "Filter by acknowledgement state; absent returns both"
+// @Param type query string false "Only alerts of this type; used to expand one group from the grouped view"
+// @Param group_by query string false "Pass 'type' to collapse same-type alerts into one entry with count and first/last timestamps"
 // @Success 200 {array} models.DeviceAlert
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -369,10 +612,15 @@ func GetDeviceAlert(c *gin.Context) {
 	limit := 100
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
 			return
 		}
 	}
+	since, hasLast, err := parseLastParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
 	if _, err := uuid.Parse(c.Param("id")); err != nil {
 		c.JSON(http.StatusOK, []models.DeviceAlert{})
 		return
@@ -380,17 +628,65 @@ func GetDeviceAlert(c *gin.Context) {
 	deviceID := c.Param("id")
 
 	alerts := make([]models.DeviceAlert, 0)
-	if err := database.DB.Where("deviceid = ?", deviceID).
+	query := database.Read().Where("deviceid = ?", deviceID)
+	if hasLast {
+		query = query.Where("timestamp >= ?", since)
+	}
+	// Snoozed alerts are hidden by default and re-surface once the snooze
+	// expires; pass include_snoozed=true to list them anyway.
+	if c.Query("include_snoozed") != "true" {
+		query = query.Where("snoozed_until IS NULL OR snoozed_until <= ?", time.Now())
+	}
+	// acknowledged=false narrows to open alerts; acknowledged=true to handled
+	// ones. Absent, both are returned.
+	if ack := c.Query("acknowledged"); ack != "" {
+		if ack != "true" && ack != "false" {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid acknowledged parameter: must be true or false")
+			return
+		}
+		query = query.Where("acknowledged = ?", ack == "true")
+	}
+	if alertType := c.Query("type"); alertType != "" {
+		query = query.Where("alert_type = ?", alertType)
+	}
+	// group_by=type collapses the flat list into one row per alert type for
+	// triage; a group is expanded by re-querying with ?type=<alert_type>.
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		if groupBy != "type" {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid group_by parameter: only 'type' is supported")
+			return
+		}
+		respondGroupedAlerts(c, query)
+		return
+	}
+	if err := query.
 		Order("timestamp desc").
 		Limit(limit).
 		Find(&alerts).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, alerts)
 }
 
+// validResolution reports whether s looks like WIDTHxHEIGHT (e.g. 1920x1080).
+func validResolution(s string) bool {
+	width, height, ok := strings.Cut(s, "x")
+	if !ok {
+		return false
+	}
+	w, err := strconv.Atoi(width)
+	if err != nil || w <= 0 {
+		return false
+	}
+	h, err := strconv.Atoi(height)
+	if err != nil || h <= 0 {
+		return false
+	}
+	return true
+}
+
 // GetDeviceScreenshot returns recent screenshots metadata for a device
 // @Summary Get device screenshots
 // @Description Get recent screenshot metadata for a specific device with presigned URLs
@@ -398,17 +694,46 @@ func GetDeviceAlert(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Device ID (UUID)"
 // @Param limit query int false "Number of records to return" default(50)
+// @Param resolution query string false "Only screenshots with this exact resolution, e.g. 1920x1080"
+// @Param min_size query int false "Only screenshots of at least this many bytes"
+// @Param max_size query int false "Only screenshots of at most this many bytes"
 // @Success 200 {array} object "Array of screenshot metadata with presigned URLs"
-// @Failure 400 {object} map[string]string "Bad request - invalid limit parameter"
+// @Failure 400 {object} map[string]string "Bad request - invalid limit or filter parameters"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /devices/{id}/screenshots [get]
 func GetDeviceScreenshot(c *gin.Context) {
 	limit := 50
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
+			return
+		}
+	}
+	resolution := c.Query("resolution")
+	if resolution != "" && !validResolution(resolution) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid resolution parameter: expected WIDTHxHEIGHT, e.g. 1920x1080")
+		return
+	}
+	minSize, maxSize := int64(-1), int64(-1)
+	if raw := c.Query("min_size"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid min_size parameter: must be a non-negative integer")
 			return
 		}
+		minSize = parsed
+	}
+	if raw := c.Query("max_size"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid max_size parameter: must be a non-negative integer")
+			return
+		}
+		maxSize = parsed
+	}
+	if minSize >= 0 && maxSize >= 0 && minSize > maxSize {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "min_size must not exceed max_size")
+		return
 	}
 	if _, err := uuid.Parse(c.Param("id")); err != nil {
 		c.JSON(http.StatusOK, []interface{}{})
@@ -416,30 +741,57 @@ func GetDeviceScreenshot(c *gin.Context) {
 	}
 	deviceID := c.Param("id")
 
+	// Filters are applied in the query so filtered-out rows are never presigned
+	query := database.Read().Where("deviceid = ?", deviceID)
+	if resolution != "" {
+		query = query.Where("resolution = ?", resolution)
+	}
+	if minSize >= 0 {
+		query = query.Where("size >= ?", minSize)
+	}
+	if maxSize >= 0 {
+		query = query.Where("size <= ?", maxSize)
+	}
+
 	shots := make([]models.DeviceScreenshot, 0)
-	if err := database.DB.Where("deviceid = ?", deviceID).
+	if err := query.
 		Order("timestamp desc").
 		Limit(limit).
 		Find(&shots).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
-	// Generate presigned URLs for screenshots
+	// Degraded mode: when storage is unreachable we cannot mint fresh presigned
+	// URLs, so flag the outage and fall back to cached URLs where still valid
+	storageDown := len(shots) > 0 && s3.HealthCheck() != nil
+
 	resp := make([]gin.H, 0, len(shots))
 	for _, s := range shots {
-		// Generate presigned URL for the screenshot
-		screenshotURL := s3.GeneratePresignedURL(s.Path)
-
-		resp = append(resp, gin.H{
-			"screenshotid":   s.ScreenshotID,
-			"deviceid":       s.DeviceID,
-			"timestamp":      s.Timestamp,
-			"path":           s.Path,
-			"resolution":     s.Resolution,
-			"size":           s.Size,
-			"screenshot_url": screenshotURL,
-		})
+		item := gin.H{
+			"screenshotid": s.ScreenshotID,
+			"deviceid":     s.DeviceID,
+			"timestamp":    s.Timestamp,
+			"path":         s.Path,
+			"resolution":   s.Resolution,
+			"size":         s.Size,
+		}
+
+		if storageDown {
+			item["storage_unavailable"] = true
+			if cached, ok := s3.CachedPresignedURL(s.Path); ok {
+				item["screenshot_url"] = cached
+				item["cached"] = true
+			} else {
+				item["screenshot_url"] = ""
+				item["cached"] = false
+			}
+		} else {
+			// Generate presigned URL for the screenshot
+			item["screenshot_url"] = s3.GeneratePresignedURL(s.Path)
+		}
+
+		resp = append(resp, item)
 	}
 
 	c.JSON(http.StatusOK, resp)
@@ -452,6 +804,7 @@ func GetDeviceScreenshot(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param command body models.DeviceRemoteCommand true "Command to execute"
+// @Param sync query bool false "Forward synchronously and include the forwarding outcome in the response"
 // @Success 200 {object} models.DeviceRemoteCommand
 // @Failure 400 {object} map[string]string "Bad request - invalid JSON"
 // @Failure 500 {object} map[string]string "Internal server error"
@@ -459,20 +812,36 @@ func GetDeviceScreenshot(c *gin.Context) {
 func CreateRemoteCommand(c *gin.Context) {
 	var cmd models.DeviceRemoteCommand
 	if err := c.BindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
 	// Basic validation
 	if cmd.DeviceID == uuid.Nil || strings.TrimSpace(cmd.CommandText) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "deviceid and command_text are required"})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "deviceid and command_text are required")
 		return
 	}
 
 	// Validate command using model validation (includes whitelist check)
 	validationErrors := cmd.ValidateRemoteCommand()
 	if len(validationErrors) > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": strings.Join(validationErrors, "; ")})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, strings.Join(validationErrors, "; "))
+		return
+	}
+
+	// Scheduled commands must be scheduled for the future
+	if cmd.ScheduledAt != nil && cmd.ScheduledAt.Before(time.Now()) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "scheduled_at must be in the future")
+		return
+	}
+
+	// Headless devices may not support remote commands at all
+	if err := checkDeviceCapability(cmd.DeviceID, CapabilityCommands); err != nil {
+		if errors.Is(err, errCapabilityMissing) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			RespondInternalError(c, err)
+		}
 		return
 	}
 
@@ -483,43 +852,73 @@ func CreateRemoteCommand(c *gin.Context) {
 	cmd.Status = "pending"
 	cmd.CreatedAt = time.Now()
 
+	// Default the pickup deadline; a scheduled command gets its TTL from the
+	// scheduled time so deferring past the TTL does not expire it unrun.
+	if cmd.ExpiresAt.IsZero() {
+		base := cmd.CreatedAt
+		if cmd.ScheduledAt != nil && cmd.ScheduledAt.After(base) {
+			base = *cmd.ScheduledAt
+		}
+		cmd.ExpiresAt = base.Add(commandTTL())
+	}
+
 	if err := database.DB.Create(&cmd).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
+	// Enforce the per-device command history cap, if configured
+	trimCommandHistory(cmd.DeviceID)
+
+	// Conditional commands are only dispatched when the condition holds against
+	// the device's latest metric; otherwise they stay pending for later
+	// re-evaluation. Unconditional commands always dispatch.
+	dispatch := true
+	// Commands scheduled for later are withheld; agents pick them up from the
+	// pending queue once they come due
+	if cmd.ScheduledAt != nil && cmd.ScheduledAt.After(time.Now()) {
+		dispatch = false
+	}
+	if strings.TrimSpace(cmd.Condition) != "" {
+		var latest models.DeviceMetric
+		if err := database.DB.Where("deviceid = ?", cmd.DeviceID).
+			Order("timestamp desc").
+			First(&latest).Error; err != nil {
+			// No metrics yet: hold the command until the device reports state
+			dispatch = false
+		} else if met, err := cmd.ConditionMet(&latest); err != nil || !met {
+			dispatch = false
+		}
+	}
+
 	// Forward command to devices backend if DEVICES_API_URL is set
 	devicesAPIURL := os.Getenv("DEVICES_API_URL")
-	if devicesAPIURL != "" {
-		go func() {
-			payload := map[string]interface{}{
-				"command_text": cmd.CommandText,
-			}
-			jsonData, err := json.Marshal(payload)
-			if err != nil {
-				fmt.Printf("Error marshaling command payload: %v\n", err)
+	if dispatch && devicesAPIURL != "" {
+		// Synchronous mode reports forwarding failures to the caller instead of
+		// fire-and-forget; useful for small fleets where immediate feedback matters
+		if strings.EqualFold(c.Query("sync"), "true") || commandForwardSyncEnabled() {
+			if err := forwardCommand(devicesAPIURL, &cmd); err != nil {
+				recordForwardStatus(cmd.CommandID, ForwardStatusFailed)
+				c.JSON(http.StatusBadGateway, gin.H{
+					"command":       cmd,
+					"forwarded":     false,
+					"forward_error": err.Error(),
+				})
 				return
 			}
+			recordForwardStatus(cmd.CommandID, ForwardStatusSent)
+			cmd.ForwardStatus = ForwardStatusSent
+			c.JSON(http.StatusOK, gin.H{"command": cmd, "forwarded": true})
+			return
+		}
 
-			// Use retry client for forwarding to devices backend
-			retryClient := util.NewHTTPClientWithRetry(5*time.Second, 3)
-			resp, err := retryClient.Post(
-				fmt.Sprintf("%s/devices/%s/commands", devicesAPIURL, cmd.DeviceID),
-				"application/json",
-				bytes.NewBuffer(jsonData),
-			)
-			if err != nil {
-				fmt.Printf("Error forwarding command to devices backend after retries: %v\n", err)
+		go func() {
+			if err := forwardCommand(devicesAPIURL, &cmd); err != nil {
+				fmt.Printf("Error forwarding command to devices backend: %v\n", err)
+				recordForwardStatus(cmd.CommandID, ForwardStatusFailed)
 				return
 			}
-			defer func() {
-				if err := resp.Body.Close(); err != nil {
-					fmt.Printf("Error closing response body: %v\n", err)
-				}
-			}()
-			if resp.StatusCode >= 400 {
-				fmt.Printf("Devices backend returned error status: %d\n", resp.StatusCode)
-			}
+			recordForwardStatus(cmd.CommandID, ForwardStatusSent)
 		}()
 	}
 
@@ -543,9 +942,16 @@ func GetPendingCommands(c *gin.Context) {
 	deviceID := c.Param("id")
 
 	commands := make([]models.DeviceRemoteCommand, 0)
-	if err := database.DB.Where("deviceid = ? AND status = ?", deviceID, "pending").
+	// Exclude commands scheduled for the future; they become visible once due.
+	// Expired commands are excluded here as well so an agent never receives
+	// one in the window before the sweeper marks it. Higher-priority commands
+	// (e.g. on-demand screenshots) are delivered first.
+	now := time.Now()
+	if err := database.DB.Where("deviceid = ? AND status = ? AND (scheduled_at IS NULL OR scheduled_at <= ?) AND (expires_at <= ? OR expires_at > ?)",
+		deviceID, "pending", now, time.Time{}, now).
+		Order("priority desc, created_at asc").
 		Find(&commands).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -559,6 +965,8 @@ func GetPendingCommands(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Device ID (UUID)"
 // @Param limit query int false "Number of records to return" default(100)
+// @Param offset query int false "Number of records to skip"
+// @Param envelope query bool false "Wrap the response as {data, pagination} instead of a bare array"
 // @Success 200 {array} models.DeviceRemoteCommand
 // @Failure 400 {object} map[string]string "Bad request - invalid limit parameter"
 // @Failure 500 {object} map[string]string "Internal server error"
@@ -567,26 +975,41 @@ func GetDeviceCommands(c *gin.Context) {
 	limit := 100
 	if l := c.Query("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
 			return
 		}
 	}
+	offset, err := parseOffsetParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
 	if _, err := uuid.Parse(c.Param("id")); err != nil {
 		c.JSON(http.StatusOK, []models.DeviceRemoteCommand{})
 		return
 	}
 	deviceID := c.Param("id")
 
-	var commands []models.DeviceRemoteCommand
-	if err := database.DB.Where("deviceid = ?", deviceID).
+	commands := make([]models.DeviceRemoteCommand, 0)
+	if err := database.Read().Where("deviceid = ?", deviceID).
 		Order("created_at desc").
 		Limit(limit).
+		Offset(offset).
 		Find(&commands).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
+		return
+	}
+	if err := decompressCommandResults(commands); err != nil {
+		RespondInternalError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, commands)
+	respondList(c, commands, limit, offset, func() (int64, error) {
+		var total int64
+		err := database.Read().Model(&models.DeviceRemoteCommand{}).
+			Where("deviceid = ?", deviceID).Count(&total).Error
+		return total, err
+	})
 }
 
 // UpdateCommandStatus updates command execution status
@@ -603,7 +1026,7 @@ func GetDeviceCommands(c *gin.Context) {
 func UpdateCommandStatus(c *gin.Context) {
 	var cmd models.DeviceRemoteCommand
 	if err := c.BindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -611,17 +1034,24 @@ func UpdateCommandStatus(c *gin.Context) {
 		cmd.CompletedAt = time.Now()
 	}
 
+	// Optionally gzip large results at write time; reads decompress
+	// transparently so clients always see plain text
+	resultText, resultBlob, resultCompressed := storedCommandResult(cmd.Result)
+	updates := map[string]interface{}{
+		"status":            cmd.Status,
+		"result":            resultText,
+		"compressed_result": resultBlob,
+		"compressed":        resultCompressed,
+		"exit_code":         cmd.ExitCode,
+		"completed_at":      cmd.CompletedAt,
+	}
+
 	// First attempt: update by explicit command ID
 	result := database.DB.Model(&models.DeviceRemoteCommand{}).
 		Where("commandid = ?", cmd.CommandID).
-		Updates(map[string]interface{}{
-			"status":       cmd.Status,
-			"result":       cmd.Result,
-			"exit_code":    cmd.ExitCode,
-			"completed_at": cmd.CompletedAt,
-		})
+		Updates(updates)
 	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		RespondInternalError(c, result.Error)
 		return
 	}
 
@@ -642,14 +1072,9 @@ func UpdateCommandStatus(c *gin.Context) {
 				// Update that command
 				result = database.DB.Model(&models.DeviceRemoteCommand{}).
 					Where("commandid = ?", latest.CommandID).
-					Updates(map[string]interface{}{
-						"status":       cmd.Status,
-						"result":       cmd.Result,
-						"exit_code":    cmd.ExitCode,
-						"completed_at": cmd.CompletedAt,
-					})
+					Updates(updates)
 				if result.Error != nil {
-					c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+					RespondInternalError(c, result.Error)
 					return
 				}
 			}
@@ -659,7 +1084,7 @@ func UpdateCommandStatus(c *gin.Context) {
 		// Return 404 only when no identifiers are present at all (legacy malformed requests).
 		if result.RowsAffected == 0 {
 			if cmd.CommandID == uuid.Nil && cmd.DeviceID == uuid.Nil {
-				c.JSON(http.StatusNotFound, gin.H{"error": "command not found"})
+				RespondError(c, http.StatusNotFound, ErrCodeNotFound, "command not found")
 				return
 			}
 			// Idempotent acknowledgement: nothing to update, but request is well-formed.
@@ -684,7 +1109,17 @@ func UpdateCommandStatus(c *gin.Context) {
 func ReportAlert(c *gin.Context) {
 	var alert models.DeviceAlert
 	if err := c.BindJSON(&alert); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	// A flapping sensor can flood one alert type; the token bucket suppresses
+	// the excess before it reaches the table and downstream notifications
+	if !allowAlert(alert.DeviceID, alert.AlertType) {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":      "alert rate limit exceeded",
+			"suppressed": true,
+		})
 		return
 	}
 
@@ -692,9 +1127,12 @@ func ReportAlert(c *gin.Context) {
 		alert.AlertID = uuid.New()
 	}
 	alert.Timestamp = time.Now()
+	// Known alert types get their configured canonical severity, so one
+	// miscalibrated agent cannot mislabel a fleet-wide condition
+	alert.Level = normalizeAlertLevel(alert.AlertType, alert.Level)
 
 	if err := database.DB.Create(&alert).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
@@ -715,20 +1153,63 @@ func ReportAlert(c *gin.Context) {
 func StoreScreenshot(c *gin.Context) {
 	var screenshot models.DeviceScreenshot
 	if err := c.BindJSON(&screenshot); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
 
+	// When throttling is enabled, reject screenshots arriving faster than the
+	// minimum interval and discard the already-uploaded object so it does not
+	// linger in storage unreferenced
+	if interval := screenshotMinInterval(); interval > 0 {
+		var last models.DeviceScreenshot
+		if err := database.DB.Where("deviceid = ? AND timestamp >= ?",
+			screenshot.DeviceID, time.Now().Add(-interval)).
+			Order("timestamp desc").
+			First(&last).Error; err == nil {
+			if screenshot.Path != "" {
+				if err := s3.RemoveObject(screenshot.Path); err != nil {
+					fmt.Printf("Failed to remove throttled screenshot object %s: %v\n", screenshot.Path, err)
+				}
+			}
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       "screenshot rate limit exceeded",
+				"retry_after": interval.String(),
+			})
+			return
+		}
+	}
+
+	// Idle screens produce identical captures; when dedup is enabled and the
+	// device's latest screenshot carries the same checksum, keep the existing
+	// row and discard the redundant upload
+	if screenshotDedupEnabled() && screenshot.Checksum != "" {
+		var latest models.DeviceScreenshot
+		if err := database.DB.Where("deviceid = ?", screenshot.DeviceID).
+			Order("timestamp desc").
+			First(&latest).Error; err == nil && latest.Checksum == screenshot.Checksum {
+			if screenshot.Path != "" && screenshot.Path != latest.Path {
+				if err := s3.RemoveObject(screenshot.Path); err != nil {
+					fmt.Printf("Failed to remove duplicate screenshot object %s: %v\n", screenshot.Path, err)
+				}
+			}
+			c.JSON(http.StatusOK, latest)
+			return
+		}
+	}
+
 	if screenshot.ScreenshotID == uuid.Nil {
 		screenshot.ScreenshotID = uuid.New()
 	}
 	// Timestamp is set by database default, so we do not override it here
 
 	if err := database.DB.Create(&screenshot).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		RespondInternalError(c, err)
 		return
 	}
 
+	// Notify any live SSE subscribers watching this device
+	publishScreenshot(screenshot)
+
 	c.JSON(http.StatusOK, screenshot)
 }
 
@@ -741,13 +1222,41 @@ func StoreScreenshot(c *gin.Context) {
 // @Param location query string false "Filter by location"
 // @Param start_time query string false "Filter by start time (RFC3339 format)"
 // @Param end_time query string false "Filter by end time (RFC3339 format)"
+// @Param limit query int false "Number of records to return (all when omitted)"
+// @Param offset query int false "Number of records to skip"
+// @Param envelope query bool false "Wrap the response as {data, pagination} instead of a bare array"
 // @Success 200 {array} models.DeviceActivity
 // @Router /activities [get]
 func ListActivities(c *gin.Context) {
-	var activities []models.DeviceActivity
-	if err := database.DB.Order("timestamp desc").Find(&activities).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter: must be a positive integer")
+			return
+		}
+	}
+	offset, err := parseOffsetParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, activities)
+
+	activities := make([]models.DeviceActivity, 0)
+	query := database.Read().Order("timestamp desc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+	if err := query.Find(&activities).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	respondList(c, activities, limit, offset, func() (int64, error) {
+		var total int64
+		err := database.Read().Model(&models.DeviceActivity{}).Count(&total).Error
+		return total, err
+	})
 }
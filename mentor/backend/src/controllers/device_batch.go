@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxBatchRegisterSize is the maximum number of devices accepted in a single batch registration
+const MaxBatchRegisterSize = 100
+
+// BatchRegisterResult describes the outcome for a single device in a batch registration
+type BatchRegisterResult struct {
+	DeviceID uuid.UUID `json:"deviceid"`
+	Status   string    `json:"status"` // "created", "updated", or "invalid"
+	Errors   []string  `json:"errors,omitempty"`
+}
+
+// BatchRegisterDevices registers or updates multiple devices in a single transaction
+// @Summary Batch register devices
+// @Description Register or update many devices at once. Each device is upserted in a single transaction; per-device validation failures are reported without failing the whole batch.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param devices body []models.Device true "Devices to register"
+// @Success 200 {array} controllers.BatchRegisterResult
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or batch too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/batch [post]
+func BatchRegisterDevices(c *gin.Context) {
+	var devices []models.Device
+	if err := c.BindJSON(&devices); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid request body: "+err.Error())
+		return
+	}
+
+	if len(devices) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "batch cannot be empty")
+		return
+	}
+	if len(devices) > MaxBatchRegisterSize {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "batch size exceeds maximum of 100 devices")
+		return
+	}
+
+	results := make([]BatchRegisterResult, 0, len(devices))
+
+	// Upsert all valid devices in one transaction so a hard database error rolls
+	// back the entire batch. Validation failures are per-item and do not abort.
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range devices {
+			device := &devices[i]
+
+			if validationErrors := device.ValidateDevice(); len(validationErrors) > 0 {
+				results = append(results, BatchRegisterResult{
+					DeviceID: device.DeviceID,
+					Status:   "invalid",
+					Errors:   validationErrors,
+				})
+				continue
+			}
+
+			if device.DeviceID == uuid.Nil {
+				device.DeviceID = uuid.New()
+			}
+			device.LastSeen = time.Now()
+			device.IsOnline = true
+
+			// Determine created vs updated before the upsert
+			var count int64
+			if err := tx.Model(&models.Device{}).
+				Where("deviceid = ?", device.DeviceID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+
+			result := tx.Where("deviceid = ?", device.DeviceID).
+				Assign(*device).
+				FirstOrCreate(device)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			status := "created"
+			if count > 0 {
+				status = "updated"
+			}
+			results = append(results, BatchRegisterResult{
+				DeviceID: device.DeviceID,
+				Status:   status,
+			})
+		}
+		return nil
+	})
+
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func performBatchRegister(t *testing.T, devices []models.Device) (*httptest.ResponseRecorder, []BatchRegisterResult) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(devices)
+	c.Request, _ = http.NewRequest("POST", "/devices/batch", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	BatchRegisterDevices(c)
+
+	var results []BatchRegisterResult
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+			t.Fatalf("failed to unmarshal batch results: %v, body: %s", err, w.Body.String())
+		}
+	}
+	return w, results
+}
+
+func TestBatchRegisterDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	existingID := uuid.New()
+
+	// Seed one existing device so the batch exercises the update path
+	existing := models.Device{
+		DeviceID:   existingID,
+		DeviceName: "batch-existing",
+		DeviceType: "laptop",
+	}
+	if err := database.DB.Create(&existing).Error; err != nil {
+		t.Fatalf("failed to seed existing device: %v", err)
+	}
+
+	devices := []models.Device{
+		{DeviceName: "batch-new", DeviceType: "desktop"},
+		{DeviceID: existingID, DeviceName: "batch-existing-renamed", DeviceType: "laptop"},
+	}
+
+	w, results := performBatchRegister(t, devices)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if results[0].Status != "created" {
+		t.Errorf("expected first device status 'created', got %q", results[0].Status)
+	}
+	if results[0].DeviceID == uuid.Nil {
+		t.Errorf("expected generated UUID for new device, got nil UUID")
+	}
+	if results[1].Status != "updated" {
+		t.Errorf("expected second device status 'updated', got %q", results[1].Status)
+	}
+	if results[1].DeviceID != existingID {
+		t.Errorf("expected existing device ID %s, got %s", existingID, results[1].DeviceID)
+	}
+}
+
+func TestBatchRegisterDevicesValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	devices := []models.Device{
+		{DeviceName: ""}, // invalid: empty name
+		{DeviceName: "batch-valid", DeviceType: "server"},
+	}
+
+	w, results := performBatchRegister(t, devices)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Status != "invalid" {
+		t.Errorf("expected first device status 'invalid', got %q", results[0].Status)
+	}
+	if len(results[0].Errors) == 0 {
+		t.Errorf("expected validation errors for invalid device")
+	}
+	if results[1].Status != "created" {
+		t.Errorf("expected second device status 'created', got %q", results[1].Status)
+	}
+}
+
+func TestBatchRegisterDevicesLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Empty batch is rejected
+	w, _ := performBatchRegister(t, []models.Device{})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty batch, got %d", w.Code)
+	}
+
+	// Oversized batch is rejected before touching the database
+	oversized := make([]models.Device, MaxBatchRegisterSize+1)
+	for i := range oversized {
+		oversized[i].DeviceName = "overflow"
+	}
+	w2, _ := performBatchRegister(t, oversized)
+	if w2.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for oversized batch, got %d", w2.Code)
+	}
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceChanges is the incremental-sync response: the devices that changed
+// since the client's cursor, plus the cursor to pass on the next poll.
+type DeviceChanges struct {
+	Devices   []models.Device `json:"devices"`
+	NextSince time.Time       `json:"next_since"`
+}
+
+// GetDeviceChanges returns devices changed since a timestamp
+// @Summary Get recently-changed devices
+// @Description Return devices whose record was updated or that checked in after the given since timestamp, ordered by update time. Clients poll this with the next_since cursor from the previous response for efficient incremental sync instead of refetching the full device list.
+// @Tags devices
+// @Produce json
+// @Param since query string true "Only return devices changed after this time (RFC3339)"
+// @Success 200 {object} controllers.DeviceChanges
+// @Failure 400 {object} map[string]string "Bad request - missing or invalid since parameter"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/changes [get]
+func GetDeviceChanges(c *gin.Context) {
+	sinceParam := c.Query("since")
+	if sinceParam == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "since parameter is required")
+		return
+	}
+	since, err := time.Parse(time.RFC3339, sinceParam)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid since parameter: must be RFC3339")
+		return
+	}
+
+	changes := DeviceChanges{
+		Devices:   make([]models.Device, 0),
+		NextSince: since,
+	}
+	if err := database.Read().
+		Where("updated_at > ? OR last_seen > ?", since, since).
+		Order("updated_at asc").
+		Find(&changes.Devices).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Advance the cursor to the newest change seen so the next poll picks up
+	// where this one left off; an empty result echoes the caller's cursor.
+	for _, device := range changes.Devices {
+		if device.UpdatedAt.After(changes.NextSince) {
+			changes.NextSince = device.UpdatedAt
+		}
+		if device.LastSeen.After(changes.NextSince) {
+			changes.NextSince = device.LastSeen
+		}
+	}
+
+	applyDeviceMasking(c, changes.Devices)
+
+	c.JSON(http.StatusOK, changes)
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getDeviceChanges(t *testing.T, query string) (*httptest.ResponseRecorder, DeviceChanges) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices/changes"+query, nil)
+
+	GetDeviceChanges(c)
+
+	var changes DeviceChanges
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &changes); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+	}
+	return w, changes
+}
+
+func TestGetDeviceChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	old := time.Now().Add(-2 * time.Hour)
+	stale := models.Device{DeviceID: uuid.New(), DeviceName: "stale", LastSeen: old}
+	changed := models.Device{DeviceID: uuid.New(), DeviceName: "changed", LastSeen: old}
+	for _, device := range []models.Device{stale, changed} {
+		if err := database.DB.Create(&device).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+		// Backdate both so only the explicit update below crosses the cursor.
+		if err := database.DB.Model(&models.Device{}).
+			Where("deviceid = ?", device.DeviceID).
+			UpdateColumn("updated_at", old).Error; err != nil {
+			t.Fatalf("failed to backdate device: %v", err)
+		}
+	}
+
+	since := time.Now().Add(-time.Hour)
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid = ?", changed.DeviceID).
+		Update("device_location", "lab").Error; err != nil {
+		t.Fatalf("failed to update device: %v", err)
+	}
+
+	w, changes := getDeviceChanges(t, "?since="+since.Format(time.RFC3339))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if len(changes.Devices) != 1 || changes.Devices[0].DeviceID != changed.DeviceID {
+		t.Fatalf("expected only the updated device, got %+v", changes.Devices)
+	}
+	if !changes.NextSince.After(since) {
+		t.Errorf("expected next_since to advance past %v, got %v", since, changes.NextSince)
+	}
+
+	// Polling again with the returned cursor yields nothing new.
+	w, changes = getDeviceChanges(t, "?since="+changes.NextSince.Format(time.RFC3339Nano))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if len(changes.Devices) != 0 {
+		t.Errorf("expected no further changes, got %+v", changes.Devices)
+	}
+}
+
+func TestGetDeviceChangesValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w, _ := getDeviceChanges(t, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for missing since, got %d", w.Code)
+	}
+	if w, _ := getDeviceChanges(t, "?since=yesterday"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed since, got %d", w.Code)
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Default agent configuration values returned when no config row exists for a device
+const (
+	// DefaultMetricIntervalSeconds is the default interval between metric submissions
+	DefaultMetricIntervalSeconds = 60
+	// DefaultScreenshotIntervalSeconds is the default interval between screenshot captures
+	DefaultScreenshotIntervalSeconds = 300
+	// DefaultCPUThreshold is the default CPU usage percentage that triggers an alert
+	DefaultCPUThreshold = 90
+	// DefaultMemoryThreshold is the default memory usage percentage that triggers an alert
+	DefaultMemoryThreshold = 90
+	// DefaultDiskThreshold is the default disk usage percentage that triggers an alert
+	DefaultDiskThreshold = 95
+)
+
+// defaultDeviceConfig returns the built-in agent configuration for a device
+// that has no operator-defined config stored.
+func defaultDeviceConfig(deviceID uuid.UUID) models.DeviceConfig {
+	return models.DeviceConfig{
+		DeviceID:                  deviceID,
+		MetricIntervalSeconds:     DefaultMetricIntervalSeconds,
+		ScreenshotIntervalSeconds: DefaultScreenshotIntervalSeconds,
+		CPUThreshold:              DefaultCPUThreshold,
+		MemoryThreshold:           DefaultMemoryThreshold,
+		DiskThreshold:             DefaultDiskThreshold,
+	}
+}
+
+// GetDeviceConfig returns the agent configuration for a device
+// @Summary Get device configuration
+// @Description Get the server-driven agent configuration for a device. Returns built-in defaults when no config has been set.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} models.DeviceConfig
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/config [get]
+func GetDeviceConfig(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var config models.DeviceConfig
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&config).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// No operator-defined config yet: serve defaults so agents always get a usable config
+			c.JSON(http.StatusOK, defaultDeviceConfig(deviceID))
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// SetDeviceConfig creates or updates the agent configuration for a device
+// @Summary Set device configuration
+// @Description Set the server-driven agent configuration for a device (poll intervals and alert thresholds)
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param config body models.DeviceConfig true "Agent configuration"
+// @Success 200 {object} models.DeviceConfig
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/config [put]
+func SetDeviceConfig(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var config models.DeviceConfig
+	if err := c.BindJSON(&config); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if config.MetricIntervalSeconds <= 0 || config.ScreenshotIntervalSeconds <= 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "intervals must be greater than 0")
+		return
+	}
+
+	// Device ID comes from the URL, not the payload
+	config.DeviceID = deviceID
+	config.UpdatedAt = time.Now()
+	if config.ConfigID == uuid.Nil {
+		config.ConfigID = uuid.New()
+	}
+
+	// Upsert keyed by device ID so repeated PUTs update the same row
+	var existing models.DeviceConfig
+	result := database.DB.Where("deviceid = ?", deviceID).First(&existing)
+	if result.Error == nil {
+		config.ConfigID = existing.ConfigID
+		if err := database.DB.Model(&models.DeviceConfig{}).
+			Where("configid = ?", existing.ConfigID).
+			Updates(map[string]interface{}{
+				"metric_interval_seconds":     config.MetricIntervalSeconds,
+				"screenshot_interval_seconds": config.ScreenshotIntervalSeconds,
+				"cpu_threshold":               config.CPUThreshold,
+				"memory_threshold":            config.MemoryThreshold,
+				"disk_threshold":              config.DiskThreshold,
+				"updated_at":                  config.UpdatedAt,
+			}).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+	} else if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		if err := database.DB.Create(&config).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+	} else {
+		RespondInternalError(c, result.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
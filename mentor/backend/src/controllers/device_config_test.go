@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceConfigDefaults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/config", nil)
+
+	GetDeviceConfig(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var config models.DeviceConfig
+	if err := json.Unmarshal(w.Body.Bytes(), &config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	if config.MetricIntervalSeconds != DefaultMetricIntervalSeconds {
+		t.Errorf("expected default metric interval %d, got %d", DefaultMetricIntervalSeconds, config.MetricIntervalSeconds)
+	}
+	if config.ScreenshotIntervalSeconds != DefaultScreenshotIntervalSeconds {
+		t.Errorf("expected default screenshot interval %d, got %d", DefaultScreenshotIntervalSeconds, config.ScreenshotIntervalSeconds)
+	}
+	if config.DeviceID != deviceID {
+		t.Errorf("expected device ID %s in defaults, got %s", deviceID, config.DeviceID)
+	}
+}
+
+func TestSetThenGetDeviceConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+
+	desired := models.DeviceConfig{
+		MetricIntervalSeconds:     30,
+		ScreenshotIntervalSeconds: 120,
+		CPUThreshold:              80,
+		MemoryThreshold:           85,
+		DiskThreshold:             90,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	b, _ := json.Marshal(desired)
+	c.Request, _ = http.NewRequest("PUT", "/devices/"+deviceID.String()+"/config", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	SetDeviceConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// Fetch it back and verify the stored values
+	w2 := httptest.NewRecorder()
+	c2, _ := gin.CreateTestContext(w2)
+	c2.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c2.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/config", nil)
+
+	GetDeviceConfig(c2)
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w2.Code, w2.Body.String())
+	}
+
+	var fetched models.DeviceConfig
+	if err := json.Unmarshal(w2.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	if fetched.MetricIntervalSeconds != 30 {
+		t.Errorf("expected metric interval 30, got %d", fetched.MetricIntervalSeconds)
+	}
+	if fetched.CPUThreshold != 80 {
+		t.Errorf("expected CPU threshold 80, got %f", fetched.CPUThreshold)
+	}
+}
+
+func TestSetDeviceConfigInvalidInterval(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	body := []byte(`{"metric_interval_seconds": 0, "screenshot_interval_seconds": 120}`)
+	c.Request, _ = http.NewRequest("PUT", "/devices/"+deviceID.String()+"/config", io.NopCloser(bytes.NewReader(body)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	SetDeviceConfig(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for zero interval, got %d", w.Code)
+	}
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// deletableTelemetry names the per-device tables removed with the device, in
+// the order they are deleted. Keyed by the name reported in the summary.
+var deletableTelemetry = []struct {
+	name  string
+	model interface{}
+}{
+	{"metrics", &models.DeviceMetric{}},
+	{"processes", &models.DeviceProcess{}},
+	{"activities", &models.DeviceActivity{}},
+	{"alerts", &models.DeviceAlert{}},
+	{"screenshots", &models.DeviceScreenshot{}},
+	{"commands", &models.DeviceRemoteCommand{}},
+}
+
+// DeleteDevice removes a decommissioned device and all its telemetry
+// @Summary Delete a device
+// @Description Delete the device row together with its metrics, processes, activities, alerts, screenshots, and remote commands in one transaction, returning a count summary of deleted rows. Screenshot objects in MinIO are removed best-effort afterwards; an object-store failure is logged but does not undo the deletion.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id} [delete]
+func DeleteDevice(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var device models.Device
+	if err := database.DB.First(&device, "deviceid = ?", deviceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Collect the backing object paths before the rows disappear
+	var screenshotPaths []string
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Pluck("path", &screenshotPaths).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	deleted := map[string]int64{}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, table := range deletableTelemetry {
+			result := tx.Where("deviceid = ?", deviceID).Delete(table.model)
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted[table.name] = result.RowsAffected
+		}
+		// Rollups are derived from raw metrics; drop them alongside
+		if err := tx.Where("deviceid = ?", deviceID).
+			Delete(&models.DeviceMetricRollup{}).Error; err != nil {
+			return err
+		}
+		// Hard delete so the UUID can be re-registered later; soft delete is
+		// reserved for duplicates folded into a canonical device
+		return tx.Unscoped().Where("deviceid = ?", deviceID).Delete(&models.Device{}).Error
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Rows are gone; remove the backing objects best-effort
+	for _, p := range screenshotPaths {
+		if p == "" {
+			continue
+		}
+		if err := s3.RemoveObject(p); err != nil {
+			fmt.Printf("Failed to remove screenshot object %s for deleted device: %v\n", p, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deviceid": deviceID, "deleted": deleted})
+}
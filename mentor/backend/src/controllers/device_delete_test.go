@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func deleteDevice(t *testing.T, id string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	c.Request, _ = http.NewRequest("DELETE", "/devices/"+id, nil)
+
+	DeleteDevice(c)
+	return w
+}
+
+func TestDeleteDeviceCascades(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "box", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+	metric := models.DeviceMetric{MetricID: uuid.New(), DeviceID: device.DeviceID, CPUUsage: 10}
+	if err := database.DB.Create(&metric).Error; err != nil {
+		t.Fatalf("failed to create metric: %v", err)
+	}
+	alert := models.DeviceAlert{AlertID: uuid.New(), DeviceID: device.DeviceID, Level: "warning", AlertType: "cpu"}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to create alert: %v", err)
+	}
+
+	w := deleteDevice(t, device.DeviceID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var devices, metrics, alerts int64
+	if err := database.DB.Unscoped().Model(&models.Device{}).Where("deviceid = ?", device.DeviceID).Count(&devices).Error; err != nil {
+		t.Fatalf("failed to count devices: %v", err)
+	}
+	if err := database.DB.Model(&models.DeviceMetric{}).Where("deviceid = ?", device.DeviceID).Count(&metrics).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	if err := database.DB.Model(&models.DeviceAlert{}).Where("deviceid = ?", device.DeviceID).Count(&alerts).Error; err != nil {
+		t.Fatalf("failed to count alerts: %v", err)
+	}
+	if devices != 0 || metrics != 0 || alerts != 0 {
+		t.Errorf("expected device and telemetry gone, got devices=%d metrics=%d alerts=%d", devices, metrics, alerts)
+	}
+}
+
+func TestDeleteDeviceNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := deleteDevice(t, uuid.New().String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown device, got %d", w.Code)
+	}
+	if w := deleteDevice(t, "not-a-uuid"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed ID, got %d", w.Code)
+	}
+}
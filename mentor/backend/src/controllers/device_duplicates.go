@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DuplicateCluster groups devices that look like the same physical machine
+// registered more than once.
+type DuplicateCluster struct {
+	// Key is what the members share: a MAC address or a device name.
+	Key     string          `json:"key"`
+	By      string          `json:"by"` // "mac_address" or "device_name"
+	Devices []models.Device `json:"devices"`
+}
+
+// MergeDevicesRequest names the canonical device and the duplicates to fold
+// into it.
+type MergeDevicesRequest struct {
+	CanonicalID  uuid.UUID   `json:"canonical_id"`
+	DuplicateIDs []uuid.UUID `json:"duplicate_ids"`
+}
+
+// telemetryModels lists every table carrying a deviceid column that the merge
+// endpoint repoints at the canonical device.
+var telemetryModels = []interface{}{
+	&models.DeviceMetric{},
+	&models.DeviceMetricRollup{},
+	&models.DeviceProcess{},
+	&models.DeviceActivity{},
+	&models.DeviceRemoteCommand{},
+	&models.DeviceScreenshot{},
+	&models.DeviceAlert{},
+	&models.DeviceLog{},
+	&models.UserSession{},
+}
+
+// GetDuplicateDevices lists clusters of probable duplicate devices
+// @Summary Detect duplicate devices
+// @Description Group devices by MAC address and by name and return clusters with more than one member. These are usually the same machine registered under different UUIDs.
+// @Tags admin
+// @Produce json
+// @Success 200 {array} controllers.DuplicateCluster
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/devices/duplicates [get]
+func GetDuplicateDevices(c *gin.Context) {
+	var devices []models.Device
+	if err := database.Read().Find(&devices).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	byMac := make(map[string][]models.Device)
+	byName := make(map[string][]models.Device)
+	for _, device := range devices {
+		if mac := strings.ToLower(strings.TrimSpace(device.MacAddress)); mac != "" {
+			byMac[mac] = append(byMac[mac], device)
+		}
+		if name := strings.ToLower(strings.TrimSpace(device.DeviceName)); name != "" {
+			byName[name] = append(byName[name], device)
+		}
+	}
+
+	clusters := make([]DuplicateCluster, 0)
+	seen := make(map[uuid.UUID]bool)
+	for key, members := range byMac {
+		if len(members) > 1 {
+			clusters = append(clusters, DuplicateCluster{Key: key, By: "mac_address", Devices: members})
+			for _, m := range members {
+				seen[m.DeviceID] = true
+			}
+		}
+	}
+	// Name clusters are weaker evidence; skip ones already covered by a MAC
+	// cluster to avoid reporting the same duplicates twice.
+	for key, members := range byName {
+		if len(members) <= 1 {
+			continue
+		}
+		covered := true
+		for _, m := range members {
+			if !seen[m.DeviceID] {
+				covered = false
+				break
+			}
+		}
+		if !covered {
+			clusters = append(clusters, DuplicateCluster{Key: key, By: "device_name", Devices: members})
+		}
+	}
+
+	c.JSON(http.StatusOK, clusters)
+}
+
+// MergeDevices folds duplicate devices into a canonical one
+// @Summary Merge duplicate devices
+// @Description Repoint all telemetry (metrics, processes, commands, alerts, ...) from the duplicate devices at the canonical device, then soft-delete the duplicates. The canonical device must exist and cannot appear among the duplicates.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param merge body controllers.MergeDevicesRequest true "Canonical device and duplicates to merge"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or device sets"
+// @Failure 404 {object} map[string]string "Canonical or duplicate device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/devices/merge [post]
+func MergeDevices(c *gin.Context) {
+	var req MergeDevicesRequest
+	if err := c.BindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid request body: "+err.Error())
+		return
+	}
+	if req.CanonicalID == uuid.Nil || len(req.DuplicateIDs) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "canonical_id and duplicate_ids are required")
+		return
+	}
+	for _, id := range req.DuplicateIDs {
+		if id == req.CanonicalID {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "canonical device cannot be merged into itself")
+			return
+		}
+	}
+
+	var canonical models.Device
+	if err := database.DB.First(&canonical, "deviceid = ?", req.CanonicalID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "canonical device not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	repointed := int64(0)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var duplicates []models.Device
+		if err := tx.Where("deviceid IN ?", req.DuplicateIDs).Find(&duplicates).Error; err != nil {
+			return err
+		}
+		if len(duplicates) != len(req.DuplicateIDs) {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, model := range telemetryModels {
+			result := tx.Model(model).
+				Where("deviceid IN ?", req.DuplicateIDs).
+				Update("deviceid", req.CanonicalID)
+			if result.Error != nil {
+				return result.Error
+			}
+			repointed += result.RowsAffected
+		}
+
+		return tx.Model(&models.Device{}).
+			Where("deviceid IN ?", req.DuplicateIDs).
+			Updates(map[string]interface{}{
+				"merged_into": req.CanonicalID,
+				"deleted_at":  tx.NowFunc(),
+			}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "one or more duplicate devices not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"canonical_id":    req.CanonicalID,
+		"merged":          len(req.DuplicateIDs),
+		"telemetry_moved": repointed,
+	})
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getDuplicateDevices(t *testing.T) []DuplicateCluster {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/admin/devices/duplicates", nil)
+
+	GetDuplicateDevices(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var clusters []DuplicateCluster
+	if err := json.Unmarshal(w.Body.Bytes(), &clusters); err != nil {
+		t.Fatalf("failed to unmarshal clusters: %v", err)
+	}
+	return clusters
+}
+
+func mergeDevices(t *testing.T, req MergeDevicesRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal merge request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/admin/devices/merge", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	MergeDevices(c)
+	return w
+}
+
+func TestGetDuplicateDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	mac := "aa:bb:cc:dd:ee:ff"
+	for _, d := range []models.Device{
+		{DeviceID: uuid.New(), DeviceName: "box-1", DeviceType: "server", MacAddress: mac},
+		{DeviceID: uuid.New(), DeviceName: "box-1-reimaged", DeviceType: "server", MacAddress: mac},
+		{DeviceID: uuid.New(), DeviceName: "unique", DeviceType: "server", MacAddress: "11:22:33:44:55:66"},
+	} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+
+	clusters := getDuplicateDevices(t)
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 duplicate cluster, got %d: %+v", len(clusters), clusters)
+	}
+	if clusters[0].By != "mac_address" || clusters[0].Key != mac || len(clusters[0].Devices) != 2 {
+		t.Errorf("unexpected cluster: %+v", clusters[0])
+	}
+}
+
+func TestMergeDevicesRepointsTelemetry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	canonical := models.Device{DeviceID: uuid.New(), DeviceName: "box", DeviceType: "server"}
+	duplicate := models.Device{DeviceID: uuid.New(), DeviceName: "box", DeviceType: "server"}
+	for _, d := range []models.Device{canonical, duplicate} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+	metric := models.DeviceMetric{MetricID: uuid.New(), DeviceID: duplicate.DeviceID, CPUUsage: 12}
+	if err := database.DB.Create(&metric).Error; err != nil {
+		t.Fatalf("failed to create metric: %v", err)
+	}
+	alert := models.DeviceAlert{AlertID: uuid.New(), DeviceID: duplicate.DeviceID, Level: "warning", AlertType: "cpu"}
+	if err := database.DB.Create(&alert).Error; err != nil {
+		t.Fatalf("failed to create alert: %v", err)
+	}
+
+	w := mergeDevices(t, MergeDevicesRequest{
+		CanonicalID:  canonical.DeviceID,
+		DuplicateIDs: []uuid.UUID{duplicate.DeviceID},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// Telemetry now belongs to the canonical device.
+	var moved models.DeviceMetric
+	if err := database.DB.First(&moved, "metricid = ?", metric.MetricID).Error; err != nil {
+		t.Fatalf("failed to reload metric: %v", err)
+	}
+	if moved.DeviceID != canonical.DeviceID {
+		t.Errorf("expected metric repointed at canonical device, got %s", moved.DeviceID)
+	}
+
+	// The duplicate is soft-deleted and gone from normal listings.
+	var visible int64
+	if err := database.DB.Model(&models.Device{}).Count(&visible).Error; err != nil {
+		t.Fatalf("failed to count devices: %v", err)
+	}
+	if visible != 1 {
+		t.Errorf("expected only the canonical device visible, got %d", visible)
+	}
+	var merged models.Device
+	if err := database.DB.Unscoped().First(&merged, "deviceid = ?", duplicate.DeviceID).Error; err != nil {
+		t.Fatalf("failed to load merged device: %v", err)
+	}
+	if merged.MergedInto == nil || *merged.MergedInto != canonical.DeviceID {
+		t.Errorf("expected merged_into to record the canonical device, got %+v", merged.MergedInto)
+	}
+}
+
+func TestMergeDevicesValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "box", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	if w := mergeDevices(t, MergeDevicesRequest{CanonicalID: device.DeviceID, DuplicateIDs: []uuid.UUID{device.DeviceID}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 merging a device into itself, got %d", w.Code)
+	}
+	if w := mergeDevices(t, MergeDevicesRequest{CanonicalID: uuid.New(), DuplicateIDs: []uuid.UUID{device.DeviceID}}); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown canonical, got %d", w.Code)
+	}
+	if w := mergeDevices(t, MergeDevicesRequest{CanonicalID: device.DeviceID, DuplicateIDs: []uuid.UUID{uuid.New()}}); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown duplicate, got %d", w.Code)
+	}
+}
@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateDeviceGroup creates a new device group
+// @Summary Create device group
+// @Description Create a named group for organizing devices (site, department, canary ring)
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group body models.DeviceGroup true "Group to create"
+// @Success 200 {object} models.DeviceGroup
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or empty name"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups [post]
+func CreateDeviceGroup(c *gin.Context) {
+	var group models.DeviceGroup
+	if err := c.BindJSON(&group); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if strings.TrimSpace(group.Name) == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "group name cannot be empty")
+		return
+	}
+	if group.GroupID == uuid.Nil {
+		group.GroupID = uuid.New()
+	}
+
+	if err := database.DB.Create(&group).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// ListDeviceGroups returns all device groups
+// @Summary List device groups
+// @Description Get all device groups
+// @Tags groups
+// @Produce json
+// @Success 200 {array} models.DeviceGroup
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups [get]
+func ListDeviceGroups(c *gin.Context) {
+	groups := make([]models.DeviceGroup, 0)
+	if err := database.DB.Order("name asc").Find(&groups).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, groups)
+}
+
+// findDeviceGroup loads a group by path parameter, writing the error response
+// itself when the ID is invalid or unknown.
+func findDeviceGroup(c *gin.Context) (*models.DeviceGroup, bool) {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid group ID")
+		return nil, false
+	}
+
+	var group models.DeviceGroup
+	if err := database.DB.Where("groupid = ?", groupID).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "group not found")
+			return nil, false
+		}
+		RespondInternalError(c, err)
+		return nil, false
+	}
+	return &group, true
+}
+
+// GetDeviceGroup returns one group with its member device IDs
+// @Summary Get device group
+// @Description Get a device group and the IDs of its member devices
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid group ID"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups/{id} [get]
+func GetDeviceGroup(c *gin.Context) {
+	group, ok := findDeviceGroup(c)
+	if !ok {
+		return
+	}
+
+	deviceIDs := make([]uuid.UUID, 0)
+	if err := database.DB.Model(&models.DeviceGroupMember{}).
+		Where("groupid = ?", group.GroupID).
+		Pluck("deviceid", &deviceIDs).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"group": group, "device_ids": deviceIDs})
+}
+
+// DeleteDeviceGroup removes a group and its memberships
+// @Summary Delete device group
+// @Description Delete a device group and all its membership rows. Devices themselves are untouched.
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Bad request - invalid group ID"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups/{id} [delete]
+func DeleteDeviceGroup(c *gin.Context) {
+	group, ok := findDeviceGroup(c)
+	if !ok {
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("groupid = ?", group.GroupID).
+			Delete(&models.DeviceGroupMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("groupid = ?", group.GroupID).
+			Delete(&models.DeviceGroup{}).Error
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// AddDeviceToGroup adds a device to a group
+// @Summary Add device to group
+// @Description Add a device to a group. Adding a device that is already a member is a no-op.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Param member body models.DeviceGroupMember true "Membership with deviceid set"
+// @Success 200 {object} models.DeviceGroupMember
+// @Failure 400 {object} map[string]string "Bad request - invalid group or device ID"
+// @Failure 404 {object} map[string]string "Group or device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups/{id}/devices [post]
+func AddDeviceToGroup(c *gin.Context) {
+	group, ok := findDeviceGroup(c)
+	if !ok {
+		return
+	}
+
+	var member models.DeviceGroupMember
+	if err := c.BindJSON(&member); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if member.DeviceID == uuid.Nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "deviceid is required")
+		return
+	}
+
+	var device models.Device
+	if err := database.DB.Where("deviceid = ?", member.DeviceID).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Membership is idempotent: return the existing row rather than violating
+	// the unique index
+	var existing models.DeviceGroupMember
+	result := database.DB.Where("groupid = ? AND deviceid = ?", group.GroupID, member.DeviceID).
+		First(&existing)
+	if result.Error == nil {
+		c.JSON(http.StatusOK, existing)
+		return
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		RespondInternalError(c, result.Error)
+		return
+	}
+
+	member.MemberID = uuid.New()
+	member.GroupID = group.GroupID
+	if err := database.DB.Create(&member).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveDeviceFromGroup removes a device from a group
+// @Summary Remove device from group
+// @Description Remove a device's membership in a group
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Param deviceid path string true "Device ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Bad request - invalid group or device ID"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups/{id}/devices/{deviceid} [delete]
+func RemoveDeviceFromGroup(c *gin.Context) {
+	group, ok := findDeviceGroup(c)
+	if !ok {
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("deviceid"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	if err := database.DB.Where("groupid = ? AND deviceid = ?", group.GroupID, deviceID).
+		Delete(&models.DeviceGroupMember{}).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "removed"})
+}
+
+// DeviceGroupSummary aggregates the health of a group's member devices
+type DeviceGroupSummary struct {
+	GroupID     uuid.UUID        `json:"groupid"`
+	Name        string           `json:"name"`
+	DeviceCount int64            `json:"device_count"`
+	OnlineCount int64            `json:"online_count"`
+	AlertCounts map[string]int64 `json:"alert_counts"`
+	AvgCPUUsage *float64         `json:"avg_cpu_usage"`
+}
+
+// GetDeviceGroupSummary returns aggregated health for a group
+// @Summary Get group health summary
+// @Description Aggregate a group's health: device and online counts, alert counts per level, and the average of each member's latest CPU usage
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Success 200 {object} controllers.DeviceGroupSummary
+// @Failure 400 {object} map[string]string "Bad request - invalid group ID"
+// @Failure 404 {object} map[string]string "Group not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /groups/{id}/summary [get]
+func GetDeviceGroupSummary(c *gin.Context) {
+	group, ok := findDeviceGroup(c)
+	if !ok {
+		return
+	}
+
+	summary := DeviceGroupSummary{
+		GroupID:     group.GroupID,
+		Name:        group.Name,
+		AlertCounts: map[string]int64{},
+	}
+
+	memberIDs := database.DB.Model(&models.DeviceGroupMember{}).
+		Select("deviceid").
+		Where("groupid = ?", group.GroupID)
+
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid IN (?)", memberIDs).
+		Count(&summary.DeviceCount).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid IN (?) AND is_online", memberIDs).
+		Count(&summary.OnlineCount).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	var levelCounts []struct {
+		Level string
+		Count int64
+	}
+	if err := database.DB.Model(&models.DeviceAlert{}).
+		Select("LOWER(level) AS level, COUNT(*) AS count").
+		Where("deviceid IN (?)", memberIDs).
+		Group("LOWER(level)").
+		Scan(&levelCounts).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	for _, lc := range levelCounts {
+		summary.AlertCounts[lc.Level] = lc.Count
+	}
+
+	// Average over each member's latest metric, not over all history
+	latest := database.DB.Model(&models.DeviceMetric{}).
+		Select("DISTINCT ON (deviceid) cpu_usage").
+		Where("deviceid IN (?)", memberIDs).
+		Order("deviceid, timestamp DESC")
+	if err := database.DB.Table("(?) AS latest_metrics", latest).
+		Select("AVG(cpu_usage) AS avg_cpu_usage").
+		Scan(&summary).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
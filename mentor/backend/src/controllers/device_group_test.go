@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func addDeviceToGroup(t *testing.T, groupID uuid.UUID, deviceID uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: groupID.String()}}
+	b, _ := json.Marshal(models.DeviceGroupMember{DeviceID: deviceID})
+	c.Request, _ = http.NewRequest("POST", "/groups/"+groupID.String()+"/devices", bytes.NewReader(b))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	AddDeviceToGroup(c)
+	return w
+}
+
+func TestDeviceGroupMembership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	group := models.DeviceGroup{GroupID: uuid.New(), Name: "membership-test-group"}
+	if err := database.DB.Create(&group).Error; err != nil {
+		t.Fatalf("failed to seed group: %v", err)
+	}
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "grouped-device"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	if w := addDeviceToGroup(t, group.GroupID, device.DeviceID); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 adding member, got %d, body: %s", w.Code, w.Body.String())
+	}
+	// Adding again is idempotent
+	if w := addDeviceToGroup(t, group.GroupID, device.DeviceID); w.Code != http.StatusOK {
+		t.Fatalf("expected idempotent re-add, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceGroupMember{}).
+		Where("groupid = ?", group.GroupID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count members: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 membership row, got %d", count)
+	}
+
+	// Unknown device is rejected
+	if w := addDeviceToGroup(t, group.GroupID, uuid.New()); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown device, got %d", w.Code)
+	}
+
+	// Removal
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		gin.Param{Key: "id", Value: group.GroupID.String()},
+		gin.Param{Key: "deviceid", Value: device.DeviceID.String()},
+	}
+	c.Request, _ = http.NewRequest("DELETE", "/groups/x/devices/y", nil)
+	RemoveDeviceFromGroup(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 removing member, got %d", w.Code)
+	}
+
+	if err := database.DB.Model(&models.DeviceGroupMember{}).
+		Where("groupid = ?", group.GroupID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count members: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no membership rows after removal, got %d", count)
+	}
+}
+
+func TestGetDeviceGroupSummary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	group := models.DeviceGroup{GroupID: uuid.New(), Name: "summary-test-group"}
+	if err := database.DB.Create(&group).Error; err != nil {
+		t.Fatalf("failed to seed group: %v", err)
+	}
+
+	online := models.Device{DeviceID: uuid.New(), DeviceName: "online-member", IsOnline: true, LastSeen: time.Now()}
+	offline := models.Device{DeviceID: uuid.New(), DeviceName: "offline-member", IsOnline: false}
+	outsider := models.Device{DeviceID: uuid.New(), DeviceName: "not-a-member", IsOnline: true}
+	for _, d := range []models.Device{online, offline, outsider} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to seed device: %v", err)
+		}
+	}
+	for _, id := range []uuid.UUID{online.DeviceID, offline.DeviceID} {
+		m := models.DeviceGroupMember{MemberID: uuid.New(), GroupID: group.GroupID, DeviceID: id}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed membership: %v", err)
+		}
+	}
+
+	now := time.Now()
+	seeds := []interface{}{
+		// Latest metrics: 40 for online (older 90 must be ignored), 60 for offline
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: online.DeviceID, Timestamp: now.Add(-time.Hour), CPUUsage: 90},
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: online.DeviceID, Timestamp: now, CPUUsage: 40},
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: offline.DeviceID, Timestamp: now, CPUUsage: 60},
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: outsider.DeviceID, Timestamp: now, CPUUsage: 100},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: online.DeviceID, Level: "critical", AlertType: "cpu", Message: "hot"},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: offline.DeviceID, Level: "warning", AlertType: "disk", Message: "full"},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: outsider.DeviceID, Level: "critical", AlertType: "cpu", Message: "not counted"},
+	}
+	for _, s := range seeds {
+		if err := database.DB.Create(s).Error; err != nil {
+			t.Fatalf("failed to seed summary data: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: group.GroupID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/groups/"+group.GroupID.String()+"/summary", nil)
+
+	GetDeviceGroupSummary(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var summary DeviceGroupSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("failed to unmarshal summary: %v", err)
+	}
+	if summary.DeviceCount != 2 {
+		t.Errorf("expected 2 member devices, got %d", summary.DeviceCount)
+	}
+	if summary.OnlineCount != 1 {
+		t.Errorf("expected 1 online member, got %d", summary.OnlineCount)
+	}
+	if summary.AlertCounts["critical"] != 1 || summary.AlertCounts["warning"] != 1 {
+		t.Errorf("unexpected alert counts: %v", summary.AlertCounts)
+	}
+	if summary.AvgCPUUsage == nil || *summary.AvgCPUUsage != 50 {
+		t.Errorf("expected avg of latest CPU (40+60)/2=50, got %v", summary.AvgCPUUsage)
+	}
+}
+
+func TestGetDeviceGroupSummaryUnknownGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/groups/x/summary", nil)
+
+	GetDeviceGroupSummary(c)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown group, got %d", w.Code)
+	}
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxImportRows caps how many device rows a single CSV import may carry.
+	MaxImportRows = 1000
+	// MaxImportBytes caps the CSV payload size; a device row is tiny, so one
+	// megabyte covers far more rows than MaxImportRows allows.
+	MaxImportBytes = 1 << 20
+)
+
+// ImportRowResult describes the outcome for a single CSV row.
+type ImportRowResult struct {
+	Line     int       `json:"line"`
+	DeviceID uuid.UUID `json:"deviceid,omitempty"`
+	Status   string    `json:"status"` // "imported", "updated", or "error"
+	Error    string    `json:"error,omitempty"`
+}
+
+// importableColumns maps CSV headers to setters on the device being built.
+// Headers match the device JSON field names so an exported spreadsheet can be
+// re-imported as-is.
+var importableColumns = map[string]func(*models.Device, string) error{
+	"deviceid": func(d *models.Device, v string) error {
+		if v == "" {
+			return nil
+		}
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return fmt.Errorf("invalid deviceid: %v", err)
+		}
+		d.DeviceID = id
+		return nil
+	},
+	"device_name":     func(d *models.Device, v string) error { d.DeviceName = v; return nil },
+	"device_type":     func(d *models.Device, v string) error { d.DeviceType = v; return nil },
+	"os":              func(d *models.Device, v string) error { d.OS = v; return nil },
+	"device_location": func(d *models.Device, v string) error { d.DeviceLocation = v; return nil },
+	"ip_address":      func(d *models.Device, v string) error { d.IPAddress = v; return nil },
+	"mac_address":     func(d *models.Device, v string) error { d.MacAddress = v; return nil },
+	"current_user":    func(d *models.Device, v string) error { d.CurrentUser = v; return nil },
+	"hardware_id":     func(d *models.Device, v string) error { d.HardwareID = v; return nil },
+}
+
+// ImportDevicesCSV bulk-imports devices from an uploaded CSV
+// @Summary Import devices from CSV
+// @Description Upsert devices from a CSV body whose header row names device fields (device_name, device_type, os, ...). Rows are validated individually and applied in one transaction; the response reports imported/updated/error per row with its line number. Complements the JSON batch endpoint for spreadsheet onboarding.
+// @Tags devices
+// @Accept plain
+// @Produce json
+// @Success 200 {array} controllers.ImportRowResult
+// @Failure 400 {object} map[string]string "Bad request - malformed CSV, unknown header, or file too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/import [post]
+func ImportDevicesCSV(c *gin.Context) {
+	reader := csv.NewReader(io.LimitReader(c.Request.Body, MaxImportBytes+1))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "failed to read CSV header: "+err.Error())
+		return
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := importableColumns[header[i]]; !ok {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("unknown column %q", header[i]))
+			return
+		}
+	}
+
+	type importRow struct {
+		line   int
+		device models.Device
+	}
+	rows := make([]importRow, 0)
+	results := make([]ImportRowResult, 0)
+	line := 1
+	var read int64
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			results = append(results, ImportRowResult{Line: line, Status: "error", Error: err.Error()})
+			continue
+		}
+		for _, field := range record {
+			read += int64(len(field))
+		}
+		if read > MaxImportBytes {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "CSV exceeds maximum size")
+			return
+		}
+		if len(rows) >= MaxImportRows {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("CSV exceeds maximum of %d rows", MaxImportRows))
+			return
+		}
+
+		var device models.Device
+		rowErr := ""
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if err := importableColumns[header[i]](&device, strings.TrimSpace(value)); err != nil {
+				rowErr = err.Error()
+				break
+			}
+		}
+		if rowErr == "" {
+			if validationErrors := device.ValidateDevice(); len(validationErrors) > 0 {
+				rowErr = strings.Join(validationErrors, "; ")
+			}
+		}
+		if rowErr != "" {
+			results = append(results, ImportRowResult{Line: line, Status: "error", Error: rowErr})
+			continue
+		}
+		rows = append(rows, importRow{line: line, device: device})
+	}
+
+	// Upsert all valid rows in one transaction so a hard database error rolls
+	// back the entire import; per-row validation errors were reported above.
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range rows {
+			device := &rows[i].device
+			if device.DeviceID == uuid.Nil {
+				device.DeviceID = uuid.New()
+			}
+			device.LastSeen = time.Now()
+
+			var count int64
+			if err := tx.Model(&models.Device{}).
+				Where("deviceid = ?", device.DeviceID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Where("deviceid = ?", device.DeviceID).
+				Assign(*device).
+				FirstOrCreate(device).Error; err != nil {
+				return err
+			}
+
+			status := "imported"
+			if count > 0 {
+				status = "updated"
+			}
+			results = append(results, ImportRowResult{Line: rows[i].line, DeviceID: device.DeviceID, Status: status})
+		}
+		return nil
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func importDevicesCSV(t *testing.T, csvBody string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/import", strings.NewReader(csvBody))
+	c.Request.Header.Set("Content-Type", "text/csv")
+
+	ImportDevicesCSV(c)
+	return w
+}
+
+func TestImportDevicesCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	existing := models.Device{DeviceID: uuid.New(), DeviceName: "old-name", DeviceType: "server"}
+	if err := database.DB.Create(&existing).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	csvBody := "deviceid,device_name,device_type,os\n" +
+		",imported-1,server,linux\n" +
+		existing.DeviceID.String() + ",renamed,server,linux\n" +
+		",,server,linux\n"
+
+	w := importDevicesCSV(t, csvBody)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var results []ImportRowResult
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatalf("failed to unmarshal results: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 row results, got %d: %+v", len(results), results)
+	}
+	if results[0].Line != 2 || results[0].Status != "imported" {
+		t.Errorf("unexpected result for line 2: %+v", results[0])
+	}
+	if results[1].Line != 3 || results[1].Status != "updated" || results[1].DeviceID != existing.DeviceID {
+		t.Errorf("unexpected result for line 3: %+v", results[1])
+	}
+	if results[2].Line != 4 || results[2].Status != "error" || !strings.Contains(results[2].Error, "device name") {
+		t.Errorf("expected validation error for line 4, got %+v", results[2])
+	}
+
+	var renamed models.Device
+	if err := database.DB.First(&renamed, "deviceid = ?", existing.DeviceID).Error; err != nil {
+		t.Fatalf("failed to reload device: %v", err)
+	}
+	if renamed.DeviceName != "renamed" {
+		t.Errorf("expected existing device renamed, got %q", renamed.DeviceName)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.Device{}).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count devices: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 devices after import, got %d", count)
+	}
+}
+
+func TestImportDevicesCSVRejectsUnknownColumn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := importDevicesCSV(t, "device_name,favourite_colour\nbox,blue\n")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown column, got %d, body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestImportDevicesCSVRowCap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	var sb strings.Builder
+	sb.WriteString("device_name,device_type\n")
+	for i := 0; i <= MaxImportRows; i++ {
+		sb.WriteString("box,server\n")
+	}
+	w := importDevicesCSV(t, sb.String())
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for oversized import, got %d", w.Code)
+	}
+}
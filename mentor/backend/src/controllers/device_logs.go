@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"fmt"
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxLogBatchSize is the maximum number of log events accepted per ingest
+	MaxLogBatchSize = 500
+	// DefaultLogsLimit is the default number of log events to return
+	DefaultLogsLimit = 100
+)
+
+// IngestDeviceLogs stores a batch of structured log events from a device
+// @Summary Ingest device logs
+// @Description Store a batch of structured log events reported by a device agent
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param logs body []models.DeviceLog true "Log events"
+// @Success 200 {array} models.DeviceLog
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON, device ID, level, or batch too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/logs [post]
+func IngestDeviceLogs(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var logs []models.DeviceLog
+	if err := c.BindJSON(&logs); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if len(logs) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "log batch cannot be empty")
+		return
+	}
+	if len(logs) > MaxLogBatchSize {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("log batch exceeds maximum of %d events", MaxLogBatchSize))
+		return
+	}
+
+	// Validate the whole batch before storing any of it
+	for i := range logs {
+		if validationErrors := logs[i].ValidateDeviceLog(); len(validationErrors) > 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("log event %d: %s", i, strings.Join(validationErrors, "; ")))
+			return
+		}
+	}
+
+	now := time.Now()
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		for i := range logs {
+			logs[i].DeviceID = deviceID
+			if logs[i].LogID == uuid.Nil {
+				logs[i].LogID = uuid.New()
+			}
+			if logs[i].Timestamp.IsZero() {
+				logs[i].Timestamp = now
+			}
+			if err := tx.Create(&logs[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// GetDeviceLogs returns recent log events for a device
+// @Summary Get device logs
+// @Description Get recent structured log events for a device, optionally filtered by level and time range
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param level query string false "Filter by log level"
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Param limit query int false "Number of records to return" default(100)
+// @Success 200 {array} models.DeviceLog
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/logs [get]
+func GetDeviceLogs(c *gin.Context) {
+	limit := DefaultLogsLimit
+	if l := c.Query("limit"); l != "" {
+		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter: must be a positive integer")
+			return
+		}
+	}
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		c.JSON(http.StatusOK, []models.DeviceLog{})
+		return
+	}
+	deviceID := c.Param("id")
+
+	query := database.DB.Where("deviceid = ?", deviceID)
+	if level := c.Query("level"); level != "" {
+		query = query.Where("LOWER(level) = ?", strings.ToLower(level))
+	}
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("timestamp >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("timestamp <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	logs := make([]models.DeviceLog, 0)
+	if err := query.
+		Order("timestamp desc").
+		Limit(limit).
+		Find(&logs).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func ingestLogs(t *testing.T, deviceID string, logs []models.DeviceLog) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID}}
+	b, _ := json.Marshal(logs)
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID+"/logs", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	IngestDeviceLogs(c)
+	return w
+}
+
+func TestIngestAndGetDeviceLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	logs := []models.DeviceLog{
+		{Level: "info", Message: "agent started", Fields: map[string]interface{}{"version": "1.2.3"}},
+		{Level: "error", Message: "disk check failed", Fields: map[string]interface{}{"disk": "/dev/sda1"}},
+	}
+
+	w := ingestLogs(t, deviceID.String(), logs)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// Retrieve with a level filter
+	w2 := httptest.NewRecorder()
+	c2, _ := gin.CreateTestContext(w2)
+	c2.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c2.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/logs?level=error", nil)
+
+	GetDeviceLogs(c2)
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w2.Code, w2.Body.String())
+	}
+
+	var fetched []models.DeviceLog
+	if err := json.Unmarshal(w2.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to unmarshal logs: %v", err)
+	}
+	if len(fetched) != 1 {
+		t.Fatalf("expected 1 error log, got %d", len(fetched))
+	}
+	if fetched[0].Message != "disk check failed" {
+		t.Errorf("expected 'disk check failed', got %q", fetched[0].Message)
+	}
+	if fetched[0].Fields["disk"] != "/dev/sda1" {
+		t.Errorf("expected structured field disk=/dev/sda1, got %v", fetched[0].Fields)
+	}
+}
+
+func TestIngestDeviceLogsInvalidLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	logs := []models.DeviceLog{{Level: "fatal", Message: "boom"}}
+
+	w := ingestLogs(t, deviceID.String(), logs)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid level, got %d", w.Code)
+	}
+
+	// Nothing from the rejected batch should be stored
+	var count int64
+	if err := database.DB.Model(&models.DeviceLog{}).
+		Where("deviceid = ?", deviceID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count logs: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected rejected batch to store nothing, found %d logs", count)
+	}
+}
+
+func TestIngestDeviceLogsBatchTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	oversized := make([]models.DeviceLog, MaxLogBatchSize+1)
+	for i := range oversized {
+		oversized[i] = models.DeviceLog{Level: "info", Message: "overflow"}
+	}
+
+	w := ingestLogs(t, uuid.New().String(), oversized)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for oversized batch, got %d", w.Code)
+	}
+}
+
+func TestGetDeviceLogsTimeFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+	old := models.DeviceLog{LogID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-2 * time.Hour), Level: "info", Message: "old"}
+	recent := models.DeviceLog{LogID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-5 * time.Minute), Level: "info", Message: "recent"}
+	for _, l := range []models.DeviceLog{old, recent} {
+		if err := database.DB.Create(&l).Error; err != nil {
+			t.Fatalf("failed to seed log: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	from := now.Add(-time.Hour).Format(time.RFC3339)
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/logs?from="+from, nil)
+
+	GetDeviceLogs(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var fetched []models.DeviceLog
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to unmarshal logs: %v", err)
+	}
+	if len(fetched) != 1 {
+		t.Fatalf("expected 1 log within the hour, got %d", len(fetched))
+	}
+	if fetched[0].Message != "recent" {
+		t.Errorf("expected the recent log, got %q", fetched[0].Message)
+	}
+}
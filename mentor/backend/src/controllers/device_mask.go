@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"mentor-backend/middleware"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maskedDeviceFields parses MASK_DEVICE_FIELDS, a comma-separated list of
+// device JSON field names (e.g. "ip_address,mac_address,current_user") to
+// hide from non-elevated callers. Empty means masking is off.
+func maskedDeviceFields() []string {
+	fields := []string{}
+	for _, field := range strings.Split(os.Getenv("MASK_DEVICE_FIELDS"), ",") {
+		if trimmed := strings.ToLower(strings.TrimSpace(field)); trimmed != "" {
+			fields = append(fields, trimmed)
+		}
+	}
+	return fields
+}
+
+// maskValue replaces a sensitive value with a short deterministic digest.
+// Hashing instead of blanking keeps equality intact, so viewers can still
+// tell two devices share an IP without learning which IP it is. Empty values
+// stay empty.
+func maskValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return "masked-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// maskDeviceFields blanks the requested fields on one device record.
+func maskDeviceFields(device *models.Device, fields []string) {
+	for _, field := range fields {
+		switch field {
+		case "ip_address":
+			device.IPAddress = maskValue(device.IPAddress)
+		case "mac_address":
+			device.MacAddress = maskValue(device.MacAddress)
+		case "current_user":
+			device.CurrentUser = maskValue(device.CurrentUser)
+		case "hardware_id":
+			device.HardwareID = maskValue(device.HardwareID)
+		case "device_location":
+			device.DeviceLocation = maskValue(device.DeviceLocation)
+		}
+	}
+}
+
+// applyDeviceMasking masks the configured sensitive fields on a device slice
+// before it is serialized, unless the caller holds an elevated role. Every
+// handler returning device records goes through this so list, get and search
+// views agree on what a viewer may see.
+func applyDeviceMasking(c *gin.Context, devices []models.Device) {
+	fields := maskedDeviceFields()
+	if len(fields) == 0 || middleware.IsElevated(c) {
+		return
+	}
+	for i := range devices {
+		maskDeviceFields(&devices[i], fields)
+	}
+}
+
+// maskDeviceForRequest is the single-record variant of applyDeviceMasking,
+// for handlers that embed one device in a larger response.
+func maskDeviceForRequest(c *gin.Context, device *models.Device) {
+	fields := maskedDeviceFields()
+	if len(fields) == 0 || middleware.IsElevated(c) {
+		return
+	}
+	maskDeviceFields(device, fields)
+}
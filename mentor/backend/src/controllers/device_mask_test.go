@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestMaskValue(t *testing.T) {
+	if maskValue("") != "" {
+		t.Error("expected empty values to stay empty")
+	}
+	masked := maskValue("10.0.0.5")
+	if !strings.HasPrefix(masked, "masked-") || strings.Contains(masked, "10.0.0.5") {
+		t.Errorf("expected digest, got %q", masked)
+	}
+	if maskValue("10.0.0.5") != masked {
+		t.Error("expected masking to be deterministic")
+	}
+	if maskValue("10.0.0.6") == masked {
+		t.Error("expected different values to mask differently")
+	}
+}
+
+func TestListDevicesMasking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{
+		DeviceID:    uuid.New(),
+		DeviceName:  "front-desk",
+		IPAddress:   "10.0.0.5",
+		MacAddress:  "aa:bb:cc:dd:ee:ff",
+		CurrentUser: "alice",
+		LastSeen:    time.Now(),
+	}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	t.Setenv("MASK_DEVICE_FIELDS", "ip_address,mac_address,current_user")
+
+	list := func(headers map[string]string) models.Device {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/devices", nil)
+		for k, v := range headers {
+			c.Request.Header.Set(k, v)
+		}
+		ListDevices(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+		}
+		var devices []models.Device
+		if err := json.Unmarshal(w.Body.Bytes(), &devices); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		if len(devices) != 1 {
+			t.Fatalf("expected 1 device, got %d", len(devices))
+		}
+		return devices[0]
+	}
+
+	got := list(nil)
+	if got.IPAddress == "10.0.0.5" || got.MacAddress == "aa:bb:cc:dd:ee:ff" || got.CurrentUser == "alice" {
+		t.Errorf("expected sensitive fields masked, got %+v", got)
+	}
+	if !strings.HasPrefix(got.IPAddress, "masked-") {
+		t.Errorf("expected digest in place of IP, got %q", got.IPAddress)
+	}
+	// Unmasked fields pass through.
+	if got.DeviceName != "front-desk" {
+		t.Errorf("expected device name untouched, got %q", got.DeviceName)
+	}
+
+	// An admin key sees the full record.
+	t.Setenv("ADMIN_API_KEYS", "root-key")
+	got = list(map[string]string{"X-API-Key": "root-key"})
+	if got.IPAddress != "10.0.0.5" || got.CurrentUser != "alice" {
+		t.Errorf("expected elevated caller to see full record, got %+v", got)
+	}
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MaxNoteLength caps note text so a single annotation cannot balloon the table
+const MaxNoteLength = 2000
+
+// AddDeviceNote attaches an operator note to a device
+// @Summary Add a device note
+// @Description Create a free-form operator annotation on a device. Text is required, trimmed, and limited to MaxNoteLength characters.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param note body models.DeviceNote true "Note (author and text)"
+// @Success 201 {object} models.DeviceNote
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID or note text"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/notes [post]
+func AddDeviceNote(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var note models.DeviceNote
+	if err := c.ShouldBindJSON(&note); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	note.Text = strings.TrimSpace(note.Text)
+	if note.Text == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "note text is required")
+		return
+	}
+	if len(note.Text) > MaxNoteLength {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "note text exceeds maximum length")
+		return
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if count == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+		return
+	}
+
+	note.NoteID = uuid.New()
+	note.DeviceID = deviceID
+	// Timestamp app-side rather than via the column default: Postgres now() is
+	// fixed per transaction, which would tie notes added in the same transaction
+	// and make newest-first ordering unstable.
+	note.CreatedAt = time.Now()
+	if err := database.DB.Create(&note).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// GetDeviceNotes lists the notes on a device, newest first
+// @Summary List device notes
+// @Description Return operator annotations for a device ordered newest first.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {array} models.DeviceNote
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/notes [get]
+func GetDeviceNotes(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	notes := make([]models.DeviceNote, 0)
+	if err := database.DB.Where("deviceid = ?", deviceID).
+		Order("created_at desc").
+		Find(&notes).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func addNote(t *testing.T, deviceID uuid.UUID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/notes", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	AddDeviceNote(c)
+	return w
+}
+
+func TestAddAndListDeviceNotes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "note-target", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	w := addNote(t, device.DeviceID, `{"author": "ops", "text": "first note"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var created models.DeviceNote
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to unmarshal note: %v", err)
+	}
+	if created.Text != "first note" || created.Author != "ops" {
+		t.Errorf("unexpected created note: %+v", created)
+	}
+	if created.DeviceID != device.DeviceID {
+		t.Errorf("expected note bound to device %s, got %s", device.DeviceID, created.DeviceID)
+	}
+
+	w = addNote(t, device.DeviceID, `{"author": "ops", "text": "second note"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 for second note, got %d", w.Code)
+	}
+
+	// Listing returns newest first
+	w = httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: device.DeviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+device.DeviceID.String()+"/notes", nil)
+
+	GetDeviceNotes(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var notes []models.DeviceNote
+	if err := json.Unmarshal(w.Body.Bytes(), &notes); err != nil {
+		t.Fatalf("failed to unmarshal notes: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Text != "second note" || notes[1].Text != "first note" {
+		t.Errorf("expected newest-first ordering, got %q then %q", notes[0].Text, notes[1].Text)
+	}
+}
+
+func TestAddDeviceNoteValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "note-validation", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	// Empty and whitespace-only text are rejected
+	for _, body := range []string{`{"text": ""}`, `{"text": "   "}`} {
+		w := addNote(t, device.DeviceID, body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", body, w.Code)
+		}
+	}
+
+	// Over-length text is rejected
+	long := strings.Repeat("x", MaxNoteLength+1)
+	w := addNote(t, device.DeviceID, `{"text": "`+long+`"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for over-length note, got %d", w.Code)
+	}
+
+	// Unknown device is a 404
+	w = addNote(t, uuid.New(), `{"text": "note for nobody"}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown device, got %d", w.Code)
+	}
+}
+
+func TestDeviceOverviewIncludesLatestNote(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "overview-note", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	for _, text := range []string{"older note", "newest note"} {
+		if w := addNote(t, device.DeviceID, `{"text": "`+text+`"}`); w.Code != http.StatusCreated {
+			t.Fatalf("failed to add note %q: %d", text, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: device.DeviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+device.DeviceID.String()+"/overview", nil)
+
+	GetDeviceOverview(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var overview DeviceOverview
+	if err := json.Unmarshal(w.Body.Bytes(), &overview); err != nil {
+		t.Fatalf("failed to unmarshal overview: %v", err)
+	}
+	if overview.LatestNote == nil || overview.LatestNote.Text != "newest note" {
+		t.Errorf("expected latest note in overview, got %+v", overview.LatestNote)
+	}
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultOverviewActivityWindow is the lookback used for the activity count
+const DefaultOverviewActivityWindow = 24 * time.Hour
+
+// DeviceOverview is a denormalized read combining everything the device detail
+// page needs in one response, replacing ~6 separate calls.
+type DeviceOverview struct {
+	Device              models.Device               `json:"device"`
+	LatestMetric        *models.DeviceMetric        `json:"latest_metric"`
+	LatestScreenshot    gin.H                       `json:"latest_screenshot"`
+	AlertSummary        map[string]int64            `json:"alert_summary"`
+	LatestCommand       *models.DeviceRemoteCommand `json:"latest_command"`
+	LatestNote          *models.DeviceNote          `json:"latest_note"`
+	RecentActivityCount int64                       `json:"recent_activity_count"`
+}
+
+// GetDeviceOverview returns a device with its latest telemetry in one call
+// @Summary Get device overview
+// @Description Return the device together with its latest metric, latest screenshot (presigned URL), alert counts per level, latest command, and recent activity count. One denormalized read for the detail page.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} controllers.DeviceOverview
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/overview [get]
+func GetDeviceOverview(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	overview := DeviceOverview{
+		AlertSummary: map[string]int64{},
+	}
+
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		First(&overview.Device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Latest metric, command, and screenshot are each a single indexed lookup
+	var metric models.DeviceMetric
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		Order("timestamp desc").
+		First(&metric).Error; err == nil {
+		overview.LatestMetric = &metric
+	}
+
+	var command models.DeviceRemoteCommand
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		Order("created_at desc").
+		First(&command).Error; err == nil {
+		overview.LatestCommand = &command
+	}
+
+	var note models.DeviceNote
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		Order("created_at desc").
+		First(&note).Error; err == nil {
+		overview.LatestNote = &note
+	}
+
+	var shot models.DeviceScreenshot
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		Order("timestamp desc").
+		First(&shot).Error; err == nil {
+		overview.LatestScreenshot = gin.H{
+			"screenshotid":   shot.ScreenshotID,
+			"timestamp":      shot.Timestamp,
+			"path":           shot.Path,
+			"resolution":     shot.Resolution,
+			"size":           shot.Size,
+			"screenshot_url": s3.GeneratePresignedURL(shot.Path),
+		}
+	}
+
+	// Alert counts per level in a single grouped query
+	var levelCounts []struct {
+		Level string
+		Count int64
+	}
+	if err := database.Read().Model(&models.DeviceAlert{}).
+		Select("LOWER(level) AS level, COUNT(*) AS count").
+		Where("deviceid = ?", deviceID).
+		Group("LOWER(level)").
+		Scan(&levelCounts).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	for _, lc := range levelCounts {
+		overview.AlertSummary[lc.Level] = lc.Count
+	}
+
+	since := time.Now().Add(-DefaultOverviewActivityWindow)
+	if err := database.Read().Model(&models.DeviceActivity{}).
+		Where("deviceid = ? AND timestamp >= ?", deviceID, since).
+		Count(&overview.RecentActivityCount).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	maskDeviceForRequest(c, &overview.Device)
+
+	c.JSON(http.StatusOK, overview)
+}
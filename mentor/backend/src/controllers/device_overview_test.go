@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getDeviceOverview(t *testing.T, deviceID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID+"/overview", nil)
+
+	GetDeviceOverview(c)
+	return w
+}
+
+func TestGetDeviceOverview(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "overview-device", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	now := time.Now()
+	seeds := []interface{}{
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: device.DeviceID, Timestamp: now.Add(-2 * time.Minute), CPUUsage: 12.5},
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: device.DeviceID, Timestamp: now.Add(-time.Minute), CPUUsage: 42.0},
+		&models.DeviceScreenshot{ScreenshotID: uuid.New(), DeviceID: device.DeviceID, Timestamp: now, Path: "screenshots/latest.png"},
+		&models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: device.DeviceID, CommandText: "get_info", Status: "completed", CreatedAt: now},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: device.DeviceID, Level: "critical", AlertType: "cpu", Message: "cpu pegged"},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: device.DeviceID, Level: "warning", AlertType: "disk", Message: "disk filling"},
+		&models.DeviceActivity{ActivityID: uuid.New(), DeviceID: device.DeviceID, Timestamp: now.Add(-time.Hour), ActivityType: "login"},
+	}
+	for _, s := range seeds {
+		if err := database.DB.Create(s).Error; err != nil {
+			t.Fatalf("failed to seed overview data: %v", err)
+		}
+	}
+
+	w := getDeviceOverview(t, device.DeviceID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var overview DeviceOverview
+	if err := json.Unmarshal(w.Body.Bytes(), &overview); err != nil {
+		t.Fatalf("failed to unmarshal overview: %v", err)
+	}
+	if overview.Device.DeviceName != "overview-device" {
+		t.Errorf("expected seeded device, got %q", overview.Device.DeviceName)
+	}
+	if overview.LatestMetric == nil || overview.LatestMetric.CPUUsage != 42.0 {
+		t.Errorf("expected latest metric with cpu 42.0, got %+v", overview.LatestMetric)
+	}
+	if overview.LatestScreenshot == nil || overview.LatestScreenshot["path"] != "screenshots/latest.png" {
+		t.Errorf("expected latest screenshot, got %v", overview.LatestScreenshot)
+	}
+	if _, ok := overview.LatestScreenshot["screenshot_url"]; !ok {
+		t.Error("expected screenshot_url on latest screenshot")
+	}
+	if overview.LatestCommand == nil || overview.LatestCommand.CommandText != "get_info" {
+		t.Errorf("expected latest command, got %+v", overview.LatestCommand)
+	}
+	if overview.AlertSummary["critical"] != 1 || overview.AlertSummary["warning"] != 1 {
+		t.Errorf("expected one critical and one warning alert, got %v", overview.AlertSummary)
+	}
+	if overview.RecentActivityCount != 1 {
+		t.Errorf("expected 1 recent activity, got %d", overview.RecentActivityCount)
+	}
+}
+
+func TestGetDeviceOverviewUnknownDevice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := getDeviceOverview(t, uuid.New().String())
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown device, got %d", w.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// purgeableTypes maps the types accepted by the purge endpoint to the model
+// whose rows are deleted. Screenshots additionally have their stored objects
+// removed.
+var purgeableTypes = map[string]interface{}{
+	"metrics":     &models.DeviceMetric{},
+	"processes":   &models.DeviceProcess{},
+	"activities":  &models.DeviceActivity{},
+	"alerts":      &models.DeviceAlert{},
+	"commands":    &models.DeviceRemoteCommand{},
+	"screenshots": &models.DeviceScreenshot{},
+	"logs":        &models.DeviceLog{},
+}
+
+// PurgeDeviceData deletes selected telemetry for a device, keeping the device
+// @Summary Purge a device's telemetry
+// @Description Delete the selected telemetry types for one device (metrics, processes, activities, alerts, commands, screenshots, logs) while keeping its registration, e.g. before re-deploying the agent. Screenshot objects are also removed from storage. Requires confirm=true.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param types query string true "Comma-separated telemetry types to purge"
+// @Param confirm query bool true "Must be true to execute the purge"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID, types, or missing confirmation"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/purge [post]
+func PurgeDeviceData(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	if c.Query("confirm") != "true" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "purge requires confirm=true")
+		return
+	}
+
+	types := []string{}
+	for _, raw := range strings.Split(c.Query("types"), ",") {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if name == "" {
+			continue
+		}
+		if _, ok := purgeableTypes[name]; !ok {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, fmt.Sprintf("unknown telemetry type %q", name))
+			return
+		}
+		types = append(types, name)
+	}
+	if len(types) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "types parameter is required")
+		return
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if count == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+		return
+	}
+
+	// Collect screenshot object names before their rows disappear
+	var screenshotPaths []string
+	for _, name := range types {
+		if name == "screenshots" {
+			if err := database.DB.Model(&models.DeviceScreenshot{}).
+				Where("deviceid = ?", deviceID).
+				Pluck("path", &screenshotPaths).Error; err != nil {
+				RespondInternalError(c, err)
+				return
+			}
+		}
+	}
+
+	purged := map[string]int64{}
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		for _, name := range types {
+			result := tx.Where("deviceid = ?", deviceID).Delete(purgeableTypes[name])
+			if result.Error != nil {
+				return result.Error
+			}
+			purged[name] = result.RowsAffected
+
+			// Metrics rollups are derived from raw metrics; purge them together
+			if name == "metrics" {
+				if err := tx.Where("deviceid = ?", deviceID).
+					Delete(&models.DeviceMetricRollup{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Rows are gone; remove the backing objects best-effort
+	for _, p := range screenshotPaths {
+		if p == "" {
+			continue
+		}
+		if err := s3.RemoveObject(p); err != nil {
+			fmt.Printf("Failed to remove purged screenshot object %s: %v\n", p, err)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deviceid": deviceID, "purged": purged})
+}
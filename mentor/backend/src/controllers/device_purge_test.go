@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func purgeDevice(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/purge"+query, nil)
+
+	PurgeDeviceData(c)
+	return w
+}
+
+func TestPurgeDeviceDataSelective(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "purge-me", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	seed := []interface{}{
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: device.DeviceID, CPUUsage: 10},
+		&models.DeviceMetric{MetricID: uuid.New(), DeviceID: device.DeviceID, CPUUsage: 20},
+		&models.DeviceAlert{AlertID: uuid.New(), DeviceID: device.DeviceID, Level: "warning", AlertType: "cpu", Message: "m"},
+		&models.DeviceActivity{ActivityID: uuid.New(), DeviceID: device.DeviceID, ActivityType: "app_launch", App: "firefox"},
+	}
+	for _, row := range seed {
+		if err := database.DB.Create(row).Error; err != nil {
+			t.Fatalf("failed to seed telemetry: %v", err)
+		}
+	}
+
+	w := purgeDevice(t, device.DeviceID, "?types=metrics&confirm=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var metricCount, alertCount, activityCount int64
+	database.DB.Model(&models.DeviceMetric{}).Where("deviceid = ?", device.DeviceID).Count(&metricCount)
+	database.DB.Model(&models.DeviceAlert{}).Where("deviceid = ?", device.DeviceID).Count(&alertCount)
+	database.DB.Model(&models.DeviceActivity{}).Where("deviceid = ?", device.DeviceID).Count(&activityCount)
+
+	if metricCount != 0 {
+		t.Errorf("expected metrics purged, %d remain", metricCount)
+	}
+	if alertCount != 1 || activityCount != 1 {
+		t.Errorf("expected other telemetry untouched, got alerts=%d activities=%d", alertCount, activityCount)
+	}
+
+	// The device registration itself survives
+	var remaining models.Device
+	if err := database.DB.Where("deviceid = ?", device.DeviceID).First(&remaining).Error; err != nil {
+		t.Errorf("expected device row to survive purge: %v", err)
+	}
+}
+
+func TestPurgeDeviceDataValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "purge-validation", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	// Missing confirmation
+	if w := purgeDevice(t, device.DeviceID, "?types=metrics"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without confirm, got %d", w.Code)
+	}
+	// Unknown type
+	if w := purgeDevice(t, device.DeviceID, "?types=everything&confirm=true"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown type, got %d", w.Code)
+	}
+	// Missing types
+	if w := purgeDevice(t, device.DeviceID, "?confirm=true"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without types, got %d", w.Code)
+	}
+	// Unknown device
+	if w := purgeDevice(t, uuid.New(), "?types=metrics&confirm=true"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown device, got %d", w.Code)
+	}
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DefaultAtRiskMinLevel is the minimum alert level used when none is requested
+const DefaultAtRiskMinLevel = "critical"
+
+// DeviceAtRisk is a device joined with its alert counts at or above a level
+type DeviceAtRisk struct {
+	DeviceID       uuid.UUID `json:"deviceid"`
+	DeviceName     string    `json:"device_name"`
+	DeviceType     string    `json:"device_type"`
+	OS             string    `json:"os"`
+	IsOnline       bool      `json:"is_online"`
+	DeviceLocation string    `json:"device_location"`
+	AlertCount     int64     `json:"alert_count"`
+	MaxLevelRank   int       `json:"-"`
+	MaxLevel       string    `json:"max_level"`
+}
+
+// alertLevelForRank maps a severity rank back to its level name for responses
+func alertLevelForRank(rank int) string {
+	switch rank {
+	case 4:
+		return "critical"
+	case 3:
+		return "error"
+	case 2:
+		return "warning"
+	case 1:
+		return "info"
+	default:
+		return ""
+	}
+}
+
+// GetDevicesAtRisk lists devices that have alerts at or above a severity level
+// @Summary List at-risk devices
+// @Description Return devices with at least one alert at or above the given level, joined with their alert counts and ordered most severe first. A healthy fleet returns an empty array.
+// @Tags devices
+// @Produce json
+// @Param min_level query string false "Minimum alert level (info, warning, error, critical)" default(critical)
+// @Success 200 {array} controllers.DeviceAtRisk
+// @Failure 400 {object} map[string]string "Bad request - invalid level"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/at-risk [get]
+func GetDevicesAtRisk(c *gin.Context) {
+	minLevel := strings.ToLower(c.DefaultQuery("min_level", DefaultAtRiskMinLevel))
+	levels := models.AlertLevelsAtOrAbove(minLevel)
+	if levels == nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid min_level: must be info, warning, error, or critical")
+		return
+	}
+
+	// Single grouped join instead of an alert query per device
+	devices := make([]DeviceAtRisk, 0)
+	err := database.Read().Table("devices").
+		Select("devices.deviceid, devices.device_name, devices.device_type, devices.os, devices.is_online, devices.device_location, "+
+			"COUNT(device_alerts.alertid) AS alert_count, "+
+			"MAX(CASE LOWER(device_alerts.level) WHEN 'critical' THEN 4 WHEN 'error' THEN 3 WHEN 'warning' THEN 2 ELSE 1 END) AS max_level_rank").
+		Joins("JOIN device_alerts ON device_alerts.deviceid = devices.deviceid").
+		Where("LOWER(device_alerts.level) IN ?", levels).
+		Group("devices.deviceid, devices.device_name, devices.device_type, devices.os, devices.is_online, devices.device_location").
+		Order("max_level_rank DESC, alert_count DESC").
+		Scan(&devices).Error
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	for i := range devices {
+		devices[i].MaxLevel = alertLevelForRank(devices[i].MaxLevelRank)
+	}
+
+	c.JSON(http.StatusOK, devices)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getDevicesAtRisk(t *testing.T, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices/at-risk"+query, nil)
+
+	GetDevicesAtRisk(c)
+	return w
+}
+
+func TestGetDevicesAtRisk(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	critical := models.Device{DeviceID: uuid.New(), DeviceName: "critical-device"}
+	warned := models.Device{DeviceID: uuid.New(), DeviceName: "warned-device"}
+	healthy := models.Device{DeviceID: uuid.New(), DeviceName: "healthy-device"}
+	for _, d := range []models.Device{critical, warned, healthy} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to seed device: %v", err)
+		}
+	}
+
+	alerts := []models.DeviceAlert{
+		{AlertID: uuid.New(), DeviceID: critical.DeviceID, Level: "critical", AlertType: "cpu", Message: "cpu pegged"},
+		{AlertID: uuid.New(), DeviceID: critical.DeviceID, Level: "warning", AlertType: "memory", Message: "memory high"},
+		{AlertID: uuid.New(), DeviceID: warned.DeviceID, Level: "warning", AlertType: "disk", Message: "disk filling"},
+	}
+	for _, a := range alerts {
+		if err := database.DB.Create(&a).Error; err != nil {
+			t.Fatalf("failed to seed alert: %v", err)
+		}
+	}
+
+	// Only the device with a critical alert qualifies at min_level=error
+	w := getDevicesAtRisk(t, "?min_level=error")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var atRisk []DeviceAtRisk
+	if err := json.Unmarshal(w.Body.Bytes(), &atRisk); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(atRisk) != 1 {
+		t.Fatalf("expected 1 at-risk device, got %d", len(atRisk))
+	}
+	if atRisk[0].DeviceID != critical.DeviceID {
+		t.Errorf("expected device %s, got %s", critical.DeviceID, atRisk[0].DeviceID)
+	}
+	if atRisk[0].MaxLevel != "critical" {
+		t.Errorf("expected max_level critical, got %q", atRisk[0].MaxLevel)
+	}
+	if atRisk[0].AlertCount != 1 {
+		t.Errorf("expected 1 alert at or above error, got %d", atRisk[0].AlertCount)
+	}
+
+	// At min_level=warning both alerting devices appear, most severe first
+	w2 := getDevicesAtRisk(t, "?min_level=warning")
+	var atRisk2 []DeviceAtRisk
+	if err := json.Unmarshal(w2.Body.Bytes(), &atRisk2); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(atRisk2) != 2 {
+		t.Fatalf("expected 2 at-risk devices, got %d", len(atRisk2))
+	}
+	if atRisk2[0].DeviceID != critical.DeviceID {
+		t.Errorf("expected most severe device first, got %s", atRisk2[0].DeviceName)
+	}
+}
+
+func TestGetDevicesAtRiskHealthyFleet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := getDevicesAtRisk(t, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if body := w.Body.String(); body != "[]" {
+		t.Errorf("expected empty array for a healthy fleet, got %s", body)
+	}
+}
+
+func TestGetDevicesAtRiskInvalidLevel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := getDevicesAtRisk(t, "?min_level=catastrophic")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid level, got %d", w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sampling limits for canary rollouts
+const (
+	// DefaultSampleSize is the sample size used when n is not provided
+	DefaultSampleSize = 10
+	// MaxSampleSize caps how many devices a single sample may return
+	MaxSampleSize = 100
+)
+
+// SampleDevices returns a random subset of devices for canary rollouts
+// @Summary Sample random devices
+// @Description Return a random N devices matching optional filters. Used to target a subset of the fleet for gradual command rollouts.
+// @Tags devices
+// @Produce json
+// @Param n query int false "Sample size (capped at 100)" default(10)
+// @Param online query bool false "Only sample devices currently online"
+// @Param type query string false "Only sample devices of this type (desktop, mobile, tablet, server, iot)"
+// @Success 200 {array} models.Device
+// @Failure 400 {object} map[string]string "Bad request - invalid sample size or filter"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/sample [get]
+func SampleDevices(c *gin.Context) {
+	n := DefaultSampleSize
+	if raw := c.Query("n"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid n parameter: must be a positive integer")
+			return
+		}
+		n = parsed
+	}
+	if n > MaxSampleSize {
+		n = MaxSampleSize
+	}
+
+	query := database.Read().Model(&models.Device{})
+
+	if raw := c.Query("online"); raw != "" {
+		online, err := strconv.ParseBool(raw)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid online parameter: must be true or false")
+			return
+		}
+		query = query.Where("is_online = ?", online)
+	}
+
+	if deviceType := strings.ToLower(c.Query("type")); deviceType != "" {
+		if !models.IsValidDeviceType(deviceType) {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid type parameter: must be desktop, mobile, tablet, server, or iot")
+			return
+		}
+		query = query.Where("LOWER(device_type) = ?", deviceType)
+	}
+
+	// random() keeps the sampling in SQL; fine at our fleet sizes, revisit with
+	// reservoir sampling if fleets grow past what a sort can handle
+	devices := make([]models.Device, 0, n)
+	if err := query.Order("random()").Limit(n).Find(&devices).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	applyDeviceMasking(c, devices)
+
+	c.JSON(http.StatusOK, devices)
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func sampleDevices(t *testing.T, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices/sample"+query, nil)
+
+	SampleDevices(c)
+	return w
+}
+
+func TestSampleDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// Seed a mixed fleet: 6 online servers, 4 offline desktops
+	for i := 0; i < 6; i++ {
+		d := models.Device{DeviceID: uuid.New(), DeviceName: fmt.Sprintf("server-%d", i), DeviceType: "server", IsOnline: true}
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to seed device: %v", err)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		d := models.Device{DeviceID: uuid.New(), DeviceName: fmt.Sprintf("desktop-%d", i), DeviceType: "desktop", IsOnline: false}
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to seed device: %v", err)
+		}
+	}
+
+	w := sampleDevices(t, "?n=3&online=true&type=server")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var sampled []models.Device
+	if err := json.Unmarshal(w.Body.Bytes(), &sampled); err != nil {
+		t.Fatalf("failed to unmarshal devices: %v", err)
+	}
+	if len(sampled) != 3 {
+		t.Fatalf("expected sample of 3 devices, got %d", len(sampled))
+	}
+	for _, d := range sampled {
+		if !d.IsOnline || d.DeviceType != "server" {
+			t.Errorf("sampled device %s does not match filter: online=%v type=%s", d.DeviceName, d.IsOnline, d.DeviceType)
+		}
+	}
+
+	// Requesting more than available returns everything that matches
+	w2 := sampleDevices(t, "?n=50&type=desktop")
+	var all []models.Device
+	if err := json.Unmarshal(w2.Body.Bytes(), &all); err != nil {
+		t.Fatalf("failed to unmarshal devices: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("expected all 4 desktops, got %d", len(all))
+	}
+}
+
+func TestSampleDevicesInvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := sampleDevices(t, "?n=-1"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for negative n, got %d", w.Code)
+	}
+	if w := sampleDevices(t, "?online=maybe"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid online filter, got %d", w.Code)
+	}
+	if w := sampleDevices(t, "?type=mainframe"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid type filter, got %d", w.Code)
+	}
+}
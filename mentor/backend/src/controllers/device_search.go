@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// escapeLikePattern backslash-escapes LIKE wildcards in user input so a
+// search for "db_01" or "50%" matches those literal strings instead of
+// acting as a pattern.
+func escapeLikePattern(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `%`, `\%`)
+	s = strings.ReplaceAll(s, `_`, `\_`)
+	return s
+}
+
+// applyDeviceSearch narrows a device query to rows where any searchable text
+// field contains q, case-insensitively. LOWER + LIKE with an explicit ESCAPE
+// behaves the same on Postgres and SQLite; current_user must be quoted
+// because unquoted it resolves to the SQL session function on Postgres.
+func applyDeviceSearch(query *gorm.DB, q string) *gorm.DB {
+	pattern := "%" + strings.ToLower(escapeLikePattern(q)) + "%"
+	return query.Where(
+		`LOWER(device_name) LIKE ? ESCAPE '\' OR LOWER("current_user") LIKE ? ESCAPE '\' OR LOWER(ip_address) LIKE ? ESCAPE '\' OR LOWER(device_location) LIKE ? ESCAPE '\'`,
+		pattern, pattern, pattern, pattern,
+	)
+}
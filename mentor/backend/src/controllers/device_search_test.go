@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestEscapeLikePattern(t *testing.T) {
+	cases := map[string]string{
+		"plain":  "plain",
+		"50%":    `50\%`,
+		"db_01":  `db\_01`,
+		`a\b`:    `a\\b`,
+		"%_\\%_": `\%\_\\\%\_`,
+	}
+	for input, want := range cases {
+		if got := escapeLikePattern(input); got != want {
+			t.Errorf("escapeLikePattern(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func searchDevices(t *testing.T, q string) []models.Device {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices?q="+url.QueryEscape(q), nil)
+
+	ListDevices(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var devices []models.Device
+	if err := json.Unmarshal(w.Body.Bytes(), &devices); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return devices
+}
+
+func TestListDevicesSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	seed := []models.Device{
+		{DeviceID: uuid.New(), DeviceName: "Finance-Laptop", CurrentUser: "alice", IPAddress: "10.0.0.5", DeviceLocation: "Beirut", LastSeen: time.Now()},
+		{DeviceID: uuid.New(), DeviceName: "build_server", CurrentUser: "bob", IPAddress: "10.0.1.9", DeviceLocation: "Paris", LastSeen: time.Now()},
+		{DeviceID: uuid.New(), DeviceName: "kiosk-3", CurrentUser: "carol", IPAddress: "192.168.4.2", DeviceLocation: "Beirut Annex", LastSeen: time.Now()},
+	}
+	for _, device := range seed {
+		if err := database.DB.Create(&device).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+
+	// Case-insensitive name match.
+	if got := searchDevices(t, "finance"); len(got) != 1 || got[0].DeviceName != "Finance-Laptop" {
+		t.Errorf("expected the finance laptop, got %+v", got)
+	}
+
+	// Match on current user.
+	if got := searchDevices(t, "BOB"); len(got) != 1 || got[0].CurrentUser != "bob" {
+		t.Errorf("expected bob's device, got %+v", got)
+	}
+
+	// Match on location hits both Beirut devices.
+	if got := searchDevices(t, "beirut"); len(got) != 2 {
+		t.Errorf("expected 2 Beirut devices, got %+v", got)
+	}
+
+	// Underscore is matched literally, not as a single-character wildcard.
+	if got := searchDevices(t, "build_server"); len(got) != 1 || got[0].DeviceName != "build_server" {
+		t.Errorf("expected the build server, got %+v", got)
+	}
+	if got := searchDevices(t, "build%"); len(got) != 0 {
+		t.Errorf("expected %% to be literal and match nothing, got %+v", got)
+	}
+
+	// Empty q returns the full list.
+	if got := searchDevices(t, ""); len(got) != 3 {
+		t.Errorf("expected all 3 devices for empty q, got %+v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSimilarDevices is how many similar devices are returned when n
+	// is not given.
+	DefaultSimilarDevices = 5
+	// MaxSimilarDevices caps n so one request cannot rank the whole fleet.
+	MaxSimilarDevices = 50
+)
+
+// DeviceSimilarity is one entry in the similarity ranking: how closely
+// another device's process-name set matches the reference device's.
+type DeviceSimilarity struct {
+	DeviceID   uuid.UUID `json:"deviceid"`
+	Similarity float64   `json:"similarity"`
+	Shared     int       `json:"shared"`
+}
+
+// GetSimilarDevices ranks devices by process-profile similarity
+// @Summary Find devices with similar process profiles
+// @Description Compare the device's current process-name set against every other device using Jaccard similarity and return the closest matches. Useful for spotting machines configured alike, or the one that is not. Returns an empty list when the device has no process snapshot.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param n query int false "Number of similar devices to return" default(5)
+// @Success 200 {array} DeviceSimilarity
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID or n"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/similar [get]
+func GetSimilarDevices(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	n := DefaultSimilarDevices
+	if raw := c.Query("n"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid n parameter: must be a positive integer")
+			return
+		}
+		n = parsed
+	}
+	if n > MaxSimilarDevices {
+		n = MaxSimilarDevices
+	}
+
+	profiles, err := loadProcessProfiles()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	reference, ok := profiles[deviceID]
+	if !ok || len(reference) == 0 {
+		// No snapshot to compare against
+		c.JSON(http.StatusOK, []DeviceSimilarity{})
+		return
+	}
+
+	ranking := make([]DeviceSimilarity, 0)
+	for id, profile := range profiles {
+		if id == deviceID || len(profile) == 0 {
+			continue
+		}
+		shared := 0
+		for name := range profile {
+			if reference[name] {
+				shared++
+			}
+		}
+		// Disjoint profiles are not similar at all; omit them rather than
+		// pad the ranking with zeros
+		if shared == 0 {
+			continue
+		}
+		union := len(reference) + len(profile) - shared
+		ranking = append(ranking, DeviceSimilarity{
+			DeviceID:   id,
+			Similarity: float64(shared) / float64(union),
+			Shared:     shared,
+		})
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Similarity != ranking[j].Similarity {
+			return ranking[i].Similarity > ranking[j].Similarity
+		}
+		// Stable order for equal scores
+		return ranking[i].DeviceID.String() < ranking[j].DeviceID.String()
+	})
+	if len(ranking) > n {
+		ranking = ranking[:n]
+	}
+
+	c.JSON(http.StatusOK, ranking)
+}
+
+// loadProcessProfiles builds the per-device set of lowercased process names
+// from the current snapshots.
+func loadProcessProfiles() (map[uuid.UUID]map[string]bool, error) {
+	var rows []struct {
+		DeviceID    uuid.UUID `gorm:"column:deviceid"`
+		ProcessName string
+	}
+	if err := database.Read().Model(&models.DeviceProcess{}).
+		Distinct("deviceid", "process_name").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[uuid.UUID]map[string]bool)
+	for _, row := range rows {
+		name := strings.ToLower(strings.TrimSpace(row.ProcessName))
+		if name == "" {
+			continue
+		}
+		if profiles[row.DeviceID] == nil {
+			profiles[row.DeviceID] = make(map[string]bool)
+		}
+		profiles[row.DeviceID][name] = true
+	}
+	return profiles, nil
+}
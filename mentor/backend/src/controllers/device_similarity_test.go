@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getSimilarDevices(t *testing.T, deviceID uuid.UUID, query string) []DeviceSimilarity {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/similar"+query, nil)
+
+	GetSimilarDevices(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var ranking []DeviceSimilarity
+	if err := json.Unmarshal(w.Body.Bytes(), &ranking); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return ranking
+}
+
+func seedProcessNames(t *testing.T, deviceID uuid.UUID, names ...string) {
+	t.Helper()
+	for i, name := range names {
+		p := models.DeviceProcess{
+			ProcessID: uuid.New(), DeviceID: deviceID,
+			PID: 100 + i, ProcessName: name,
+		}
+		if err := database.DB.Create(&p).Error; err != nil {
+			t.Fatalf("failed to seed process: %v", err)
+		}
+	}
+}
+
+func TestGetSimilarDevicesRanking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	reference := uuid.New()
+	twin := uuid.New()
+	cousin := uuid.New()
+	stranger := uuid.New()
+
+	seedProcessNames(t, reference, "sshd", "nginx", "postgres", "cron")
+	seedProcessNames(t, twin, "sshd", "nginx", "postgres", "cron")   // identical: 1.0
+	seedProcessNames(t, cousin, "sshd", "nginx", "redis")            // 2 shared of 5: 0.4
+	seedProcessNames(t, stranger, "explorer.exe", "chrome", "slack") // disjoint: 0
+	ranking := getSimilarDevices(t, reference, "?n=5")
+
+	if len(ranking) != 2 {
+		t.Fatalf("expected 2 ranked devices (disjoint profile omitted), got %d: %+v", len(ranking), ranking)
+	}
+	if ranking[0].DeviceID != twin || ranking[0].Similarity != 1.0 {
+		t.Errorf("expected twin first with similarity 1.0, got %+v", ranking[0])
+	}
+	if ranking[1].DeviceID != cousin {
+		t.Errorf("expected cousin second, got %+v", ranking[1])
+	}
+	if ranking[1].Similarity <= 0 || ranking[1].Similarity >= ranking[0].Similarity {
+		t.Errorf("expected cousin similarity between 0 and 1.0, got %v", ranking[1].Similarity)
+	}
+}
+
+func TestGetSimilarDevicesInsufficientData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// Device with no process snapshot ranks nothing
+	if ranking := getSimilarDevices(t, uuid.New(), ""); len(ranking) != 0 {
+		t.Errorf("expected empty ranking for device without snapshot, got %+v", ranking)
+	}
+}
+
+func TestGetSimilarDevicesValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/similar?n=0", nil)
+	GetSimilarDevices(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for n=0, got %d", w.Code)
+	}
+}
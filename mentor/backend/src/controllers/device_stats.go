@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"fmt"
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DefaultIngestStatsWindow is the default lookback window for ingestion statistics
+const DefaultIngestStatsWindow = time.Hour
+
+// IngestStats summarizes how many telemetry rows a device submitted in a window
+type IngestStats struct {
+	DeviceID   uuid.UUID `json:"deviceid"`
+	Window     string    `json:"window"`
+	Metrics    int64     `json:"metrics"`
+	Processes  int64     `json:"processes"`
+	Activities int64     `json:"activities"`
+	Alerts     int64     `json:"alerts"`
+}
+
+// GetDeviceIngestStats reports ingestion throughput for a device
+// @Summary Get device ingestion statistics
+// @Description Count metrics, processes, activities, and alerts received from a device within a time window. Useful for spotting chatty agents and tuning rate limits.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param window query string false "Lookback window as a Go duration (e.g. 1h, 30m)" default(1h)
+// @Success 200 {object} controllers.IngestStats
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID or window"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/ingest-stats [get]
+func GetDeviceIngestStats(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	window := DefaultIngestStatsWindow
+	if w := c.Query("window"); w != "" {
+		parsed, err := time.ParseDuration(w)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid window parameter: must be a positive duration like 1h or 30m")
+			return
+		}
+		window = parsed
+	}
+
+	since := time.Now().Add(-window)
+	stats := IngestStats{
+		DeviceID: deviceID,
+		Window:   window.String(),
+	}
+
+	// Grouped counts per telemetry table; idle devices naturally report zeros
+	counts := []struct {
+		model interface{}
+		dest  *int64
+	}{
+		{&models.DeviceMetric{}, &stats.Metrics},
+		{&models.DeviceProcess{}, &stats.Processes},
+		{&models.DeviceActivity{}, &stats.Activities},
+		{&models.DeviceAlert{}, &stats.Alerts},
+	}
+	for _, q := range counts {
+		if err := database.Read().Model(q.model).
+			Where("deviceid = ? AND timestamp >= ?", deviceID, since).
+			Count(q.dest).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// DefaultMetricStatsWindow is the default lookback window for metric summaries
+const DefaultMetricStatsWindow = 24 * time.Hour
+
+// metricStatsFields is the whitelist of metric columns that can be summarized
+var metricStatsFields = map[string]bool{
+	"cpu_usage":     true,
+	"cpu_temp":      true,
+	"memory_used":   true,
+	"swap_used":     true,
+	"disk_used":     true,
+	"net_bytes_in":  true,
+	"net_bytes_out": true,
+}
+
+// MetricFieldStats summarizes one metric field over a window. The aggregate
+// values are pointers so devices with no data report nulls rather than zeros.
+type MetricFieldStats struct {
+	DeviceID uuid.UUID `json:"deviceid"`
+	Field    string    `json:"field"`
+	Window   string    `json:"window"`
+	Min      *float64  `json:"min"`
+	Max      *float64  `json:"max"`
+	Avg      *float64  `json:"avg"`
+	Latest   *float64  `json:"latest"`
+	Samples  int64     `json:"samples"`
+}
+
+// GetDeviceMetricStats returns min/max/avg/latest for one metric field
+// @Summary Get device metric summary
+// @Description Compute min, max, average, and latest value of a metric field over a window in a single grouped query, so dashboards don't download the whole series for header numbers
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param field query string false "Metric field to summarize" default(cpu_usage)
+// @Param last query string false "Lookback window (e.g. 24h)" default(24h)
+// @Success 200 {object} controllers.MetricFieldStats
+// @Failure 400 {object} map[string]string "Bad request - invalid field or window"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/metrics/stats [get]
+func GetDeviceMetricStats(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	field := strings.ToLower(c.DefaultQuery("field", "cpu_usage"))
+	if !metricStatsFields[field] {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid field parameter: must be one of cpu_usage, cpu_temp, memory_used, swap_used, disk_used, net_bytes_in, net_bytes_out")
+		return
+	}
+
+	since, hasLast, err := parseLastParam(c)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	window := DefaultMetricStatsWindow
+	if hasLast {
+		window = time.Since(since)
+	} else {
+		since = time.Now().Add(-DefaultMetricStatsWindow)
+	}
+
+	stats := MetricFieldStats{
+		DeviceID: deviceID,
+		Field:    field,
+		Window:   window.Round(time.Second).String(),
+	}
+
+	// Windows beyond the crossover read the pre-aggregated hourly rollup
+	// instead of scanning raw metrics; short windows stay on raw data so
+	// recent values are exact. The field name is whitelisted above, so
+	// interpolating it is safe in both branches.
+	if rollupFields[field] && window > metricRollupCrossover() {
+		// Rollup buckets are hour-aligned, so the window edge is approximated
+		// to the containing hour; negligible over multi-day ranges
+		if err := database.Read().Model(&models.DeviceMetricRollup{}).
+			Select(fmt.Sprintf("MIN(min_%s) as min, MAX(max_%s) as max, "+
+				"SUM(avg_%s * samples) / NULLIF(SUM(samples), 0) as avg, "+
+				"COALESCE(SUM(samples), 0) as samples", field, field, field)).
+			Where("deviceid = ? AND bucket_start >= ?", deviceID, since.UTC().Truncate(time.Hour)).
+			Scan(&stats).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+	} else if err := database.Read().Model(&models.DeviceMetric{}).
+		Select(fmt.Sprintf("MIN(%s) as min, MAX(%s) as max, AVG(%s) as avg, COUNT(*) as samples", field, field, field)).
+		Where("deviceid = ? AND timestamp >= ?", deviceID, since).
+		Scan(&stats).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	if stats.Samples > 0 {
+		var latest float64
+		if err := database.Read().Model(&models.DeviceMetric{}).
+			Select(field).
+			Where("deviceid = ? AND timestamp >= ?", deviceID, since).
+			Order("timestamp desc").
+			Limit(1).
+			Scan(&latest).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		stats.Latest = &latest
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// DefaultHeavyProcessesLimit is the default number of ranked processes to return
+const DefaultHeavyProcessesLimit = 5
+
+// HeavyProcess summarizes one process's resource consumption across a window
+type HeavyProcess struct {
+	ProcessName string  `json:"process_name"`
+	PeakCPU     float64 `json:"peak_cpu"`
+	AvgCPU      float64 `json:"avg_cpu"`
+	PeakMemory  uint64  `json:"peak_memory"`
+	AvgMemory   float64 `json:"avg_memory"`
+	Samples     int64   `json:"samples"`
+}
+
+// GetHeavyProcesses ranks a device's most expensive processes over a window
+// @Summary Get heaviest processes over time
+// @Description Rank the processes that consumed the most CPU or memory across a time window, with per-process peak and average. More analytical than the latest-snapshot process list.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param by query string false "Resource to rank by: cpu or memory" default(cpu)
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Param n query int false "Number of processes to return" default(5)
+// @Success 200 {array} controllers.HeavyProcess
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/processes/heavy [get]
+func GetHeavyProcesses(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	by := strings.ToLower(c.DefaultQuery("by", "cpu"))
+	if by != "cpu" && by != "memory" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid by parameter: must be cpu or memory")
+		return
+	}
+
+	limit := DefaultHeavyProcessesLimit
+	if n := c.Query("n"); n != "" {
+		if _, err := fmt.Sscanf(n, "%d", &limit); err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid n parameter: must be a positive integer")
+			return
+		}
+	}
+
+	query := database.Read().Model(&models.DeviceProcess{}).
+		Select("process_name, MAX(cpu) as peak_cpu, AVG(cpu) as avg_cpu, "+
+			"MAX(memory) as peak_memory, AVG(memory) as avg_memory, COUNT(*) as samples").
+		Where("deviceid = ?", deviceID).
+		Group("process_name")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("timestamp >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("timestamp <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	if by == "memory" {
+		query = query.Order("peak_memory desc")
+	} else {
+		query = query.Order("peak_cpu desc")
+	}
+
+	heavy := make([]HeavyProcess, 0)
+	if err := query.Limit(limit).Find(&heavy).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, heavy)
+}
@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceIngestStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	// Seed varied ingest: two metrics, one process, one activity inside the
+	// window and one old metric outside it
+	for i := 0; i < 2; i++ {
+		m := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-time.Minute)}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+	old := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-2 * time.Hour)}
+	if err := database.DB.Create(&old).Error; err != nil {
+		t.Fatalf("failed to seed old metric: %v", err)
+	}
+	p := models.DeviceProcess{ProcessID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-time.Minute), ProcessName: "ingest-test"}
+	if err := database.DB.Create(&p).Error; err != nil {
+		t.Fatalf("failed to seed process: %v", err)
+	}
+	a := models.DeviceActivity{ActivityID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-time.Minute), ActivityType: "app_usage"}
+	if err := database.DB.Create(&a).Error; err != nil {
+		t.Fatalf("failed to seed activity: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/ingest-stats?window=1h", nil)
+
+	GetDeviceIngestStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats IngestStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if stats.Metrics != 2 {
+		t.Errorf("expected 2 metrics in window, got %d", stats.Metrics)
+	}
+	if stats.Processes != 1 {
+		t.Errorf("expected 1 process in window, got %d", stats.Processes)
+	}
+	if stats.Activities != 1 {
+		t.Errorf("expected 1 activity in window, got %d", stats.Activities)
+	}
+	if stats.Alerts != 0 {
+		t.Errorf("expected 0 alerts in window, got %d", stats.Alerts)
+	}
+}
+
+func TestGetDeviceIngestStatsIdleDevice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/ingest-stats", nil)
+
+	GetDeviceIngestStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats IngestStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if stats.Metrics != 0 || stats.Processes != 0 || stats.Activities != 0 || stats.Alerts != 0 {
+		t.Errorf("expected zero counts for idle device, got %+v", stats)
+	}
+}
+
+func TestGetDeviceIngestStatsInvalidWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/ingest-stats?window=bogus", nil)
+
+	GetDeviceIngestStats(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid window, got %d", w.Code)
+	}
+}
+
+func TestGetHeavyProcessesByMemory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	// "browser" peaks highest on memory, "indexer" on CPU
+	seed := []models.DeviceProcess{
+		{ProcessID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-10 * time.Minute), ProcessName: "browser", CPU: 20, Memory: 4000},
+		{ProcessID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-5 * time.Minute), ProcessName: "browser", CPU: 30, Memory: 8000},
+		{ProcessID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-10 * time.Minute), ProcessName: "indexer", CPU: 90, Memory: 1000},
+		{ProcessID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-5 * time.Minute), ProcessName: "indexer", CPU: 95, Memory: 1200},
+	}
+	for i := range seed {
+		if err := database.DB.Create(&seed[i]).Error; err != nil {
+			t.Fatalf("failed to seed process: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/processes/heavy?by=memory&n=2", nil)
+
+	GetHeavyProcesses(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var heavy []HeavyProcess
+	if err := json.Unmarshal(w.Body.Bytes(), &heavy); err != nil {
+		t.Fatalf("failed to unmarshal heavy processes: %v", err)
+	}
+	if len(heavy) != 2 {
+		t.Fatalf("expected 2 ranked processes, got %d", len(heavy))
+	}
+	if heavy[0].ProcessName != "browser" {
+		t.Errorf("expected 'browser' ranked first by memory, got %q", heavy[0].ProcessName)
+	}
+	if heavy[0].PeakMemory != 8000 {
+		t.Errorf("expected peak memory 8000, got %d", heavy[0].PeakMemory)
+	}
+	if heavy[0].Samples != 2 {
+		t.Errorf("expected 2 samples for browser, got %d", heavy[0].Samples)
+	}
+}
+
+func TestGetHeavyProcessesInvalidBy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/processes/heavy?by=network", nil)
+
+	GetHeavyProcesses(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid by parameter, got %d", w.Code)
+	}
+}
+
+func TestGetDeviceMetricStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	usages := []float64{20, 50, 80}
+	for i, usage := range usages {
+		m := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-time.Duration(len(usages)-i) * time.Minute), CPUUsage: usage}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/stats?field=cpu_usage&last=24h", nil)
+
+	GetDeviceMetricStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats MetricFieldStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if stats.Min == nil || *stats.Min != 20 {
+		t.Errorf("expected min 20, got %v", stats.Min)
+	}
+	if stats.Max == nil || *stats.Max != 80 {
+		t.Errorf("expected max 80, got %v", stats.Max)
+	}
+	if stats.Avg == nil || *stats.Avg != 50 {
+		t.Errorf("expected avg 50, got %v", stats.Avg)
+	}
+	if stats.Latest == nil || *stats.Latest != 80 {
+		t.Errorf("expected latest 80, got %v", stats.Latest)
+	}
+	if stats.Samples != 3 {
+		t.Errorf("expected 3 samples, got %d", stats.Samples)
+	}
+}
+
+func TestGetDeviceMetricStatsNoData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/stats", nil)
+
+	GetDeviceMetricStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats MetricFieldStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if stats.Min != nil || stats.Max != nil || stats.Avg != nil || stats.Latest != nil {
+		t.Errorf("expected null aggregates for no data, got %+v", stats)
+	}
+}
+
+func TestGetDeviceMetricStatsInvalidField(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/metrics/stats?field=uptime", nil)
+
+	GetDeviceMetricStats(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid field, got %d", w.Code)
+	}
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// deviceTokenAuthEnabled reports whether per-device bearer tokens are enforced
+// on ingestion routes (DEVICE_TOKEN_AUTH env). Off by default so existing
+// fleets keep working until every agent has been issued a token.
+func deviceTokenAuthEnabled() bool {
+	v := strings.ToLower(os.Getenv("DEVICE_TOKEN_AUTH"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
+// hashDeviceToken returns the hex SHA-256 of a clear token. Only hashes are
+// stored, so a database leak does not leak usable credentials.
+func hashDeviceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueDeviceToken mints a new agent token for a device
+// @Summary Issue a device token
+// @Description Generate a per-device agent credential. The clear token is returned only in this response; the server keeps just its hash. Issue a new token and revoke the old one to rotate.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/token [post]
+func IssueDeviceToken(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if count == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+		return
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		RespondError(c, http.StatusInternalServerError, ErrCodeInternal, "failed to generate token")
+		return
+	}
+	token := hex.EncodeToString(raw)
+
+	record := models.DeviceToken{
+		TokenID:   uuid.New(),
+		DeviceID:  deviceID,
+		TokenHash: hashDeviceToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := database.DB.Create(&record).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokenid":    record.TokenID,
+		"deviceid":   deviceID,
+		"token":      token,
+		"created_at": record.CreatedAt,
+	})
+}
+
+// RevokeDeviceToken revokes a previously issued device token
+// @Summary Revoke a device token
+// @Description Soft-delete a device token so it stops authenticating immediately. The row is kept for audit.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param tokenid path string true "Token ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string "Bad request - invalid IDs"
+// @Failure 404 {object} map[string]string "Token not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/token/{tokenid} [delete]
+func RevokeDeviceToken(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+	tokenID, err := uuid.Parse(c.Param("tokenid"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid token ID")
+		return
+	}
+
+	result := database.DB.Model(&models.DeviceToken{}).
+		Where("tokenid = ? AND deviceid = ? AND revoked_at IS NULL", tokenID, deviceID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		RespondInternalError(c, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "token not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "revoked"})
+}
+
+// RequireDeviceToken validates the Bearer token on a device-scoped ingestion
+// route. The device is taken from the :id path parameter; a token issued to a
+// different device is rejected so one compromised agent cannot write as
+// another. A no-op until DEVICE_TOKEN_AUTH is enabled.
+func RequireDeviceToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !deviceTokenAuthEnabled() {
+			c.Next()
+			return
+		}
+
+		deviceID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+
+		var record models.DeviceToken
+		err = database.DB.Where("token_hash = ?", hashDeviceToken(strings.TrimSpace(token))).
+			First(&record).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			} else {
+				RespondInternalError(c, err)
+			}
+			return
+		}
+		if record.RevokedAt != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token revoked"})
+			return
+		}
+		if record.DeviceID != deviceID {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token does not belong to this device"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func issueToken(t *testing.T, deviceID uuid.UUID) (uuid.UUID, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/token", nil)
+
+	IssueDeviceToken(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 issuing token, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		TokenID uuid.UUID `json:"tokenid"`
+		Token   string    `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal token response: %v", err)
+	}
+	if resp.Token == "" {
+		t.Fatal("expected non-empty token")
+	}
+	return resp.TokenID, resp.Token
+}
+
+// authedRequest runs a request through the token middleware with a trivial
+// terminal handler and reports the resulting status.
+func authedRequest(t *testing.T, deviceID uuid.UUID, bearer string) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/alerts", nil)
+	if bearer != "" {
+		c.Request.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	RequireDeviceToken()(c)
+	if c.IsAborted() {
+		return w.Code
+	}
+	return http.StatusOK
+}
+
+func seedTokenDevice(t *testing.T) models.Device {
+	t.Helper()
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "token-device", DeviceType: "laptop"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+	return device
+}
+
+func TestDeviceTokenAuthFlow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("DEVICE_TOKEN_AUTH", "true")
+
+	device := seedTokenDevice(t)
+	other := seedTokenDevice(t)
+	tokenID, token := issueToken(t, device.DeviceID)
+
+	// Hash, not the token itself, is stored
+	var record models.DeviceToken
+	if err := database.DB.Where("tokenid = ?", tokenID).First(&record).Error; err != nil {
+		t.Fatalf("failed to load token record: %v", err)
+	}
+	if record.TokenHash == token {
+		t.Error("token stored in clear text")
+	}
+
+	if code := authedRequest(t, device.DeviceID, token); code != http.StatusOK {
+		t.Errorf("expected valid token to pass, got %d", code)
+	}
+	if code := authedRequest(t, other.DeviceID, token); code != http.StatusForbidden {
+		t.Errorf("expected cross-device token use to return 403, got %d", code)
+	}
+	if code := authedRequest(t, device.DeviceID, ""); code != http.StatusUnauthorized {
+		t.Errorf("expected missing token to return 401, got %d", code)
+	}
+	if code := authedRequest(t, device.DeviceID, "bogus"); code != http.StatusUnauthorized {
+		t.Errorf("expected unknown token to return 401, got %d", code)
+	}
+}
+
+func TestDeviceTokenRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("DEVICE_TOKEN_AUTH", "true")
+
+	device := seedTokenDevice(t)
+	tokenID, token := issueToken(t, device.DeviceID)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "id", Value: device.DeviceID.String()},
+		{Key: "tokenid", Value: tokenID.String()},
+	}
+	c.Request, _ = http.NewRequest("DELETE", "/devices/"+device.DeviceID.String()+"/token/"+tokenID.String(), nil)
+	RevokeDeviceToken(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 revoking token, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	if code := authedRequest(t, device.DeviceID, token); code != http.StatusUnauthorized {
+		t.Errorf("expected revoked token to return 401, got %d", code)
+	}
+
+	// Revoking twice is a 404: the token is already gone
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{
+		{Key: "id", Value: device.DeviceID.String()},
+		{Key: "tokenid", Value: tokenID.String()},
+	}
+	c.Request, _ = http.NewRequest("DELETE", "/devices/"+device.DeviceID.String()+"/token/"+tokenID.String(), nil)
+	RevokeDeviceToken(c)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 revoking twice, got %d", w.Code)
+	}
+}
+
+func TestDeviceTokenAuthOffByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("DEVICE_TOKEN_AUTH", "")
+
+	device := seedTokenDevice(t)
+	if code := authedRequest(t, device.DeviceID, ""); code != http.StatusOK {
+		t.Errorf("expected middleware to no-op with auth disabled, got %d", code)
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTopDevices is how many devices the top-usage view returns by default
+	DefaultTopDevices = 10
+	// MaxTopDevices bounds the top-usage result size
+	MaxTopDevices = 100
+)
+
+// topUsageFields maps the "by" query values onto the metric column to rank by.
+var topUsageFields = map[string]string{
+	"cpu":      "cpu_usage",
+	"cpu_temp": "cpu_temp",
+	"memory":   "memory_used",
+	"disk":     "disk_used",
+}
+
+// DeviceTopUsage is one row of the hot-devices view: a device joined to its
+// latest metric sample.
+type DeviceTopUsage struct {
+	DeviceID   uuid.UUID `json:"deviceid"`
+	DeviceName string    `json:"device_name"`
+	DeviceType string    `json:"device_type"`
+	IsOnline   bool      `json:"is_online"`
+	Timestamp  time.Time `json:"timestamp"`
+	CPUUsage   float64   `json:"cpu_usage"`
+	CPUTemp    float64   `json:"cpu_temp"`
+	MemoryUsed uint64    `json:"memory_used"`
+	DiskUsed   uint64    `json:"disk_used"`
+}
+
+// GetTopDevices lists the devices with the highest current resource usage
+// @Summary List top resource-consuming devices
+// @Description Join each device to its latest metric sample and rank by the chosen field (cpu, cpu_temp, memory, disk). The latest sample per device is computed in one DISTINCT ON query, not per device.
+// @Tags devices
+// @Produce json
+// @Param by query string false "Field to rank by: cpu (default), cpu_temp, memory, disk"
+// @Param n query int false "Number of devices to return (default 10, max 100)"
+// @Success 200 {array} controllers.DeviceTopUsage
+// @Failure 400 {object} map[string]string "Bad request - invalid by or n parameter"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/top [get]
+func GetTopDevices(c *gin.Context) {
+	by := c.DefaultQuery("by", "cpu")
+	field, ok := topUsageFields[by]
+	if !ok {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid by parameter; use cpu, cpu_temp, memory, or disk")
+		return
+	}
+
+	n := DefaultTopDevices
+	if raw := c.Query("n"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid n parameter")
+			return
+		}
+		n = parsed
+	}
+	if n > MaxTopDevices {
+		n = MaxTopDevices
+	}
+
+	// One DISTINCT ON pass finds every device's latest sample, then the join
+	// ranks them; no per-device queries
+	latest := database.Read().Model(&models.DeviceMetric{}).
+		Select("DISTINCT ON (deviceid) deviceid, timestamp, cpu_usage, cpu_temp, memory_used, disk_used").
+		Order("deviceid, timestamp DESC")
+
+	results := make([]DeviceTopUsage, 0)
+	if err := database.Read().Table("(?) AS latest_metrics", latest).
+		Select("devices.deviceid, devices.device_name, devices.device_type, devices.is_online, " +
+			"latest_metrics.timestamp, latest_metrics.cpu_usage, latest_metrics.cpu_temp, " +
+			"latest_metrics.memory_used, latest_metrics.disk_used").
+		Joins("JOIN devices ON devices.deviceid = latest_metrics.deviceid").
+		Order("latest_metrics." + field + " DESC").
+		Limit(n).
+		Scan(&results).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
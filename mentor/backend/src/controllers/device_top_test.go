@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetTopDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// Three devices with different latest CPU; the older high sample on the
+	// "cool" device must not count
+	base := time.Now().Add(-time.Hour)
+	devices := []struct {
+		name      string
+		latestCPU float64
+	}{
+		{"hot", 95},
+		{"warm", 50},
+		{"cool", 5},
+	}
+	ids := map[string]uuid.UUID{}
+	for _, d := range devices {
+		device := models.Device{DeviceID: uuid.New(), DeviceName: d.name, DeviceType: "server"}
+		if err := database.DB.Create(&device).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+		ids[d.name] = device.DeviceID
+
+		older := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: device.DeviceID,
+			Timestamp: base, CPUUsage: 99,
+		}
+		latest := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: device.DeviceID,
+			Timestamp: base.Add(30 * time.Minute), CPUUsage: d.latestCPU,
+		}
+		for _, m := range []models.DeviceMetric{older, latest} {
+			if err := database.DB.Create(&m).Error; err != nil {
+				t.Fatalf("failed to seed metric: %v", err)
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices/top?by=cpu&n=2", nil)
+
+	GetTopDevices(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var top []DeviceTopUsage
+	if err := json.Unmarshal(w.Body.Bytes(), &top); err != nil {
+		t.Fatalf("failed to unmarshal top devices: %v", err)
+	}
+	if len(top) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(top))
+	}
+	if top[0].DeviceID != ids["hot"] || top[1].DeviceID != ids["warm"] {
+		t.Errorf("unexpected ranking: got %s then %s", top[0].DeviceName, top[1].DeviceName)
+	}
+	if top[0].CPUUsage != 95 {
+		t.Errorf("expected latest CPU 95 for hot device, got %v (older sample leaked in)", top[0].CPUUsage)
+	}
+}
+
+func TestGetTopDevicesValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	for _, q := range []string{"?by=bogus", "?n=0", "?n=notanumber"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/devices/top"+q, nil)
+
+		GetTopDevices(c)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
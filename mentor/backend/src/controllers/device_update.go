@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DevicePatch carries the operator-editable device fields. Pointers
+// distinguish "not provided" from "set to empty".
+type DevicePatch struct {
+	DeviceName     *string `json:"device_name"`
+	DeviceType     *string `json:"device_type"`
+	OS             *string `json:"os"`
+	DeviceLocation *string `json:"device_location"`
+	IPAddress      *string `json:"ip_address"`
+	MacAddress     *string `json:"mac_address"`
+	CurrentUser    *string `json:"current_user"`
+	Version        int     `json:"version"`
+}
+
+// patchVersion resolves the expected version from the If-Match header
+// (preferred) or the request body. Zero means the caller sent none.
+func patchVersion(c *gin.Context, patch *DevicePatch) int {
+	if raw := strings.Trim(c.GetHeader("If-Match"), `"`); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil {
+			return v
+		}
+	}
+	return patch.Version
+}
+
+// PatchDevice updates operator-editable device fields with optimistic locking
+// @Summary Update device fields
+// @Description Update operator-editable device fields. Requires the device's current version via the If-Match header (or a version body field); a stale version returns 409 so concurrent edits cannot silently clobber each other.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param If-Match header string false "Expected device version"
+// @Param patch body controllers.DevicePatch true "Fields to update"
+// @Success 200 {object} models.Device
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON, device ID, or missing version"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 409 {object} map[string]string "Version conflict - device was modified concurrently"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id} [patch]
+func PatchDevice(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var patch DevicePatch
+	if err := c.BindJSON(&patch); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	version := patchVersion(c, &patch)
+	if version <= 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "expected version is required (If-Match header or version field)")
+		return
+	}
+
+	var device models.Device
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if patch.DeviceName != nil {
+		updates["device_name"] = *patch.DeviceName
+	}
+	if patch.DeviceType != nil {
+		if *patch.DeviceType != "" && !models.IsValidDeviceType(*patch.DeviceType) {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device type (must be: desktop, mobile, tablet, server, or iot)")
+			return
+		}
+		updates["device_type"] = *patch.DeviceType
+	}
+	if patch.OS != nil {
+		updates["os"] = *patch.OS
+	}
+	if patch.DeviceLocation != nil {
+		updates["device_location"] = *patch.DeviceLocation
+	}
+	if patch.IPAddress != nil {
+		updates["ip_address"] = *patch.IPAddress
+	}
+	if patch.MacAddress != nil {
+		updates["mac_address"] = *patch.MacAddress
+	}
+	if patch.CurrentUser != nil {
+		updates["current_user"] = *patch.CurrentUser
+	}
+	if len(updates) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "no updatable fields provided")
+		return
+	}
+	updates["version"] = gorm.Expr("version + 1")
+
+	// The version check and bump happen in one statement, so two concurrent
+	// editors can never both match the same version
+	result := database.DB.Model(&models.Device{}).
+		Where("deviceid = ? AND version = ?", deviceID, version).
+		Updates(updates)
+	if result.Error != nil {
+		RespondInternalError(c, result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":           "device was modified concurrently: re-fetch and retry with the current version",
+			"current_version": device.Version,
+		})
+		return
+	}
+
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&device).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, device)
+}
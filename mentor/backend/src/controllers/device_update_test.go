@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func patchDevice(t *testing.T, deviceID string, body interface{}, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID}}
+	b, _ := json.Marshal(body)
+	c.Request, _ = http.NewRequest("PATCH", "/devices/"+deviceID, bytes.NewReader(b))
+	c.Request.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		c.Request.Header.Set("If-Match", ifMatch)
+	}
+
+	PatchDevice(c)
+	return w
+}
+
+func TestPatchDeviceVersioned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "before-edit", Version: 1}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	w := patchDevice(t, device.DeviceID.String(), gin.H{"device_name": "after-edit"}, "1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var updated models.Device
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("failed to unmarshal device: %v", err)
+	}
+	if updated.DeviceName != "after-edit" {
+		t.Errorf("expected renamed device, got %q", updated.DeviceName)
+	}
+	if updated.Version != 2 {
+		t.Errorf("expected version bumped to 2, got %d", updated.Version)
+	}
+}
+
+func TestPatchDeviceVersionConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "contested", Version: 1}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	// First editor wins
+	if w := patchDevice(t, device.DeviceID.String(), gin.H{"device_name": "editor-one"}, "1"); w.Code != http.StatusOK {
+		t.Fatalf("expected first edit to succeed, got %d", w.Code)
+	}
+
+	// Second editor still holds version 1 and must be rejected
+	w := patchDevice(t, device.DeviceID.String(), gin.H{"device_name": "editor-two"}, "1")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for stale version, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var current models.Device
+	if err := database.DB.Where("deviceid = ?", device.DeviceID).First(&current).Error; err != nil {
+		t.Fatalf("failed to reload device: %v", err)
+	}
+	if current.DeviceName != "editor-one" {
+		t.Errorf("expected the first edit preserved, got %q", current.DeviceName)
+	}
+}
+
+func TestPatchDeviceRequiresVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "unversioned", Version: 1}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to seed device: %v", err)
+	}
+
+	w := patchDevice(t, device.DeviceID.String(), gin.H{"device_name": "nope"}, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without a version, got %d", w.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func registerDeviceBody(t *testing.T, device models.Device) (*httptest.ResponseRecorder, models.Device) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(device)
+	c.Request, _ = http.NewRequest("POST", "/devices/register", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	RegisterDevice(c)
+
+	var registered models.Device
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &registered); err != nil {
+			t.Fatalf("failed to unmarshal device: %v, body: %s", err, w.Body.String())
+		}
+	}
+	return w, registered
+}
+
+func TestRegisterDeviceDeterministicUUIDFromHardwareID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{
+		DeviceName: "hw-id-device",
+		DeviceType: "iot",
+		HardwareID: "serial-abc-123",
+	}
+
+	w1, first := registerDeviceBody(t, device)
+	if w1.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w1.Code, w1.Body.String())
+	}
+	if first.DeviceID == uuid.Nil {
+		t.Fatal("expected non-nil device UUID")
+	}
+
+	// Re-registering with the same hardware ID must yield the same device UUID
+	w2, second := registerDeviceBody(t, device)
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w2.Code, w2.Body.String())
+	}
+	if second.DeviceID != first.DeviceID {
+		t.Errorf("expected same UUID across registrations, got %s and %s", first.DeviceID, second.DeviceID)
+	}
+
+	// A different hardware ID must yield a different UUID
+	other := device
+	other.HardwareID = "serial-xyz-789"
+	w3, third := registerDeviceBody(t, other)
+	if w3.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w3.Code, w3.Body.String())
+	}
+	if third.DeviceID == first.DeviceID {
+		t.Errorf("expected different UUID for different hardware ID, got %s twice", first.DeviceID)
+	}
+}
+
+func TestDeviceUUIDNamespaceConfigurable(t *testing.T) {
+	t.Setenv("DEVICE_UUID_NAMESPACE", "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	custom := deviceUUIDNamespace()
+	if custom != uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8") {
+		t.Errorf("expected namespace from environment, got %s", custom)
+	}
+
+	t.Setenv("DEVICE_UUID_NAMESPACE", "not-a-uuid")
+	fallback := deviceUUIDNamespace()
+	if fallback != uuid.MustParse(DefaultDeviceUUIDNamespace) {
+		t.Errorf("expected default namespace for invalid env value, got %s", fallback)
+	}
+}
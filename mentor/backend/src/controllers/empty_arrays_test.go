@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TestListHandlersReturnEmptyArrays verifies every list endpoint serializes an
+// empty result set as [] rather than null, which breaks naive clients.
+func TestListHandlersReturnEmptyArrays(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New().String()
+
+	handlers := []struct {
+		name    string
+		path    string
+		handler gin.HandlerFunc
+	}{
+		{"ListDevices", "/devices", ListDevices},
+		{"GetDeviceMetric", "/devices/" + deviceID + "/metrics", GetDeviceMetric},
+		{"GetDeviceProcesses", "/devices/" + deviceID + "/processes", GetDeviceProcesses},
+		{"GetDeviceActivity", "/devices/" + deviceID + "/activities", GetDeviceActivity},
+		{"GetDeviceAlert", "/devices/" + deviceID + "/alerts", GetDeviceAlert},
+		{"GetDeviceScreenshot", "/devices/" + deviceID + "/screenshots", GetDeviceScreenshot},
+		{"GetPendingCommands", "/devices/" + deviceID + "/commands/pending", GetPendingCommands},
+		{"GetDeviceCommands", "/devices/" + deviceID + "/commands", GetDeviceCommands},
+		{"GetDeviceLogs", "/devices/" + deviceID + "/logs", GetDeviceLogs},
+		{"ListActivities", "/activities", ListActivities},
+	}
+
+	for _, tt := range handlers {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID}}
+			c.Request, _ = http.NewRequest("GET", tt.path, nil)
+
+			tt.handler(c)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+			}
+
+			body := strings.TrimSpace(w.Body.String())
+			if body == "null" {
+				t.Errorf("%s returned null instead of []", tt.name)
+			}
+			if !strings.HasPrefix(body, "[") {
+				t.Errorf("%s did not return a JSON array, body: %s", tt.name, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Machine-readable error codes carried in APIError.Code. Clients should
+// branch on these rather than parsing messages.
+const (
+	// ErrCodeValidation marks a request the client can fix: bad parameters,
+	// malformed bodies, out-of-range values.
+	ErrCodeValidation = "ERR_VALIDATION"
+	// ErrCodeNotFound marks a well-formed request for a resource that does
+	// not exist.
+	ErrCodeNotFound = "ERR_NOT_FOUND"
+	// ErrCodeInternal marks a server-side failure. The underlying error is
+	// logged, never returned, so driver details stay out of responses.
+	ErrCodeInternal = "ERR_INTERNAL"
+)
+
+// APIError is the structured error body returned by all handlers.
+type APIError struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	// Error mirrors Message under the legacy "error" key so clients written
+	// against the old gin.H{"error": ...} bodies keep working.
+	Error string `json:"error"`
+}
+
+// RespondError writes a structured error response and aborts the request.
+func RespondError(c *gin.Context, status int, code string, message string) {
+	c.AbortWithStatusJSON(status, APIError{Code: code, Message: message, Error: message})
+}
+
+// RespondInternalError maps a server-side failure to a generic ERR_INTERNAL
+// response. The underlying error is logged with the request path for
+// debugging but never sent to the client, since database and driver messages
+// can leak schema details.
+func RespondInternalError(c *gin.Context, err error) {
+	if c.Request != nil && c.Request.URL != nil {
+		fmt.Printf("Internal error on %s %s: %v\n", c.Request.Method, c.Request.URL.Path, err)
+	} else {
+		fmt.Printf("Internal error: %v\n", err)
+	}
+	RespondError(c, http.StatusInternalServerError, ErrCodeInternal, "internal server error")
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+	var body APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body.Code != ErrCodeValidation {
+		t.Errorf("expected code %q, got %q", ErrCodeValidation, body.Code)
+	}
+	if body.Message != "invalid limit parameter" || body.Error != body.Message {
+		t.Errorf("expected message mirrored under error key, got %+v", body)
+	}
+}
+
+func TestRespondInternalErrorHidesCause(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	RespondInternalError(c, errors.New(`pq: relation "devices" does not exist`))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", w.Code)
+	}
+	var body APIError
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body.Code != ErrCodeInternal {
+		t.Errorf("expected code %q, got %q", ErrCodeInternal, body.Code)
+	}
+	// The driver message must not reach the client.
+	if body.Message != "internal server error" || body.Error != "internal server error" {
+		t.Errorf("expected generic message, got %+v", body)
+	}
+}
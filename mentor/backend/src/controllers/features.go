@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Feature names that deployments can turn off via DISABLED_FEATURES
+// (comma-separated). Flags gate the risky, write-heavy parts of the API so a
+// locked-down deployment can shrink its surface without recompiling.
+const (
+	FeatureCommands    = "commands"
+	FeatureScreenshots = "screenshots"
+	FeatureExports     = "exports"
+	FeatureNotes       = "notes"
+)
+
+// knownFeatures lists every flag the API recognizes, in the order they are
+// reported by /admin/config.
+var knownFeatures = []string{
+	FeatureCommands,
+	FeatureScreenshots,
+	FeatureExports,
+	FeatureNotes,
+}
+
+// disabledFeatures parses DISABLED_FEATURES into a lookup set. Read per
+// request, like the rest of the runtime configuration, so tests and restarts
+// pick up changes without extra plumbing.
+func disabledFeatures() map[string]bool {
+	disabled := map[string]bool{}
+	for _, f := range strings.Split(os.Getenv("DISABLED_FEATURES"), ",") {
+		if trimmed := strings.ToLower(strings.TrimSpace(f)); trimmed != "" {
+			disabled[trimmed] = true
+		}
+	}
+	return disabled
+}
+
+// FeatureEnabled reports whether the named feature is enabled. Unknown names
+// are enabled by default so adding a flag is never a breaking change.
+func FeatureEnabled(name string) bool {
+	return !disabledFeatures()[strings.ToLower(name)]
+}
+
+// EnabledFeatures returns the known features that are currently enabled.
+func EnabledFeatures() []string {
+	enabled := make([]string, 0, len(knownFeatures))
+	for _, f := range knownFeatures {
+		if FeatureEnabled(f) {
+			enabled = append(enabled, f)
+		}
+	}
+	return enabled
+}
+
+// RequireFeature returns middleware that rejects requests while the named
+// feature is disabled. Disabled routes answer 404 so the locked-down surface
+// looks identical to a deployment that never had the endpoint.
+func RequireFeature(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !FeatureEnabled(name) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "feature disabled")
+			return
+		}
+		c.Next()
+	}
+}
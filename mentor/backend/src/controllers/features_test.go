@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireFeatureBlocksDisabledRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("DISABLED_FEATURES", "commands, screenshots")
+
+	engine := gin.New()
+	engine.POST("/devices/commands", RequireFeature(FeatureCommands), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"reached": true})
+	})
+	engine.GET("/devices", RequireFeature(FeatureNotes), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"reached": true})
+	})
+
+	// Disabled feature: the route behaves as if it does not exist
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/devices/commands", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for disabled feature, got %d", w.Code)
+	}
+
+	// Enabled feature: the request passes through to the handler
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/devices", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for enabled feature, got %d", w.Code)
+	}
+}
+
+func TestEnabledFeatures(t *testing.T) {
+	t.Setenv("DISABLED_FEATURES", "")
+	if got := EnabledFeatures(); len(got) != len(knownFeatures) {
+		t.Errorf("expected all features enabled by default, got %v", got)
+	}
+
+	t.Setenv("DISABLED_FEATURES", "Exports")
+	for _, f := range EnabledFeatures() {
+		if f == FeatureExports {
+			t.Errorf("expected exports to be disabled, got %v", EnabledFeatures())
+		}
+	}
+	if !FeatureEnabled("some-future-flag") {
+		t.Error("unknown feature names should default to enabled")
+	}
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestForwardingConfig probes the command-forwarding target
+// @Summary Test forwarding configuration
+// @Description Send a synthetic GET to the configured DEVICES_API_URL (or a caller-provided url) and report reachability, HTTP status, and latency. No command is created; this is a diagnostic for the forwarding pipeline.
+// @Tags admin
+// @Produce json
+// @Param url query string false "Target to probe instead of DEVICES_API_URL"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - no target configured or target not allowed"
+// @Router /admin/forward/test [post]
+func TestForwardingConfig(c *gin.Context) {
+	target := c.Query("url")
+	if target == "" {
+		target = os.Getenv("DEVICES_API_URL")
+	}
+	if target == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "no target: set DEVICES_API_URL or pass ?url=")
+		return
+	}
+	if !forwardTargetAllowed(target) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "target is not in FORWARD_URL_ALLOWLIST")
+		return
+	}
+
+	client := &http.Client{Timeout: commandForwardTimeout()}
+	start := time.Now()
+	resp, err := client.Get(target)
+	latency := time.Since(start)
+
+	result := gin.H{
+		"target":     target,
+		"latency_ms": latency.Milliseconds(),
+	}
+	if err != nil {
+		result["reachable"] = false
+		result["error"] = err.Error()
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("Error closing forward test response body: %v\n", err)
+		}
+	}()
+
+	result["reachable"] = true
+	result["status"] = resp.StatusCode
+	c.JSON(http.StatusOK, result)
+}
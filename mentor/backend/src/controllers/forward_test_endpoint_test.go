@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func testForwardConfig(t *testing.T, query string) map[string]interface{} {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/admin/forward/test"+query, nil)
+
+	TestForwardingConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return result
+}
+
+func TestForwardingConfigReachable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+	t.Setenv("DEVICES_API_URL", backend.URL)
+
+	result := testForwardConfig(t, "")
+	if result["reachable"] != true {
+		t.Errorf("expected reachable=true, got %v", result)
+	}
+	if result["status"] != float64(http.StatusOK) {
+		t.Errorf("expected status 200 from backend, got %v", result["status"])
+	}
+	if _, ok := result["latency_ms"]; !ok {
+		t.Error("expected latency_ms in response")
+	}
+}
+
+func TestForwardingConfigUnreachable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// A closed server: connection refused
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := backend.URL
+	backend.Close()
+	t.Setenv("DEVICES_API_URL", "")
+
+	result := testForwardConfig(t, "?url="+url)
+	if result["reachable"] != false {
+		t.Errorf("expected reachable=false, got %v", result)
+	}
+	if result["error"] == nil || result["error"] == "" {
+		t.Error("expected error detail in response")
+	}
+}
+
+func TestForwardingConfigValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("DEVICES_API_URL", "")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/admin/forward/test", nil)
+	TestForwardingConfig(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without a target, got %d", w.Code)
+	}
+
+	t.Setenv("FORWARD_URL_ALLOWLIST", "allowed.example.com")
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/admin/forward/test?url=http://evil.example.com", nil)
+	TestForwardingConfig(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for disallowed target, got %d", w.Code)
+	}
+}
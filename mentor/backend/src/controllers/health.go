@@ -58,6 +58,9 @@ func HealthCheckReady(c *gin.Context) {
 			"status": "unhealthy",
 			"error":  err.Error(),
 		}
+		// Screenshot reads keep serving metadata with cached URLs, but fresh
+		// presigned URLs cannot be generated while storage is down
+		health["degraded_features"] = []string{"screenshots"}
 		allHealthy = false
 	} else {
 		checks["minio"] = gin.H{
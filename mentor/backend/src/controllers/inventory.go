@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// UpdateDeviceInventory stores an inventory snapshot for a device
+// @Summary Report device inventory
+// @Description Replace the device's inventory for one category (e.g. software) with a new snapshot. One row is kept per device and category.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param inventory body models.DeviceInventory true "Inventory category and items"
+// @Success 200 {object} models.DeviceInventory
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/inventory [post]
+func UpdateDeviceInventory(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var inventory models.DeviceInventory
+	if err := c.BindJSON(&inventory); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	inventory.Category = strings.ToLower(strings.TrimSpace(inventory.Category))
+	if inventory.Category == "" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "category is required")
+		return
+	}
+
+	inventory.DeviceID = deviceID
+	inventory.UpdatedAt = time.Now()
+	if inventory.InventoryID == uuid.Nil {
+		inventory.InventoryID = uuid.New()
+	}
+
+	// Replace the previous snapshot for this device and category
+	if err := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "deviceid"}, {Name: "category"}},
+		DoUpdates: clause.AssignmentColumns([]string{"items", "updated_at"}),
+	}).Create(&inventory).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, inventory)
+}
+
+// InventoryReportEntry counts how many devices run one name/version pair.
+type InventoryReportEntry struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Devices int    `json:"devices"`
+}
+
+// GetInventoryReport aggregates inventory across the fleet
+// @Summary Fleet inventory report
+// @Description Aggregate the latest inventory snapshots of one category into a report of which versions run on how many devices. Essential for vulnerability sweeps. Optionally restricted to devices carrying a tag.
+// @Tags devices
+// @Produce json
+// @Param category query string false "Inventory category to aggregate" default(software)
+// @Param tag query string false "Only include devices carrying this tag"
+// @Success 200 {array} InventoryReportEntry
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /inventory/report [get]
+func GetInventoryReport(c *gin.Context) {
+	category := strings.ToLower(c.DefaultQuery("category", "software"))
+	tag := strings.TrimSpace(c.Query("tag"))
+
+	query := database.Read().Where("category = ?", category)
+	if tag != "" {
+		tagged, err := deviceIDsWithTag(tag)
+		if err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+		if len(tagged) == 0 {
+			c.JSON(http.StatusOK, []InventoryReportEntry{})
+			return
+		}
+		query = query.Where("deviceid IN ?", tagged)
+	}
+
+	var inventories []models.DeviceInventory
+	if err := query.Find(&inventories).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Count distinct devices per name/version pair
+	type versionKey struct{ name, version string }
+	counts := make(map[versionKey]map[uuid.UUID]bool)
+	for _, inventory := range inventories {
+		for _, item := range inventory.Items {
+			key := versionKey{item.Name, item.Version}
+			if counts[key] == nil {
+				counts[key] = make(map[uuid.UUID]bool)
+			}
+			counts[key][inventory.DeviceID] = true
+		}
+	}
+
+	report := make([]InventoryReportEntry, 0, len(counts))
+	for key, devices := range counts {
+		report = append(report, InventoryReportEntry{
+			Name:    key.name,
+			Version: key.version,
+			Devices: len(devices),
+		})
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Name != report[j].Name {
+			return report[i].Name < report[j].Name
+		}
+		return report[i].Version < report[j].Version
+	})
+
+	c.JSON(http.StatusOK, report)
+}
+
+// deviceIDsWithTag returns the IDs of devices carrying the given tag.
+func deviceIDsWithTag(tag string) ([]uuid.UUID, error) {
+	var devices []models.Device
+	if err := database.Read().Select("deviceid", "tags").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0)
+	for _, device := range devices {
+		for _, t := range device.Tags {
+			if strings.EqualFold(t, tag) {
+				ids = append(ids, device.DeviceID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
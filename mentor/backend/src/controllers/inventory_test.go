@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func reportInventory(t *testing.T, deviceID uuid.UUID, category string, items []models.InventoryItem) {
+	t.Helper()
+	body, err := json.Marshal(gin.H{"category": category, "items": items})
+	if err != nil {
+		t.Fatalf("failed to marshal inventory: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/inventory", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	UpdateDeviceInventory(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 reporting inventory, got %d, body: %s", w.Code, w.Body.String())
+	}
+}
+
+func getInventoryReport(t *testing.T, query string) []InventoryReportEntry {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/inventory/report"+query, nil)
+
+	GetInventoryReport(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var report []InventoryReportEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	return report
+}
+
+func TestGetInventoryReportAggregatesVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	d1, d2, d3 := uuid.New(), uuid.New(), uuid.New()
+	reportInventory(t, d1, "software", []models.InventoryItem{
+		{Name: "openssl", Version: "3.0.2"},
+		{Name: "nginx", Version: "1.24.0"},
+	})
+	reportInventory(t, d2, "software", []models.InventoryItem{
+		{Name: "openssl", Version: "3.0.2"},
+	})
+	reportInventory(t, d3, "software", []models.InventoryItem{
+		{Name: "openssl", Version: "1.1.1"},
+	})
+
+	report := getInventoryReport(t, "?category=software")
+	if len(report) != 3 {
+		t.Fatalf("expected 3 report entries, got %d: %+v", len(report), report)
+	}
+	// Sorted by name then version: nginx, openssl 1.1.1, openssl 3.0.2
+	if report[0].Name != "nginx" || report[0].Devices != 1 {
+		t.Errorf("unexpected first entry: %+v", report[0])
+	}
+	if report[1].Name != "openssl" || report[1].Version != "1.1.1" || report[1].Devices != 1 {
+		t.Errorf("unexpected second entry: %+v", report[1])
+	}
+	if report[2].Name != "openssl" || report[2].Version != "3.0.2" || report[2].Devices != 2 {
+		t.Errorf("unexpected third entry: %+v", report[2])
+	}
+}
+
+func TestGetInventoryReportTagFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	tagged := models.Device{DeviceID: uuid.New(), DeviceName: "prod-1", DeviceType: "server", Tags: []string{"prod"}}
+	plain := models.Device{DeviceID: uuid.New(), DeviceName: "dev-1", DeviceType: "server"}
+	for _, d := range []models.Device{tagged, plain} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+	reportInventory(t, tagged.DeviceID, "software", []models.InventoryItem{{Name: "openssl", Version: "3.0.2"}})
+	reportInventory(t, plain.DeviceID, "software", []models.InventoryItem{{Name: "openssl", Version: "3.0.2"}})
+
+	report := getInventoryReport(t, "?tag=prod")
+	if len(report) != 1 || report[0].Devices != 1 {
+		t.Errorf("expected tag filter to count only the tagged device, got %+v", report)
+	}
+
+	if report := getInventoryReport(t, "?tag=unknown"); len(report) != 0 {
+		t.Errorf("expected empty report for unknown tag, got %+v", report)
+	}
+}
+
+func TestUpdateDeviceInventoryReplacesSnapshot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	reportInventory(t, deviceID, "software", []models.InventoryItem{{Name: "openssl", Version: "1.1.1"}})
+	reportInventory(t, deviceID, "software", []models.InventoryItem{{Name: "openssl", Version: "3.0.2"}})
+
+	var rows []models.DeviceInventory
+	if err := database.DB.Where("deviceid = ?", deviceID).Find(&rows).Error; err != nil {
+		t.Fatalf("failed to list inventories: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected a single inventory row per category, got %d", len(rows))
+	}
+	if len(rows[0].Items) != 1 || rows[0].Items[0].Version != "3.0.2" {
+		t.Errorf("expected snapshot replaced with new version, got %+v", rows[0].Items)
+	}
+}
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// aggregateBuckets maps the accepted bucket sizes to their width. Kept small
+// on purpose: arbitrary widths invite expressions that cannot use date_trunc.
+var aggregateBuckets = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// MetricAggregateBucket is one downsampled point: the bucket start plus
+// avg/min/max of each charted metric within it.
+type MetricAggregateBucket struct {
+	BucketStart   time.Time `json:"bucket_start"`
+	Samples       int64     `json:"samples"`
+	AvgCPUUsage   float64   `json:"avg_cpu_usage"`
+	MinCPUUsage   float64   `json:"min_cpu_usage"`
+	MaxCPUUsage   float64   `json:"max_cpu_usage"`
+	AvgMemoryUsed float64   `json:"avg_memory_used"`
+	MinMemoryUsed uint64    `json:"min_memory_used"`
+	MaxMemoryUsed uint64    `json:"max_memory_used"`
+	AvgDiskUsed   float64   `json:"avg_disk_used"`
+	MinDiskUsed   uint64    `json:"min_disk_used"`
+	MaxDiskUsed   uint64    `json:"max_disk_used"`
+}
+
+// MaxAlignedBuckets caps how many wall-clock buckets an aligned request may
+// expand to, since empty buckets are materialized in the response.
+const MaxAlignedBuckets = 1000
+
+// AlignedMetricBucket is one wall-clock bucket in the aligned view. Stats is
+// null for buckets with no samples so charts render gaps instead of zeroes.
+type AlignedMetricBucket struct {
+	BucketStart time.Time              `json:"bucket_start"`
+	Stats       *MetricAggregateBucket `json:"stats"`
+}
+
+// alignBuckets snaps the aggregated buckets onto a continuous wall-clock
+// grid covering [from, to]. Boundaries come from truncating the range start,
+// not the first sample, so overlays of different devices line up.
+func alignBuckets(buckets []MetricAggregateBucket, width time.Duration, from, to time.Time) []AlignedMetricBucket {
+	byStart := make(map[int64]*MetricAggregateBucket, len(buckets))
+	for i := range buckets {
+		byStart[buckets[i].BucketStart.Unix()] = &buckets[i]
+	}
+
+	aligned := make([]AlignedMetricBucket, 0)
+	for ts := from.UTC().Truncate(width); !ts.After(to); ts = ts.Add(width) {
+		aligned = append(aligned, AlignedMetricBucket{
+			BucketStart: ts,
+			Stats:       byStart[ts.Unix()],
+		})
+	}
+	return aligned
+}
+
+// bucketExpression returns the SQL that truncates a timestamp to the bucket
+// start for the connected dialect: date_trunc (or an epoch floor for widths
+// date_trunc cannot express) on Postgres, strftime on SQLite.
+func bucketExpression(dialect, bucket string) string {
+	if dialect == "sqlite" {
+		formats := map[string]string{
+			"1m": "%Y-%m-%d %H:%M:00",
+			"1h": "%Y-%m-%d %H:00:00",
+			"1d": "%Y-%m-%d 00:00:00",
+		}
+		if format, ok := formats[bucket]; ok {
+			return fmt.Sprintf("strftime('%s', timestamp)", format)
+		}
+		// 5m: floor the unix epoch to a 300-second boundary
+		return "datetime((strftime('%s', timestamp) / 300) * 300, 'unixepoch')"
+	}
+
+	truncs := map[string]string{
+		"1m": "minute",
+		"1h": "hour",
+		"1d": "day",
+	}
+	if unit, ok := truncs[bucket]; ok {
+		return fmt.Sprintf("date_trunc('%s', timestamp)", unit)
+	}
+	return "to_timestamp(floor(extract(epoch from timestamp) / 300) * 300)"
+}
+
+// GetDeviceMetricAggregate returns downsampled metrics per time bucket
+// @Summary Get aggregated device metrics
+// @Description Downsample a device's metrics into fixed time buckets (1m, 5m, 1h, 1d), returning avg/min/max of CPU usage, memory used, and disk used per bucket. Sends a few hundred points to the browser instead of tens of thousands.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param bucket query string false "Bucket size: 1m, 5m, 1h, or 1d" default(1h)
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Param align query bool false "Snap buckets to wall-clock boundaries over [from, to], materializing empty buckets with null stats; requires from and to"
+// @Success 200 {array} controllers.MetricAggregateBucket
+// @Failure 400 {object} map[string]string "Bad request - unknown bucket or invalid range"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/metrics/aggregate [get]
+func GetDeviceMetricAggregate(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	bucket := c.DefaultQuery("bucket", "1h")
+	if _, ok := aggregateBuckets[bucket]; !ok {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid bucket parameter: must be one of 1m, 5m, 1h, 1d")
+		return
+	}
+
+	db := database.Read()
+	expr := bucketExpression(db.Dialector.Name(), bucket)
+	query := db.Model(&models.DeviceMetric{}).
+		Select(expr+" as bucket_start, COUNT(*) as samples, "+
+			"AVG(cpu_usage) as avg_cpu_usage, MIN(cpu_usage) as min_cpu_usage, MAX(cpu_usage) as max_cpu_usage, "+
+			"AVG(memory_used) as avg_memory_used, MIN(memory_used) as min_memory_used, MAX(memory_used) as max_memory_used, "+
+			"AVG(disk_used) as avg_disk_used, MIN(disk_used) as min_disk_used, MAX(disk_used) as max_disk_used").
+		Where("deviceid = ?", deviceID).
+		Group(expr).
+		Order("bucket_start asc")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		if fromTime, err = time.Parse(time.RFC3339, from); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		query = query.Where("timestamp >= ?", fromTime)
+	}
+	if to := c.Query("to"); to != "" {
+		if toTime, err = time.Parse(time.RFC3339, to); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		query = query.Where("timestamp <= ?", toTime)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	align := c.Query("align") == "true"
+	width := aggregateBuckets[bucket]
+	if align {
+		// The null-filled grid needs explicit bounds; without them the range
+		// to materialize is undefined.
+		if fromTime.IsZero() || toTime.IsZero() {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "align=true requires from and to")
+			return
+		}
+		if toTime.Sub(fromTime) >= time.Duration(MaxAlignedBuckets)*width {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "too many buckets: widen the bucket size or narrow the time range")
+			return
+		}
+	}
+
+	buckets := make([]MetricAggregateBucket, 0)
+	if err := query.Find(&buckets).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	if align {
+		c.JSON(http.StatusOK, alignBuckets(buckets, width, fromTime, toTime))
+		return
+	}
+
+	c.JSON(http.StatusOK, buckets)
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getMetricAggregate(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/aggregate"+query, nil)
+
+	GetDeviceMetricAggregate(c)
+	return w
+}
+
+func TestGetDeviceMetricAggregate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	hour := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	// Two points in one hour bucket, one in the next.
+	seed := []struct {
+		at  time.Time
+		cpu float64
+	}{
+		{hour.Add(5 * time.Minute), 10},
+		{hour.Add(25 * time.Minute), 30},
+		{hour.Add(65 * time.Minute), 50},
+	}
+	for _, s := range seed {
+		metric := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: s.at, CPUUsage: s.cpu, MemoryUsed: 1000, DiskUsed: 2000,
+		}
+		if err := database.DB.Create(&metric).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := getMetricAggregate(t, deviceID, "?bucket=1h")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var buckets []MetricAggregateBucket
+	if err := json.Unmarshal(w.Body.Bytes(), &buckets); err != nil {
+		t.Fatalf("failed to unmarshal buckets: %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 hourly buckets, got %d: %+v", len(buckets), buckets)
+	}
+	first := buckets[0]
+	if first.Samples != 2 || first.AvgCPUUsage != 20 || first.MinCPUUsage != 10 || first.MaxCPUUsage != 30 {
+		t.Errorf("unexpected first bucket aggregates: %+v", first)
+	}
+	if !first.BucketStart.Equal(hour) {
+		t.Errorf("expected bucket start %v, got %v", hour, first.BucketStart)
+	}
+	if first.AvgMemoryUsed != 1000 || first.MaxDiskUsed != 2000 {
+		t.Errorf("unexpected memory/disk aggregates: %+v", first)
+	}
+}
+
+func TestAlignBuckets(t *testing.T) {
+	from := time.Date(2026, 8, 25, 12, 2, 0, 0, time.UTC)
+	to := time.Date(2026, 8, 25, 12, 20, 0, 0, time.UTC)
+	buckets := []MetricAggregateBucket{
+		{BucketStart: time.Date(2026, 8, 25, 12, 5, 0, 0, time.UTC), Samples: 3, AvgCPUUsage: 40},
+		{BucketStart: time.Date(2026, 8, 25, 12, 15, 0, 0, time.UTC), Samples: 1, AvgCPUUsage: 80},
+	}
+
+	aligned := alignBuckets(buckets, 5*time.Minute, from, to)
+	if len(aligned) != 5 {
+		t.Fatalf("expected 5 wall-clock buckets from 12:00 to 12:20, got %d", len(aligned))
+	}
+	// Boundaries snap to :00/:05/... regardless of the 12:02 range start.
+	for i, bucket := range aligned {
+		want := time.Date(2026, 8, 25, 12, 5*i, 0, 0, time.UTC)
+		if !bucket.BucketStart.Equal(want) {
+			t.Errorf("bucket %d: expected start %v, got %v", i, want, bucket.BucketStart)
+		}
+	}
+	// 12:00, 12:10 and 12:20 had no samples and stay null.
+	for _, i := range []int{0, 2, 4} {
+		if aligned[i].Stats != nil {
+			t.Errorf("expected null stats for empty bucket %d, got %+v", i, aligned[i].Stats)
+		}
+	}
+	if aligned[1].Stats == nil || aligned[1].Stats.AvgCPUUsage != 40 {
+		t.Errorf("expected 12:05 stats carried over, got %+v", aligned[1].Stats)
+	}
+	if aligned[3].Stats == nil || aligned[3].Stats.Samples != 1 {
+		t.Errorf("expected 12:15 stats carried over, got %+v", aligned[3].Stats)
+	}
+}
+
+func TestGetDeviceMetricAggregateAligned(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	hour := time.Now().UTC().Truncate(time.Hour).Add(-3 * time.Hour)
+	// Samples in the first and third hours; the middle hour stays empty.
+	for _, at := range []time.Time{hour.Add(10 * time.Minute), hour.Add(130 * time.Minute)} {
+		metric := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: at, CPUUsage: 50}
+		if err := database.DB.Create(&metric).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	from := hour.Add(2 * time.Minute)
+	to := hour.Add(170 * time.Minute)
+	w := getMetricAggregate(t, deviceID,
+		"?bucket=1h&align=true&from="+from.Format(time.RFC3339)+"&to="+to.Format(time.RFC3339))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var aligned []AlignedMetricBucket
+	if err := json.Unmarshal(w.Body.Bytes(), &aligned); err != nil {
+		t.Fatalf("failed to unmarshal aligned buckets: %v", err)
+	}
+	if len(aligned) != 3 {
+		t.Fatalf("expected 3 aligned hourly buckets, got %d: %+v", len(aligned), aligned)
+	}
+	if !aligned[0].BucketStart.Equal(hour) {
+		t.Errorf("expected first boundary on the hour %v, got %v", hour, aligned[0].BucketStart)
+	}
+	if aligned[0].Stats == nil || aligned[2].Stats == nil {
+		t.Error("expected stats in the first and third buckets")
+	}
+	if aligned[1].Stats != nil {
+		t.Errorf("expected null stats for the empty middle hour, got %+v", aligned[1].Stats)
+	}
+
+	// align without bounds is rejected.
+	if w := getMetricAggregate(t, deviceID, "?bucket=1h&align=true"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for align without from/to, got %d", w.Code)
+	}
+}
+
+func TestGetDeviceMetricAggregateRejectsUnknownBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for _, q := range []string{"?bucket=7m", "?bucket=weekly"} {
+		if w := getMetricAggregate(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
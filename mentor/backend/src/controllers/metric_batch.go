@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// lastSeenDebounce returns the minimum interval between last-seen writes for a
+// device, from LAST_SEEN_DEBOUNCE_SECONDS. Zero (the default) writes on every
+// metric as before; busy fleets can raise it to cut write amplification on the
+// devices table.
+func lastSeenDebounce() time.Duration {
+	if raw := os.Getenv("LAST_SEEN_DEBOUNCE_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return 0
+}
+
+// lastSeenWrites tracks when each device's last-seen column was last written,
+// so the debounce can skip redundant updates within the interval.
+var (
+	lastSeenWritesMu sync.Mutex
+	lastSeenWrites   = map[uuid.UUID]time.Time{}
+)
+
+// shouldWriteLastSeen reports whether the device's last-seen column should be
+// written now, and records the write when it is due. Always true when the
+// debounce is disabled.
+func shouldWriteLastSeen(deviceID uuid.UUID) bool {
+	interval := lastSeenDebounce()
+	if interval == 0 {
+		return true
+	}
+
+	lastSeenWritesMu.Lock()
+	defer lastSeenWritesMu.Unlock()
+
+	if last, ok := lastSeenWrites[deviceID]; ok && time.Since(last) < interval {
+		return false
+	}
+	lastSeenWrites[deviceID] = time.Now()
+	return true
+}
+
+// BatchUpdateDeviceMetrics ingests a batch of metrics in one request
+// @Summary Ingest device metrics in batch
+// @Description Store multiple metric samples in a single insert. Samples keep their reported timestamps (defaulting to now), and each device's last-seen is written exactly once with the max timestamp in the batch instead of once per sample.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param metrics body []models.DeviceMetric true "Metric samples"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid or empty batch"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/metrics/batch [post]
+func BatchUpdateDeviceMetrics(c *gin.Context) {
+	var metrics []models.DeviceMetric
+	if err := c.BindJSON(&metrics); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if len(metrics) == 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "batch is empty")
+		return
+	}
+
+	now := time.Now()
+	maxSeen := map[uuid.UUID]time.Time{}
+	for i := range metrics {
+		if metrics[i].MetricID == uuid.Nil {
+			metrics[i].MetricID = uuid.New()
+		}
+		if metrics[i].Timestamp.IsZero() {
+			metrics[i].Timestamp = now
+		}
+		if metrics[i].Timestamp.After(maxSeen[metrics[i].DeviceID]) {
+			maxSeen[metrics[i].DeviceID] = metrics[i].Timestamp
+		}
+	}
+
+	if err := database.DB.Create(&metrics).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// One last-seen write per device with the newest timestamp in the batch,
+	// instead of one write per sample
+	for deviceID, seen := range maxSeen {
+		database.DB.Model(&models.Device{}).
+			Where("deviceid = ?", deviceID).
+			Updates(map[string]interface{}{
+				"last_seen": seen,
+				"is_online": true,
+			})
+
+		lastSeenWritesMu.Lock()
+		lastSeenWrites[deviceID] = time.Now()
+		lastSeenWritesMu.Unlock()
+	}
+
+	// Refresh each affected hourly rollup bucket once
+	buckets := map[uuid.UUID]map[time.Time]bool{}
+	for _, m := range metrics {
+		bucket := m.Timestamp.UTC().Truncate(time.Hour)
+		if buckets[m.DeviceID] == nil {
+			buckets[m.DeviceID] = map[time.Time]bool{}
+		}
+		if !buckets[m.DeviceID][bucket] {
+			buckets[m.DeviceID][bucket] = true
+			updateMetricRollup(m.DeviceID, m.Timestamp)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"inserted": len(metrics), "devices": len(maxSeen)})
+}
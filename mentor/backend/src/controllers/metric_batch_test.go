@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestBatchUpdateDeviceMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "batch-metrics", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newest := base.Add(30 * time.Minute)
+	batch := []models.DeviceMetric{
+		{DeviceID: device.DeviceID, Timestamp: base, CPUUsage: 10},
+		{DeviceID: device.DeviceID, Timestamp: newest, CPUUsage: 30},
+		{DeviceID: device.DeviceID, Timestamp: base.Add(10 * time.Minute), CPUUsage: 20},
+	}
+	body, _ := json.Marshal(batch)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/metrics/batch", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	BatchUpdateDeviceMetrics(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceMetric{}).
+		Where("deviceid = ?", device.DeviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 stored metrics, got %d", count)
+	}
+
+	// The device row carries the max timestamp from the batch, written once,
+	// not the timestamp of whichever sample happened to insert last
+	var updated models.Device
+	if err := database.DB.Where("deviceid = ?", device.DeviceID).First(&updated).Error; err != nil {
+		t.Fatalf("failed to reload device: %v", err)
+	}
+	if !updated.IsOnline {
+		t.Error("expected device to be marked online")
+	}
+	if diff := updated.LastSeen.Sub(newest); diff < -time.Second || diff > time.Second {
+		t.Errorf("expected last_seen near %v, got %v", newest, updated.LastSeen)
+	}
+
+	// An empty batch is rejected
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/metrics/batch", bytes.NewBufferString("[]"))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	BatchUpdateDeviceMetrics(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty batch, got %d", w.Code)
+	}
+}
+
+func TestLastSeenDebounce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("LAST_SEEN_DEBOUNCE_SECONDS", "60")
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "debounce", DeviceType: "server"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	submitMetric(t, models.DeviceMetric{DeviceID: device.DeviceID, CPUUsage: 10})
+	var afterFirst models.Device
+	if err := database.DB.Where("deviceid = ?", device.DeviceID).First(&afterFirst).Error; err != nil {
+		t.Fatalf("failed to reload device: %v", err)
+	}
+
+	// Within the debounce window the second metric must not touch the row
+	submitMetric(t, models.DeviceMetric{DeviceID: device.DeviceID, CPUUsage: 20})
+	var afterSecond models.Device
+	if err := database.DB.Where("deviceid = ?", device.DeviceID).First(&afterSecond).Error; err != nil {
+		t.Fatalf("failed to reload device: %v", err)
+	}
+	if !afterSecond.LastSeen.Equal(afterFirst.LastSeen) {
+		t.Errorf("expected last_seen unchanged within debounce window: %v vs %v",
+			afterFirst.LastSeen, afterSecond.LastSeen)
+	}
+
+	// Both metrics are still stored; only the device write is debounced
+	var count int64
+	if err := database.DB.Model(&models.DeviceMetric{}).
+		Where("deviceid = ?", device.DeviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 stored metrics, got %d", count)
+	}
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMetricBufferFlushInterval is how often the buffer drains when
+// METRIC_BUFFER_FLUSH_INTERVAL is not set.
+const DefaultMetricBufferFlushInterval = 5 * time.Second
+
+var (
+	metricBufferMu   sync.Mutex
+	metricBuffer     []models.DeviceMetric
+	metricBufferStop chan struct{}
+)
+
+// metricBufferSize returns the ingest batch size from METRIC_BUFFER_SIZE.
+// Zero (the default) disables buffering and metrics are written directly.
+func metricBufferSize() int {
+	if raw := os.Getenv("METRIC_BUFFER_SIZE"); raw != "" {
+		if size, err := strconv.Atoi(raw); err == nil && size > 0 {
+			return size
+		}
+	}
+	return 0
+}
+
+func metricBufferFlushInterval() time.Duration {
+	if raw := os.Getenv("METRIC_BUFFER_FLUSH_INTERVAL"); raw != "" {
+		if d, err := parseRangeDuration(raw); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultMetricBufferFlushInterval
+}
+
+// bufferMetric queues a metric for batched insertion. Returns false when
+// buffering is disabled, in which case the caller writes directly. A full
+// buffer is flushed inline so ingest backpressure is bounded by one batch.
+func bufferMetric(metric models.DeviceMetric) bool {
+	size := metricBufferSize()
+	if size <= 0 {
+		return false
+	}
+
+	metricBufferMu.Lock()
+	metricBuffer = append(metricBuffer, metric)
+	full := len(metricBuffer) >= size
+	metricBufferMu.Unlock()
+
+	if full {
+		if _, err := FlushMetricBuffer(); err != nil {
+			fmt.Printf("Error flushing metric buffer: %v\n", err)
+		}
+	}
+	return true
+}
+
+// MetricBufferDepth reports how many metrics are waiting to be flushed.
+func MetricBufferDepth() int {
+	metricBufferMu.Lock()
+	defer metricBufferMu.Unlock()
+	return len(metricBuffer)
+}
+
+// FlushMetricBuffer persists all buffered metrics in one batch insert and
+// updates the affected hourly rollups. Safe to call when the buffer is empty.
+func FlushMetricBuffer() (int, error) {
+	metricBufferMu.Lock()
+	batch := metricBuffer
+	metricBuffer = nil
+	metricBufferMu.Unlock()
+
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := database.DB.Create(&batch).Error; err != nil {
+		// Put the batch back so a transient database error does not drop data
+		metricBufferMu.Lock()
+		metricBuffer = append(batch, metricBuffer...)
+		metricBufferMu.Unlock()
+		return 0, err
+	}
+
+	// One rollup update per (device, hour) touched by the batch
+	type rollupKey struct {
+		deviceID uuid.UUID
+		bucket   time.Time
+	}
+	touched := make(map[rollupKey]bool)
+	for _, m := range batch {
+		touched[rollupKey{m.DeviceID, m.Timestamp.UTC().Truncate(time.Hour)}] = true
+	}
+	for key := range touched {
+		updateMetricRollup(key.deviceID, key.bucket)
+	}
+
+	return len(batch), nil
+}
+
+// StartMetricBuffer launches the periodic flush loop. A no-op when buffering
+// is disabled. Stop with ShutdownMetricBuffer.
+func StartMetricBuffer() {
+	if metricBufferSize() <= 0 {
+		return
+	}
+	metricBufferStop = make(chan struct{})
+	interval := metricBufferFlushInterval()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := FlushMetricBuffer(); err != nil {
+					fmt.Printf("Error flushing metric buffer: %v\n", err)
+				}
+			case <-metricBufferStop:
+				return
+			}
+		}
+	}()
+}
+
+// ShutdownMetricBuffer stops the flush loop and drains the buffer completely
+// so a graceful shutdown never loses accepted metrics.
+func ShutdownMetricBuffer() error {
+	if metricBufferStop != nil {
+		close(metricBufferStop)
+		metricBufferStop = nil
+	}
+	n, err := FlushMetricBuffer()
+	if err != nil {
+		return fmt.Errorf("failed to flush %d buffered metrics: %v", MetricBufferDepth(), err)
+	}
+	if n > 0 {
+		fmt.Printf("Flushed %d buffered metrics on shutdown\n", n)
+	}
+	return nil
+}
+
+// WriteMetricBufferGauge appends the buffer depth to the Prometheus scrape
+// output.
+func WriteMetricBufferGauge(w io.Writer) {
+	fmt.Fprintf(w, "# HELP mentor_metric_buffer_depth Metrics accepted but not yet written to the database.\n")
+	fmt.Fprintf(w, "# TYPE mentor_metric_buffer_depth gauge\n")
+	fmt.Fprintf(w, "mentor_metric_buffer_depth %d\n", MetricBufferDepth())
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// resetMetricBuffer empties the package-level buffer so tests stay isolated.
+func resetMetricBuffer(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		metricBufferMu.Lock()
+		metricBuffer = nil
+		metricBufferMu.Unlock()
+	})
+}
+
+func countMetrics(t *testing.T, deviceID uuid.UUID) int64 {
+	t.Helper()
+	var count int64
+	if err := database.DB.Model(&models.DeviceMetric{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	return count
+}
+
+func TestBufferedMetricsPersistOnShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_BUFFER_SIZE", "100")
+	resetMetricBuffer(t)
+
+	deviceID := uuid.New()
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, CPUUsage: 10})
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, CPUUsage: 20})
+
+	// Accepted but not yet written
+	if got := countMetrics(t, deviceID); got != 0 {
+		t.Fatalf("expected 0 persisted metrics before flush, got %d", got)
+	}
+	if depth := MetricBufferDepth(); depth != 2 {
+		t.Fatalf("expected buffer depth 2, got %d", depth)
+	}
+
+	if err := ShutdownMetricBuffer(); err != nil {
+		t.Fatalf("shutdown flush failed: %v", err)
+	}
+
+	if got := countMetrics(t, deviceID); got != 2 {
+		t.Errorf("expected 2 persisted metrics after shutdown, got %d", got)
+	}
+	if depth := MetricBufferDepth(); depth != 0 {
+		t.Errorf("expected empty buffer after shutdown, got depth %d", depth)
+	}
+}
+
+func TestMetricBufferFlushesWhenFull(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_BUFFER_SIZE", "2")
+	resetMetricBuffer(t)
+
+	deviceID := uuid.New()
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, CPUUsage: 10})
+	if got := countMetrics(t, deviceID); got != 0 {
+		t.Fatalf("expected first metric to stay buffered, found %d persisted", got)
+	}
+
+	// Second metric fills the batch and triggers an inline flush
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, CPUUsage: 20})
+	if got := countMetrics(t, deviceID); got != 2 {
+		t.Errorf("expected full buffer to flush inline, got %d persisted", got)
+	}
+	if depth := MetricBufferDepth(); depth != 0 {
+		t.Errorf("expected empty buffer after inline flush, got depth %d", depth)
+	}
+}
+
+func TestMetricBufferDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_BUFFER_SIZE", "")
+	resetMetricBuffer(t)
+
+	deviceID := uuid.New()
+	submitMetric(t, models.DeviceMetric{DeviceID: deviceID, CPUUsage: 10})
+	if got := countMetrics(t, deviceID); got != 1 {
+		t.Errorf("expected direct write with buffering off, got %d persisted", got)
+	}
+}
+
+func TestWriteMetricBufferGauge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_BUFFER_SIZE", "100")
+	resetMetricBuffer(t)
+
+	submitMetric(t, models.DeviceMetric{DeviceID: uuid.New(), CPUUsage: 10})
+
+	var sb strings.Builder
+	WriteMetricBufferGauge(&sb)
+	if !strings.Contains(sb.String(), "mentor_metric_buffer_depth 1") {
+		t.Errorf("expected gauge to report depth 1, got:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+// MetricExportRow is the flat schema shared by all metric export formats.
+// UUIDs are exported as strings and the timestamp as epoch milliseconds so
+// columnar tooling does not have to parse RFC3339.
+type MetricExportRow struct {
+	MetricID    string  `json:"metricid" parquet:"metricid"`
+	DeviceID    string  `json:"deviceid" parquet:"deviceid"`
+	TimestampMs int64   `json:"timestamp_ms" parquet:"timestamp_ms"`
+	CPUUsage    float64 `json:"cpu_usage" parquet:"cpu_usage"`
+	CPUTemp     float64 `json:"cpu_temp" parquet:"cpu_temp"`
+	MemoryTotal int64   `json:"memory_total" parquet:"memory_total"`
+	MemoryUsed  int64   `json:"memory_used" parquet:"memory_used"`
+	DiskTotal   int64   `json:"disk_total" parquet:"disk_total"`
+	DiskUsed    int64   `json:"disk_used" parquet:"disk_used"`
+	NetBytesIn  int64   `json:"net_bytes_in" parquet:"net_bytes_in"`
+	NetBytesOut int64   `json:"net_bytes_out" parquet:"net_bytes_out"`
+}
+
+func metricExportRow(m models.DeviceMetric) MetricExportRow {
+	return MetricExportRow{
+		MetricID:    m.MetricID.String(),
+		DeviceID:    m.DeviceID.String(),
+		TimestampMs: m.Timestamp.UnixMilli(),
+		CPUUsage:    m.CPUUsage,
+		CPUTemp:     m.CPUTemp,
+		MemoryTotal: int64(m.MemoryTotal),
+		MemoryUsed:  int64(m.MemoryUsed),
+		DiskTotal:   int64(m.DiskTotal),
+		DiskUsed:    int64(m.DiskUsed),
+		NetBytesIn:  int64(m.NetBytesIn),
+		NetBytesOut: int64(m.NetBytesOut),
+	}
+}
+
+// ExportDeviceMetrics streams a device's metric history in CSV, JSON, or Parquet
+// @Summary Export device metrics
+// @Description Stream the metric history of a device for analytics tooling. format selects csv (default), json, or parquet; Parquet is written columnar for data-science pipelines. Supports a time-range filter on the sample timestamp.
+// @Tags devices
+// @Produce text/csv,application/json,application/octet-stream
+// @Param id path string true "Device ID (UUID)"
+// @Param format query string false "Export format: csv (default), json, or parquet"
+// @Param from query string false "Start of time range (RFC3339)"
+// @Param to query string false "End of time range (RFC3339)"
+// @Success 200 {string} string "Streamed export"
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID, format, or time range"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/metrics/export [get]
+func ExportDeviceMetrics(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "json" && format != "parquet" {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid format: must be csv, json, or parquet")
+		return
+	}
+
+	query := database.Read().Model(&models.DeviceMetric{}).
+		Where("deviceid = ?", deviceID).
+		Order("timestamp asc")
+
+	var fromTime, toTime time.Time
+	if from := c.Query("from"); from != "" {
+		ts, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter: must be RFC3339")
+			return
+		}
+		fromTime = ts
+		query = query.Where("timestamp >= ?", ts)
+	}
+	if to := c.Query("to"); to != "" {
+		ts, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter: must be RFC3339")
+			return
+		}
+		toTime = ts
+		query = query.Where("timestamp <= ?", ts)
+	}
+	if err := checkQueryRange(fromTime, toTime, "MAX_QUERY_RANGE_EXPORT"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("Error closing metric export rows: %v\n", err)
+		}
+	}()
+
+	switch format {
+	case "csv":
+		exportMetricsCSV(c, deviceID, rows)
+	case "json":
+		exportMetricsJSON(c, deviceID, rows)
+	case "parquet":
+		exportMetricsParquet(c, deviceID, rows)
+	}
+}
+
+// metricCSVHeader is the column order shared by every CSV metric export.
+var metricCSVHeader = []string{"metricid", "deviceid", "timestamp", "cpu_usage", "cpu_temp",
+	"memory_total", "memory_used", "disk_total", "disk_used", "net_bytes_in", "net_bytes_out"}
+
+func exportMetricsCSV(c *gin.Context, deviceID uuid.UUID, rows *sql.Rows) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=metrics-%s.csv", deviceID))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	if err := writer.Write(metricCSVHeader); err != nil {
+		fmt.Printf("Error writing metric export header: %v\n", err)
+		return
+	}
+
+	for rows.Next() {
+		var m models.DeviceMetric
+		if err := database.DB.ScanRows(rows, &m); err != nil {
+			fmt.Printf("Error scanning metric export row: %v\n", err)
+			return
+		}
+		record := []string{
+			m.MetricID.String(),
+			m.DeviceID.String(),
+			m.Timestamp.Format(time.RFC3339),
+			strconv.FormatFloat(m.CPUUsage, 'f', -1, 64),
+			strconv.FormatFloat(m.CPUTemp, 'f', -1, 64),
+			strconv.FormatUint(m.MemoryTotal, 10),
+			strconv.FormatUint(m.MemoryUsed, 10),
+			strconv.FormatUint(m.DiskTotal, 10),
+			strconv.FormatUint(m.DiskUsed, 10),
+			strconv.FormatUint(m.NetBytesIn, 10),
+			strconv.FormatUint(m.NetBytesOut, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			fmt.Printf("Error writing metric export row: %v\n", err)
+			return
+		}
+		// Flush per row so large exports stream instead of buffering
+		writer.Flush()
+	}
+	writer.Flush()
+}
+
+func exportMetricsJSON(c *gin.Context, deviceID uuid.UUID, rows *sql.Rows) {
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=metrics-%s.json", deviceID))
+	c.Status(http.StatusOK)
+
+	// Stream an array element at a time instead of building it in memory
+	encoder := json.NewEncoder(c.Writer)
+	first := true
+	fmt.Fprint(c.Writer, "[")
+	for rows.Next() {
+		var m models.DeviceMetric
+		if err := database.DB.ScanRows(rows, &m); err != nil {
+			fmt.Printf("Error scanning metric export row: %v\n", err)
+			break
+		}
+		if !first {
+			fmt.Fprint(c.Writer, ",")
+		}
+		first = false
+		if err := encoder.Encode(metricExportRow(m)); err != nil {
+			fmt.Printf("Error writing metric export row: %v\n", err)
+			break
+		}
+	}
+	fmt.Fprint(c.Writer, "]")
+}
+
+func exportMetricsParquet(c *gin.Context, deviceID uuid.UUID, rows *sql.Rows) {
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=metrics-%s.parquet", deviceID))
+	c.Status(http.StatusOK)
+
+	writer := parquet.NewGenericWriter[MetricExportRow](c.Writer)
+	for rows.Next() {
+		var m models.DeviceMetric
+		if err := database.DB.ScanRows(rows, &m); err != nil {
+			fmt.Printf("Error scanning metric export row: %v\n", err)
+			return
+		}
+		if _, err := writer.Write([]MetricExportRow{metricExportRow(m)}); err != nil {
+			fmt.Printf("Error writing metric export row: %v\n", err)
+			return
+		}
+	}
+	if err := writer.Close(); err != nil {
+		fmt.Printf("Error finalizing parquet export: %v\n", err)
+	}
+}
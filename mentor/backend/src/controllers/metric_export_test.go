@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+)
+
+func exportMetrics(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/export"+query, nil)
+
+	ExportDeviceMetrics(c)
+	return w
+}
+
+func seedExportMetrics(t *testing.T, deviceID uuid.UUID, count int) []models.DeviceMetric {
+	t.Helper()
+	base := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	metrics := make([]models.DeviceMetric, 0, count)
+	for i := 0; i < count; i++ {
+		m := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			CPUUsage:  float64(10 + i), CPUTemp: 50,
+			MemoryTotal: 16384, MemoryUsed: uint64(4096 + i),
+			DiskTotal: 512000, DiskUsed: 256000,
+			NetBytesIn: uint64(1000 * i), NetBytesOut: uint64(500 * i),
+		}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func TestExportDeviceMetricsParquet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seeded := seedExportMetrics(t, deviceID, 5)
+
+	w := exportMetrics(t, deviceID, "?format=parquet")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/octet-stream" {
+		t.Errorf("expected octet-stream content type, got %q", ct)
+	}
+
+	rows, err := parquet.Read[MetricExportRow](bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to read parquet output: %v", err)
+	}
+	if len(rows) != len(seeded) {
+		t.Fatalf("expected %d parquet rows, got %d", len(seeded), len(rows))
+	}
+	for i, row := range rows {
+		want := metricExportRow(seeded[i])
+		if row != want {
+			t.Errorf("parquet row %d mismatch: got %+v, want %+v", i, row, want)
+		}
+	}
+}
+
+func TestExportDeviceMetricsCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seeded := seedExportMetrics(t, deviceID, 3)
+
+	w := exportMetrics(t, deviceID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("expected CSV content type, got %q", ct)
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != len(seeded)+1 {
+		t.Fatalf("expected header plus %d rows, got %d lines", len(seeded), len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "metricid,deviceid,timestamp") {
+		t.Errorf("unexpected CSV header: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], seeded[0].MetricID.String()) {
+		t.Errorf("expected first row to start with metric ID, got %q", lines[1])
+	}
+}
+
+func TestExportDeviceMetricsJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seeded := seedExportMetrics(t, deviceID, 3)
+
+	w := exportMetrics(t, deviceID, "?format=json")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var rows []MetricExportRow
+	if err := json.Unmarshal(w.Body.Bytes(), &rows); err != nil {
+		t.Fatalf("failed to unmarshal JSON export: %v", err)
+	}
+	if len(rows) != len(seeded) {
+		t.Fatalf("expected %d JSON rows, got %d", len(seeded), len(rows))
+	}
+	for i, row := range rows {
+		if want := metricExportRow(seeded[i]); row != want {
+			t.Errorf("JSON row %d mismatch: got %+v, want %+v", i, row, want)
+		}
+	}
+}
+
+func TestExportDeviceMetricsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	cases := []string{
+		"?format=xml",
+		"?from=bogus",
+		"?to=bogus",
+	}
+	for _, q := range cases {
+		if w := exportMetrics(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
+
+// TestExportDeviceMetricsLargeRange guards the cursor-based export path:
+// rows are scanned and written one at a time, so a large range must arrive
+// complete without the handler materializing the whole result set.
+func TestExportDeviceMetricsLargeRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	const count = 2500
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	metrics := make([]models.DeviceMetric, 0, count)
+	for i := 0; i < count; i++ {
+		metrics = append(metrics, models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			CPUUsage:  float64(i % 100),
+		})
+	}
+	if err := database.DB.CreateInBatches(metrics, 500).Error; err != nil {
+		t.Fatalf("failed to seed metrics: %v", err)
+	}
+
+	w := exportMetrics(t, deviceID, "?format=csv")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if got := len(lines) - 1; got != count { // minus header
+		t.Errorf("expected %d data rows in export, got %d", count, got)
+	}
+	// Oldest row first, newest last: completeness across the whole range.
+	if !strings.Contains(lines[1], base.UTC().Format("2006-01-02")) {
+		t.Errorf("unexpected first data row: %s", lines[1])
+	}
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeviceMetricCSVFormat(t *testing.T) {
+	router, cleanup := setupTestRouterWithDB(t)
+	defer cleanup()
+
+	device := models.Device{DeviceID: sampleUUID, DeviceName: "CSV Format Test", IsOnline: true}
+	database.DB.Create(&device)
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		metric := models.DeviceMetric{
+			DeviceID:  sampleUUID,
+			CPUUsage:  float64(20 + i),
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		}
+		database.DB.Create(&metric)
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/devices/%s/metrics?format=csv&limit=3", sampleUUID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	// Header plus one line per metric, capped by limit
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, strings.Join(metricCSVHeader, ","), strings.TrimSpace(lines[0]))
+
+	// Timestamps must round-trip as RFC3339
+	fields := strings.Split(lines[1], ",")
+	if assert.True(t, len(fields) >= 3) {
+		_, err := time.Parse(time.RFC3339, fields[2])
+		assert.NoError(t, err)
+	}
+}
+
+func TestGetDeviceMetricCSVTimeRange(t *testing.T) {
+	router, cleanup := setupTestRouterWithDB(t)
+	defer cleanup()
+
+	device := models.Device{DeviceID: sampleUUID, DeviceName: "CSV Range Test", IsOnline: true}
+	database.DB.Create(&device)
+
+	old := models.DeviceMetric{DeviceID: sampleUUID, CPUUsage: 10, Timestamp: time.Now().Add(-2 * time.Hour)}
+	recent := models.DeviceMetric{DeviceID: sampleUUID, CPUUsage: 90, Timestamp: time.Now().Add(-time.Minute)}
+	database.DB.Create(&old)
+	database.DB.Create(&recent)
+
+	from := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	req, _ := http.NewRequest("GET",
+		fmt.Sprintf("/devices/%s/metrics?format=csv&from=%s", sampleUUID, from), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	// Only the recent metric survives the from filter
+	assert.Equal(t, 2, len(lines))
+	assert.Contains(t, lines[1], recent.MetricID.String())
+}
+
+func TestGetDeviceMetricInvalidFormat(t *testing.T) {
+	router, cleanup := setupTestRouterWithDB(t)
+	defer cleanup()
+
+	req, _ := http.NewRequest("GET",
+		fmt.Sprintf("/devices/%s/metrics?format=xml", sampleUUID), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultGapWindow is the lookback scanned for gaps when no range is given
+	DefaultGapWindow = 24 * time.Hour
+	// DefaultGapFactor is how many expected intervals must pass between two
+	// consecutive samples before the silence counts as a gap
+	DefaultGapFactor = 2.0
+)
+
+// MetricGap is an interval in which a device reported no metrics even though
+// samples were expected.
+type MetricGap struct {
+	GapStart time.Time `json:"gap_start"`
+	GapEnd   time.Time `json:"gap_end"`
+	Duration string    `json:"duration"`
+}
+
+// GetMetricGaps reports intervals where a device stopped sending metrics
+// @Summary Detect gaps in a device's metric series
+// @Description Scan metric timestamps in the requested window and return every interval where the spacing between consecutive samples exceeds the expected interval times the gap factor. Surfaces silently failing agents.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param expected_interval query string true "Expected sample spacing, e.g. 1m or 30s"
+// @Param factor query number false "Gap threshold as a multiple of the expected interval (default 2)"
+// @Param from query string false "Window start (RFC3339, default 24h ago)"
+// @Param to query string false "Window end (RFC3339, default now)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/metrics/gaps [get]
+func GetMetricGaps(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	expected, err := parseRangeDuration(c.Query("expected_interval"))
+	if err != nil || expected <= 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "expected_interval is required, e.g. 1m or 30s")
+		return
+	}
+
+	factor := DefaultGapFactor
+	if raw := c.Query("factor"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 1 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid factor parameter; must be at least 1")
+			return
+		}
+		factor = parsed
+	}
+
+	to := time.Now()
+	from := to.Add(-DefaultGapWindow)
+	if raw := c.Query("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid from parameter; use RFC3339")
+			return
+		}
+	}
+	if raw := c.Query("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid to parameter; use RFC3339")
+			return
+		}
+	}
+	if !to.After(from) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "to must be after from")
+		return
+	}
+	if err := checkQueryRange(from, to, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	var timestamps []time.Time
+	if err := database.Read().Model(&models.DeviceMetric{}).
+		Where("deviceid = ? AND timestamp >= ? AND timestamp <= ?", deviceID, from, to).
+		Order("timestamp asc").
+		Pluck("timestamp", &timestamps).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	threshold := time.Duration(float64(expected) * factor)
+	gaps := make([]MetricGap, 0)
+	for i := 1; i < len(timestamps); i++ {
+		if spacing := timestamps[i].Sub(timestamps[i-1]); spacing > threshold {
+			gaps = append(gaps, MetricGap{
+				GapStart: timestamps[i-1],
+				GapEnd:   timestamps[i],
+				Duration: spacing.Round(time.Second).String(),
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deviceid": deviceID,
+		"samples":  len(timestamps),
+		"gaps":     gaps,
+	})
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetMetricGaps(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+
+	// One-minute cadence with a deliberate 10-minute hole after the third sample
+	offsets := []time.Duration{0, 1 * time.Minute, 2 * time.Minute, 12 * time.Minute, 13 * time.Minute}
+	for _, off := range offsets {
+		m := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: base.Add(off), CPUUsage: 10,
+		}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/gaps?expected_interval=1m", nil)
+
+	GetMetricGaps(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Samples int         `json:"samples"`
+		Gaps    []MetricGap `json:"gaps"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Samples != 5 {
+		t.Errorf("expected 5 samples scanned, got %d", resp.Samples)
+	}
+	if len(resp.Gaps) != 1 {
+		t.Fatalf("expected exactly 1 gap, got %d: %+v", len(resp.Gaps), resp.Gaps)
+	}
+	gap := resp.Gaps[0]
+	if !gap.GapStart.Equal(base.Add(2*time.Minute)) || !gap.GapEnd.Equal(base.Add(12*time.Minute)) {
+		t.Errorf("unexpected gap bounds: %v -> %v", gap.GapStart, gap.GapEnd)
+	}
+}
+
+func TestGetMetricGapsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	cases := []string{
+		"",                      // missing expected_interval
+		"?expected_interval=0s", // non-positive interval
+		"?expected_interval=1m&factor=0.5",
+		"?expected_interval=1m&from=bogus",
+		"?expected_interval=1m&from=2026-01-02T00:00:00Z&to=2026-01-01T00:00:00Z",
+	}
+	for _, q := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+		c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics/gaps"+q, nil)
+
+		GetMetricGaps(c)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
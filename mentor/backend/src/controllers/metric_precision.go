@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxMetricPrecision bounds the precision parameter; beyond this rounding is
+// indistinguishable from full precision anyway.
+const MaxMetricPrecision = 10
+
+// parsePrecisionParam reads the optional precision query parameter. Returns
+// -1 when absent, meaning full-precision output.
+func parsePrecisionParam(c *gin.Context) (int, error) {
+	raw := c.Query("precision")
+	if raw == "" {
+		return -1, nil
+	}
+	precision, err := strconv.Atoi(raw)
+	if err != nil || precision < 0 || precision > MaxMetricPrecision {
+		return 0, fmt.Errorf("invalid precision parameter: must be an integer between 0 and %d", MaxMetricPrecision)
+	}
+	return precision, nil
+}
+
+// roundTo rounds v to the given number of decimal places.
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// applyMetricPrecision rounds the float fields of each metric for output.
+// Only the response copy is touched; stored values stay full-precision.
+func applyMetricPrecision(metrics []models.DeviceMetric, decimals int) {
+	if decimals < 0 {
+		return
+	}
+	for i := range metrics {
+		metrics[i].CPUUsage = roundTo(metrics[i].CPUUsage, decimals)
+		metrics[i].CPUTemp = roundTo(metrics[i].CPUTemp, decimals)
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getMetricsWithQuery(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics"+query, nil)
+
+	GetDeviceMetric(c)
+	return w
+}
+
+func TestGetDeviceMetricPrecision(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	metric := models.DeviceMetric{
+		MetricID: uuid.New(), DeviceID: deviceID,
+		CPUUsage: 50.50000001, CPUTemp: 61.23456,
+	}
+	if err := database.DB.Create(&metric).Error; err != nil {
+		t.Fatalf("failed to seed metric: %v", err)
+	}
+
+	w := getMetricsWithQuery(t, deviceID, "?precision=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var rounded []models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &rounded); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(rounded) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(rounded))
+	}
+	if rounded[0].CPUUsage != 50.5 {
+		t.Errorf("expected cpu_usage 50.5, got %v", rounded[0].CPUUsage)
+	}
+	if rounded[0].CPUTemp != 61.23 {
+		t.Errorf("expected cpu_temp 61.23, got %v", rounded[0].CPUTemp)
+	}
+
+	// Storage keeps full precision
+	var stored models.DeviceMetric
+	if err := database.DB.Where("metricid = ?", metric.MetricID).First(&stored).Error; err != nil {
+		t.Fatalf("failed to load stored metric: %v", err)
+	}
+	if stored.CPUUsage != 50.50000001 {
+		t.Errorf("stored cpu_usage changed: %v", stored.CPUUsage)
+	}
+
+	// Without the parameter output stays full-precision
+	w = getMetricsWithQuery(t, deviceID, "")
+	var full []models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &full); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if full[0].CPUUsage != 50.50000001 {
+		t.Errorf("expected full-precision output by default, got %v", full[0].CPUUsage)
+	}
+}
+
+func TestGetDeviceMetricPrecisionValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for _, q := range []string{"?precision=-1", "?precision=99", "?precision=two"} {
+		if w := getMetricsWithQuery(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceMetricTimeRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	base := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		metric := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: base.Add(time.Duration(i) * time.Hour),
+			CPUUsage:  float64(i),
+		}
+		if err := database.DB.Create(&metric).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	decode := func(q string) []models.DeviceMetric {
+		w := getMetricsWithQuery(t, deviceID, q)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 for %q, got %d, body: %s", q, w.Code, w.Body.String())
+		}
+		var metrics []models.DeviceMetric
+		if err := json.Unmarshal(w.Body.Bytes(), &metrics); err != nil {
+			t.Fatalf("failed to unmarshal metrics: %v", err)
+		}
+		return metrics
+	}
+	rfc := func(ts time.Time) string { return url.QueryEscape(ts.Format(time.RFC3339)) }
+
+	// Both bounds: only the middle point falls inside.
+	mid := base.Add(time.Hour)
+	got := decode("?from=" + rfc(mid.Add(-time.Minute)) + "&to=" + rfc(mid.Add(time.Minute)))
+	if len(got) != 1 || got[0].CPUUsage != 1 {
+		t.Errorf("expected only the middle metric in range, got %+v", got)
+	}
+
+	// Only from: everything at or after it.
+	if got = decode("?from=" + rfc(mid)); len(got) != 2 {
+		t.Errorf("expected 2 metrics after from, got %d", len(got))
+	}
+
+	// Only to: everything at or before it.
+	if got = decode("?to=" + rfc(mid)); len(got) != 2 {
+		t.Errorf("expected 2 metrics before to, got %d", len(got))
+	}
+
+	// Limit still caps the filtered result.
+	if got = decode("?from=" + rfc(base) + "&limit=1"); len(got) != 1 {
+		t.Errorf("expected limit to cap ranged query, got %d", len(got))
+	}
+}
+
+func TestGetDeviceMetricTimeRangeValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for _, q := range []string{"?from=yesterday", "?to=13:00"} {
+		if w := getMetricsWithQuery(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
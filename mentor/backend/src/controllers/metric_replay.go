@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MaxReplayRows caps how many synthetic metrics one replay may generate, so
+// a careless spec cannot fill the metrics table.
+const MaxReplayRows = 10000
+
+// testEndpointsEnabled reports whether test-only endpoints like metric replay
+// are active (TEST_ENDPOINTS_ENABLED env). Off by default; never enable in
+// production, these endpoints write synthetic data.
+func testEndpointsEnabled() bool {
+	v := strings.ToLower(os.Getenv("TEST_ENDPOINTS_ENABLED"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
+// MetricReplayRequest is a compact spec for a synthetic metric series: one
+// sample per interval between from and to, with value lists cycled across
+// the generated timestamps. A single-element list yields a flat series; a
+// longer list yields a repeating pattern.
+type MetricReplayRequest struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	IntervalSeconds int       `json:"interval_seconds"`
+	CPUUsage        []float64 `json:"cpu_usage"`
+	CPUTemp         []float64 `json:"cpu_temp"`
+	MemoryUsed      []uint64  `json:"memory_used"`
+	DiskUsed        []uint64  `json:"disk_used"`
+}
+
+// buildReplayMetrics expands a replay spec into the metric rows it describes.
+// Kept separate from the handler so the generation logic is testable without
+// HTTP plumbing.
+func buildReplayMetrics(deviceID uuid.UUID, spec MetricReplayRequest) []models.DeviceMetric {
+	metrics := make([]models.DeviceMetric, 0)
+	interval := time.Duration(spec.IntervalSeconds) * time.Second
+	for i, ts := 0, spec.From; !ts.After(spec.To) && i < MaxReplayRows; i, ts = i+1, ts.Add(interval) {
+		metric := models.DeviceMetric{
+			MetricID:  uuid.New(),
+			DeviceID:  deviceID,
+			Timestamp: ts,
+		}
+		if len(spec.CPUUsage) > 0 {
+			metric.CPUUsage = spec.CPUUsage[i%len(spec.CPUUsage)]
+		}
+		if len(spec.CPUTemp) > 0 {
+			metric.CPUTemp = spec.CPUTemp[i%len(spec.CPUTemp)]
+		}
+		if len(spec.MemoryUsed) > 0 {
+			metric.MemoryUsed = spec.MemoryUsed[i%len(spec.MemoryUsed)]
+		}
+		if len(spec.DiskUsed) > 0 {
+			metric.DiskUsed = spec.DiskUsed[i%len(spec.DiskUsed)]
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// ReplayDeviceMetrics generates a synthetic metric series for a device
+// @Summary Replay a synthetic metric series (test only)
+// @Description Expand a compact series spec into stored metrics for the device, so a problematic chart can be reproduced deterministically. Only active when TEST_ENDPOINTS_ENABLED is set; returns 404 otherwise so production deployments do not advertise it.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param spec body controllers.MetricReplayRequest true "Series specification"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "Bad request - invalid spec"
+// @Failure 404 {object} map[string]string "Test endpoints disabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/metrics/replay [post]
+func ReplayDeviceMetrics(c *gin.Context) {
+	if !testEndpointsEnabled() {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "not found")
+		return
+	}
+
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var spec MetricReplayRequest
+	if err := c.BindJSON(&spec); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+	if spec.From.IsZero() || spec.To.IsZero() || !spec.To.After(spec.From) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "to must be after from")
+		return
+	}
+	if spec.IntervalSeconds <= 0 {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "interval_seconds must be a positive integer")
+		return
+	}
+	if int64(spec.To.Sub(spec.From).Seconds())/int64(spec.IntervalSeconds) >= MaxReplayRows {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "spec expands past the replay row cap: widen the interval or narrow the range")
+		return
+	}
+
+	metrics := buildReplayMetrics(deviceID, spec)
+	if len(metrics) > 0 {
+		if err := database.DB.CreateInBatches(metrics, 500).Error; err != nil {
+			RespondInternalError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"generated": len(metrics)})
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func replayMetrics(t *testing.T, deviceID string, spec MetricReplayRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("failed to marshal spec: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID+"/metrics/replay", bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	ReplayDeviceMetrics(c)
+	return w
+}
+
+func TestBuildReplayMetricsCyclesValues(t *testing.T) {
+	deviceID := uuid.New()
+	from := time.Date(2026, 8, 25, 12, 0, 0, 0, time.UTC)
+	spec := MetricReplayRequest{
+		From:            from,
+		To:              from.Add(4 * time.Minute),
+		IntervalSeconds: 60,
+		CPUUsage:        []float64{10, 90},
+	}
+
+	metrics := buildReplayMetrics(deviceID, spec)
+	if len(metrics) != 5 {
+		t.Fatalf("expected 5 samples (inclusive range), got %d", len(metrics))
+	}
+	for i, metric := range metrics {
+		wantTS := from.Add(time.Duration(i) * time.Minute)
+		if !metric.Timestamp.Equal(wantTS) {
+			t.Errorf("sample %d: expected timestamp %v, got %v", i, wantTS, metric.Timestamp)
+		}
+		wantCPU := []float64{10, 90}[i%2]
+		if metric.CPUUsage != wantCPU {
+			t.Errorf("sample %d: expected CPU %v, got %v", i, wantCPU, metric.CPUUsage)
+		}
+		if metric.DeviceID != deviceID {
+			t.Errorf("sample %d: wrong device %v", i, metric.DeviceID)
+		}
+	}
+}
+
+func TestReplayDeviceMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("TEST_ENDPOINTS_ENABLED", "true")
+
+	deviceID := uuid.New()
+	from := time.Date(2026, 8, 25, 0, 0, 0, 0, time.UTC)
+	spec := MetricReplayRequest{
+		From:            from,
+		To:              from.Add(9 * time.Minute),
+		IntervalSeconds: 60,
+		CPUUsage:        []float64{25},
+		MemoryUsed:      []uint64{1024, 2048},
+	}
+
+	if w := replayMetrics(t, deviceID.String(), spec); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stored []models.DeviceMetric
+	if err := database.DB.Where("deviceid = ?", deviceID).Order("timestamp asc").Find(&stored).Error; err != nil {
+		t.Fatalf("failed to load metrics: %v", err)
+	}
+	if len(stored) != 10 {
+		t.Fatalf("expected 10 generated metrics, got %d", len(stored))
+	}
+	for i, metric := range stored {
+		if metric.CPUUsage != 25 {
+			t.Errorf("sample %d: expected flat CPU 25, got %v", i, metric.CPUUsage)
+		}
+		if want := []uint64{1024, 2048}[i%2]; metric.MemoryUsed != want {
+			t.Errorf("sample %d: expected memory %d, got %d", i, want, metric.MemoryUsed)
+		}
+	}
+}
+
+func TestReplayDeviceMetricsGuards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	from := time.Date(2026, 8, 25, 0, 0, 0, 0, time.UTC)
+	valid := MetricReplayRequest{From: from, To: from.Add(time.Minute), IntervalSeconds: 60}
+
+	// Disabled by default: hidden behind a 404.
+	if w := replayMetrics(t, uuid.New().String(), valid); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 when disabled, got %d", w.Code)
+	}
+
+	t.Setenv("TEST_ENDPOINTS_ENABLED", "true")
+	if w := replayMetrics(t, "not-a-uuid", valid); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for bad device ID, got %d", w.Code)
+	}
+	if w := replayMetrics(t, uuid.New().String(), MetricReplayRequest{From: from, To: from, IntervalSeconds: 60}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for empty range, got %d", w.Code)
+	}
+	huge := MetricReplayRequest{From: from, To: from.Add(365 * 24 * time.Hour), IntervalSeconds: 1}
+	if w := replayMetrics(t, uuid.New().String(), huge); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 past the row cap, got %d", w.Code)
+	}
+}
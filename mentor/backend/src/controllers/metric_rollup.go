@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultMetricRollupCrossover is the window size beyond which metric
+// summaries read the hourly rollup table instead of raw metrics.
+const DefaultMetricRollupCrossover = 48 * time.Hour
+
+// metricRollupCrossover returns the rollup crossover window, overridable via
+// METRIC_ROLLUP_CROSSOVER_HOURS.
+func metricRollupCrossover() time.Duration {
+	if raw := os.Getenv("METRIC_ROLLUP_CROSSOVER_HOURS"); raw != "" {
+		if hours, err := strconv.Atoi(raw); err == nil && hours > 0 {
+			return time.Duration(hours) * time.Hour
+		}
+	}
+	return DefaultMetricRollupCrossover
+}
+
+// rollupFields is the subset of metric fields pre-aggregated into the hourly
+// rollup table. Other fields always read raw metrics.
+var rollupFields = map[string]bool{
+	"cpu_usage":   true,
+	"cpu_temp":    true,
+	"memory_used": true,
+	"disk_used":   true,
+}
+
+// updateMetricRollup recomputes the hourly rollup bucket containing ts for a
+// device. Called on metric ingest; recomputing the whole bucket keeps the
+// rollup correct under out-of-order or duplicate submissions. Failures are
+// logged but never fail the ingest path.
+func updateMetricRollup(deviceID uuid.UUID, ts time.Time) {
+	bucket := ts.UTC().Truncate(time.Hour)
+
+	var agg models.DeviceMetricRollup
+	if err := database.DB.Model(&models.DeviceMetric{}).
+		Select("COUNT(*) AS samples, "+
+			"MIN(cpu_usage) AS min_cpu_usage, MAX(cpu_usage) AS max_cpu_usage, AVG(cpu_usage) AS avg_cpu_usage, "+
+			"MIN(cpu_temp) AS min_cpu_temp, MAX(cpu_temp) AS max_cpu_temp, AVG(cpu_temp) AS avg_cpu_temp, "+
+			"MIN(memory_used) AS min_memory_used, MAX(memory_used) AS max_memory_used, AVG(memory_used) AS avg_memory_used, "+
+			"MIN(disk_used) AS min_disk_used, MAX(disk_used) AS max_disk_used, AVG(disk_used) AS avg_disk_used").
+		Where("deviceid = ? AND timestamp >= ? AND timestamp < ?", deviceID, bucket, bucket.Add(time.Hour)).
+		Scan(&agg).Error; err != nil {
+		fmt.Printf("Error aggregating metric rollup for %s: %v\n", deviceID, err)
+		return
+	}
+	if agg.Samples == 0 {
+		return
+	}
+
+	agg.DeviceID = deviceID
+	agg.BucketStart = bucket
+
+	// Upsert keyed by device and bucket so repeated ingests update the same row
+	var existing models.DeviceMetricRollup
+	result := database.DB.Where("deviceid = ? AND bucket_start = ?", deviceID, bucket).First(&existing)
+	switch {
+	case result.Error == nil:
+		agg.RollupID = existing.RollupID
+		if err := database.DB.Save(&agg).Error; err != nil {
+			fmt.Printf("Error updating metric rollup for %s: %v\n", deviceID, err)
+		}
+	case errors.Is(result.Error, gorm.ErrRecordNotFound):
+		agg.RollupID = uuid.New()
+		if err := database.DB.Create(&agg).Error; err != nil {
+			fmt.Printf("Error creating metric rollup for %s: %v\n", deviceID, err)
+		}
+	default:
+		fmt.Printf("Error loading metric rollup for %s: %v\n", deviceID, result.Error)
+	}
+}
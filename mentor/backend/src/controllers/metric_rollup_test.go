@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getMetricStats(t *testing.T, deviceID, query string) MetricFieldStats {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID+"/metrics/stats"+query, nil)
+
+	GetDeviceMetricStats(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var stats MetricFieldStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	return stats
+}
+
+func TestUpdateMetricRollup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now().UTC()
+
+	values := []float64{10, 30, 50}
+	for _, v := range values {
+		m := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now, CPUUsage: v}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	updateMetricRollup(deviceID, now)
+
+	var rollup models.DeviceMetricRollup
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&rollup).Error; err != nil {
+		t.Fatalf("expected a rollup row: %v", err)
+	}
+	if rollup.Samples != 3 {
+		t.Errorf("expected 3 samples, got %d", rollup.Samples)
+	}
+	if rollup.MinCPUUsage != 10 || rollup.MaxCPUUsage != 50 || rollup.AvgCPUUsage != 30 {
+		t.Errorf("unexpected rollup aggregates: %+v", rollup)
+	}
+	if !rollup.BucketStart.Equal(now.Truncate(time.Hour)) {
+		t.Errorf("expected hour-aligned bucket, got %v", rollup.BucketStart)
+	}
+
+	// Re-ingesting recomputes the same bucket rather than creating a new row
+	m := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now, CPUUsage: 90}
+	if err := database.DB.Create(&m).Error; err != nil {
+		t.Fatalf("failed to seed metric: %v", err)
+	}
+	updateMetricRollup(deviceID, now)
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceMetricRollup{}).
+		Where("deviceid = ?", deviceID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count rollups: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected a single rollup row per bucket, got %d", count)
+	}
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&rollup).Error; err != nil {
+		t.Fatalf("failed to reload rollup: %v", err)
+	}
+	if rollup.Samples != 4 || rollup.MaxCPUUsage != 90 {
+		t.Errorf("expected recomputed bucket with 4 samples and max 90, got %+v", rollup)
+	}
+}
+
+func TestMetricStatsRollupAndRawAgree(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now().UTC()
+
+	// Spread samples across two hour buckets within the window
+	for i, v := range []float64{20, 40, 60, 80} {
+		ts := now.Add(-time.Duration(i*40) * time.Minute)
+		m := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: ts, CPUUsage: v}
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+		updateMetricRollup(deviceID, ts)
+	}
+
+	raw := getMetricStats(t, deviceID.String(), "?field=cpu_usage&last=24h")
+
+	// Force the rollup path by lowering the crossover below the window
+	t.Setenv("METRIC_ROLLUP_CROSSOVER_HOURS", "1")
+	rolled := getMetricStats(t, deviceID.String(), "?field=cpu_usage&last=24h")
+
+	if raw.Samples != rolled.Samples {
+		t.Errorf("sample counts disagree: raw=%d rollup=%d", raw.Samples, rolled.Samples)
+	}
+	if raw.Min == nil || rolled.Min == nil || *raw.Min != *rolled.Min {
+		t.Errorf("min disagrees: raw=%v rollup=%v", raw.Min, rolled.Min)
+	}
+	if raw.Max == nil || rolled.Max == nil || *raw.Max != *rolled.Max {
+		t.Errorf("max disagrees: raw=%v rollup=%v", raw.Max, rolled.Max)
+	}
+	if raw.Avg == nil || rolled.Avg == nil || math.Abs(*raw.Avg-*rolled.Avg) > 1e-9 {
+		t.Errorf("avg disagrees: raw=%v rollup=%v", raw.Avg, rolled.Avg)
+	}
+}
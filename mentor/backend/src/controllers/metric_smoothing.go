@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxSmoothingWindow bounds the smooth parameter; larger windows flatten the
+// series into a near-constant line and cost memory per request.
+const MaxSmoothingWindow = 100
+
+// parseSmoothParam reads the optional smooth query parameter. Returns 0 when
+// absent, meaning raw output.
+func parseSmoothParam(c *gin.Context) (int, error) {
+	raw := c.Query("smooth")
+	if raw == "" {
+		return 0, nil
+	}
+	window, err := strconv.Atoi(raw)
+	if err != nil || window < 2 || window > MaxSmoothingWindow {
+		return 0, fmt.Errorf("invalid smooth parameter: must be an integer between 2 and %d", MaxSmoothingWindow)
+	}
+	return window, nil
+}
+
+// applyMetricSmoothing replaces each metric's float fields with a trailing
+// moving average over the given window, computed in time order. The slice is
+// expected newest-first, as GetDeviceMetric returns it; points near the start
+// of the series average over however many earlier points exist. Only the
+// response copy is touched; stored values stay raw.
+func applyMetricSmoothing(metrics []models.DeviceMetric, window int) {
+	if window < 2 || len(metrics) == 0 {
+		return
+	}
+
+	// Snapshot the raw values so each average reads originals, not already
+	// smoothed neighbours. With metrics newest-first, the trailing window of
+	// point i is the points at indices i..i+window-1.
+	rawCPU := make([]float64, len(metrics))
+	rawTemp := make([]float64, len(metrics))
+	for i := range metrics {
+		rawCPU[i] = metrics[i].CPUUsage
+		rawTemp[i] = metrics[i].CPUTemp
+	}
+
+	for i := range metrics {
+		end := i + window
+		if end > len(metrics) {
+			end = len(metrics)
+		}
+		var cpu, temp float64
+		for j := i; j < end; j++ {
+			cpu += rawCPU[j]
+			temp += rawTemp[j]
+		}
+		n := float64(end - i)
+		metrics[i].CPUUsage = cpu / n
+		metrics[i].CPUTemp = temp / n
+	}
+}
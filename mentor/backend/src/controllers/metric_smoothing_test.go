@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestApplyMetricSmoothing(t *testing.T) {
+	// Newest-first series, raw CPU values 40, 30, 20, 10 (oldest last).
+	metrics := []models.DeviceMetric{
+		{CPUUsage: 40}, {CPUUsage: 30}, {CPUUsage: 20}, {CPUUsage: 10},
+	}
+	applyMetricSmoothing(metrics, 2)
+
+	// Each point averages itself with the previous (older) point; the oldest
+	// has no predecessor and keeps its raw value.
+	expected := []float64{35, 25, 15, 10}
+	for i, want := range expected {
+		if metrics[i].CPUUsage != want {
+			t.Errorf("point %d: expected smoothed cpu_usage %v, got %v", i, want, metrics[i].CPUUsage)
+		}
+	}
+}
+
+func TestGetDeviceMetricSmoothing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	base := time.Now().Add(-time.Hour)
+	raw := []float64{10, 20, 60}
+	for i, cpu := range raw {
+		metric := models.DeviceMetric{
+			MetricID: uuid.New(), DeviceID: deviceID,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+			CPUUsage:  cpu,
+		}
+		if err := database.DB.Create(&metric).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := getMetricsWithQuery(t, deviceID, "?smooth=3")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var smoothed []models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &smoothed); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(smoothed) != 3 {
+		t.Fatalf("expected 3 metrics, got %d", len(smoothed))
+	}
+	// Newest-first: the newest point averages all three raw values.
+	if math.Abs(smoothed[0].CPUUsage-30) > 1e-9 {
+		t.Errorf("expected newest point smoothed to 30, got %v", smoothed[0].CPUUsage)
+	}
+	if math.Abs(smoothed[2].CPUUsage-10) > 1e-9 {
+		t.Errorf("expected oldest point unchanged at 10, got %v", smoothed[2].CPUUsage)
+	}
+
+	// Without the parameter the series comes back raw.
+	w = getMetricsWithQuery(t, deviceID, "")
+	var unsmoothed []models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &unsmoothed); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if unsmoothed[0].CPUUsage != 60 {
+		t.Errorf("expected raw output by default, got %v", unsmoothed[0].CPUUsage)
+	}
+}
+
+func TestGetDeviceMetricSmoothingValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for _, q := range []string{"?smooth=1", "?smooth=0", "?smooth=999", "?smooth=five"} {
+		if w := getMetricsWithQuery(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
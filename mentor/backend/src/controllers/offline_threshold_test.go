@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestDeviceOfflineThresholdEnv(t *testing.T) {
+	if got := deviceOfflineThreshold(); got != DefaultDeviceOfflineThreshold {
+		t.Errorf("expected default threshold %v, got %v", DefaultDeviceOfflineThreshold, got)
+	}
+
+	t.Setenv("OFFLINE_THRESHOLD_SECONDS", "600")
+	if got := deviceOfflineThreshold(); got != 10*time.Minute {
+		t.Errorf("expected 10m threshold, got %v", got)
+	}
+
+	// Garbage values warn and fall back to the default instead of failing.
+	t.Setenv("OFFLINE_THRESHOLD_SECONDS", "soon")
+	if got := deviceOfflineThreshold(); got != DefaultDeviceOfflineThreshold {
+		t.Errorf("expected fallback to default threshold, got %v", got)
+	}
+}
+
+func TestListDevicesConfigurableOfflineThreshold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "recent", LastSeen: time.Now().Add(-30 * time.Second)}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	listOnline := func() bool {
+		t.Helper()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/devices", nil)
+		ListDevices(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+		}
+		var devices []models.Device
+		if err := json.Unmarshal(w.Body.Bytes(), &devices); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		for _, d := range devices {
+			if d.DeviceID == device.DeviceID {
+				return d.IsOnline
+			}
+		}
+		t.Fatalf("device %s missing from listing", device.DeviceID)
+		return false
+	}
+
+	// Seen 30s ago: online under the 5-minute default.
+	if !listOnline() {
+		t.Error("expected device online under the default threshold")
+	}
+
+	// A 10-second threshold marks the same device offline.
+	t.Setenv("OFFLINE_THRESHOLD_SECONDS", "10")
+	if listOnline() {
+		t.Error("expected device offline under a 10s threshold")
+	}
+}
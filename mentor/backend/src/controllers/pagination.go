@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseOffsetParam reads the optional offset query parameter. Zero means
+// start from the beginning.
+func parseOffsetParam(c *gin.Context) (int, error) {
+	raw := c.Query("offset")
+	if raw == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(raw)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset parameter: must be a non-negative integer")
+	}
+	return offset, nil
+}
+
+// listEnvelopeRequested reports whether the caller opted into the wrapped
+// response shape via ?envelope=true.
+func listEnvelopeRequested(c *gin.Context) bool {
+	return strings.EqualFold(c.Query("envelope"), "true")
+}
+
+// respondList writes a list response. The default shape is the bare array the
+// API has always returned; with ?envelope=true the result is wrapped as
+// {data: [...], pagination: {limit, offset, total, next_cursor}}. The total is
+// only counted when the envelope is requested, so the default path pays
+// nothing for this option.
+func respondList(c *gin.Context, items interface{}, limit, offset int, countTotal func() (int64, error)) {
+	if !listEnvelopeRequested(c) {
+		c.JSON(http.StatusOK, items)
+		return
+	}
+
+	total, err := countTotal()
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	pagination := gin.H{
+		"limit":  limit,
+		"offset": offset,
+		"total":  total,
+	}
+	// next_cursor is the offset of the following page, or null on the last page
+	if next := offset + limit; limit > 0 && int64(next) < total {
+		pagination["next_cursor"] = next
+	} else {
+		pagination["next_cursor"] = nil
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items, "pagination": pagination})
+}
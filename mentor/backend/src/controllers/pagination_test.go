@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceCommandsEnvelopeShapes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	for i := 0; i < 5; i++ {
+		cmd := models.DeviceRemoteCommand{CommandID: uuid.New(), DeviceID: deviceID, CommandText: "get_info", Status: "completed"}
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to seed command: %v", err)
+		}
+	}
+
+	// Default shape stays a bare array for back-compat
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands?limit=2", nil)
+	GetDeviceCommands(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var bare []models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &bare); err != nil {
+		t.Fatalf("expected a bare array by default: %v", err)
+	}
+	if len(bare) != 2 {
+		t.Errorf("expected 2 commands, got %d", len(bare))
+	}
+
+	// Opt-in envelope wraps the same data with pagination metadata
+	w2 := httptest.NewRecorder()
+	c2, _ := gin.CreateTestContext(w2)
+	c2.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c2.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands?limit=2&offset=2&envelope=true", nil)
+	GetDeviceCommands(c2)
+
+	if w2.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w2.Code, w2.Body.String())
+	}
+	var wrapped struct {
+		Data       []models.DeviceRemoteCommand `json:"data"`
+		Pagination struct {
+			Limit      int   `json:"limit"`
+			Offset     int   `json:"offset"`
+			Total      int64 `json:"total"`
+			NextCursor *int  `json:"next_cursor"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal(w2.Body.Bytes(), &wrapped); err != nil {
+		t.Fatalf("failed to unmarshal envelope: %v", err)
+	}
+	if len(wrapped.Data) != 2 {
+		t.Errorf("expected 2 commands in envelope, got %d", len(wrapped.Data))
+	}
+	if wrapped.Pagination.Limit != 2 || wrapped.Pagination.Offset != 2 || wrapped.Pagination.Total != 5 {
+		t.Errorf("unexpected pagination metadata: %+v", wrapped.Pagination)
+	}
+	if wrapped.Pagination.NextCursor == nil || *wrapped.Pagination.NextCursor != 4 {
+		t.Errorf("expected next_cursor 4, got %v", wrapped.Pagination.NextCursor)
+	}
+
+	// Last page reports a null next_cursor
+	w3 := httptest.NewRecorder()
+	c3, _ := gin.CreateTestContext(w3)
+	c3.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c3.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/commands?limit=2&offset=4&envelope=true", nil)
+	GetDeviceCommands(c3)
+
+	var last struct {
+		Pagination struct {
+			NextCursor *int `json:"next_cursor"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal(w3.Body.Bytes(), &last); err != nil {
+		t.Fatalf("failed to unmarshal envelope: %v", err)
+	}
+	if last.Pagination.NextCursor != nil {
+		t.Errorf("expected null next_cursor on the last page, got %v", *last.Pagination.NextCursor)
+	}
+}
+
+func TestListDevicesInvalidOffset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/devices?offset=-1", nil)
+
+	ListDevices(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for negative offset, got %d", w.Code)
+	}
+}
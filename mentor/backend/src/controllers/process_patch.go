@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProcessDelta is an incremental update to a device's process snapshot.
+// Agents send only what changed since the last cycle instead of re-uploading
+// the full list.
+type ProcessDelta struct {
+	Added   []models.DeviceProcess `json:"added"`
+	Removed []int                  `json:"removed"`
+	Updated []models.DeviceProcess `json:"updated"`
+}
+
+// PatchProcessList applies an incremental update to the process snapshot
+// @Summary Patch device process list
+// @Description Mutate the current process snapshot in place: insert added processes, delete removed PIDs, and update changed ones. Updates that reference a PID missing from the snapshot are inserted so the snapshot converges even if a prior delta was lost.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param delta body ProcessDelta true "Process list changes since the last snapshot"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} map[string]string "Bad request - invalid JSON or device ID"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/processes [patch]
+func PatchProcessList(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var delta ProcessDelta
+	if err := c.BindJSON(&delta); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	added, removed, updated := 0, 0, 0
+	now := time.Now()
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if len(delta.Removed) > 0 {
+			result := tx.Where("deviceid = ? AND pid IN ?", deviceID, delta.Removed).
+				Delete(&models.DeviceProcess{})
+			if result.Error != nil {
+				return result.Error
+			}
+			removed = int(result.RowsAffected)
+		}
+
+		for i := range delta.Updated {
+			process := &delta.Updated[i]
+			result := tx.Model(&models.DeviceProcess{}).
+				Where("deviceid = ? AND pid = ?", deviceID, process.PID).
+				Updates(map[string]interface{}{
+					"process_name": process.ProcessName,
+					"cpu":          process.CPU,
+					"memory":       process.Memory,
+					"command_text": process.CommandText,
+					"timestamp":    now,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				updated++
+				continue
+			}
+			// Unknown PID: insert so a lost earlier delta cannot leave the
+			// snapshot permanently missing a process
+			delta.Added = append(delta.Added, *process)
+		}
+
+		for i := range delta.Added {
+			process := &delta.Added[i]
+			process.DeviceID = deviceID
+			process.Timestamp = now
+			if process.ProcessID == uuid.Nil {
+				process.ProcessID = uuid.New()
+			}
+			if err := tx.Create(process).Error; err != nil {
+				return err
+			}
+			added++
+		}
+
+		return nil
+	})
+	if err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"added":   added,
+		"removed": removed,
+		"updated": updated,
+	})
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func patchProcesses(t *testing.T, deviceID uuid.UUID, delta ProcessDelta) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(delta)
+	if err != nil {
+		t.Fatalf("failed to marshal delta: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("PATCH", "/devices/"+deviceID.String()+"/processes", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	PatchProcessList(c)
+	return w
+}
+
+func seedProcessSnapshot(t *testing.T, deviceID uuid.UUID, pids ...int) {
+	t.Helper()
+	for _, pid := range pids {
+		p := models.DeviceProcess{
+			ProcessID: uuid.New(), DeviceID: deviceID,
+			PID: pid, ProcessName: "proc", CPU: 1, Memory: 100,
+		}
+		if err := database.DB.Create(&p).Error; err != nil {
+			t.Fatalf("failed to seed process: %v", err)
+		}
+	}
+}
+
+func TestPatchProcessListAppliesDeltas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seedProcessSnapshot(t, deviceID, 100, 200, 300)
+
+	delta := ProcessDelta{
+		Added:   []models.DeviceProcess{{PID: 400, ProcessName: "newproc", CPU: 5, Memory: 512}},
+		Removed: []int{300},
+		Updated: []models.DeviceProcess{{PID: 200, ProcessName: "proc", CPU: 42, Memory: 2048}},
+	}
+	w := patchProcesses(t, deviceID, delta)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var counts map[string]int
+	if err := json.Unmarshal(w.Body.Bytes(), &counts); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if counts["added"] != 1 || counts["removed"] != 1 || counts["updated"] != 1 {
+		t.Errorf("unexpected delta counts: %v", counts)
+	}
+
+	var snapshot []models.DeviceProcess
+	if err := database.DB.Where("deviceid = ?", deviceID).
+		Order("pid asc").
+		Find(&snapshot).Error; err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if len(snapshot) != 3 {
+		t.Fatalf("expected 3 processes after patch, got %d", len(snapshot))
+	}
+	if snapshot[0].PID != 100 || snapshot[1].PID != 200 || snapshot[2].PID != 400 {
+		t.Errorf("unexpected PIDs after patch: %d %d %d", snapshot[0].PID, snapshot[1].PID, snapshot[2].PID)
+	}
+	if snapshot[1].CPU != 42 || snapshot[1].Memory != 2048 {
+		t.Errorf("update not applied: cpu=%v memory=%d", snapshot[1].CPU, snapshot[1].Memory)
+	}
+	if snapshot[2].ProcessName != "newproc" {
+		t.Errorf("expected added process name newproc, got %q", snapshot[2].ProcessName)
+	}
+}
+
+func TestPatchProcessListInsertsUnknownUpdatedPID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seedProcessSnapshot(t, deviceID, 100)
+
+	// An update referencing a PID absent from the snapshot is inserted so the
+	// snapshot converges even when an earlier delta was lost
+	delta := ProcessDelta{
+		Updated: []models.DeviceProcess{{PID: 999, ProcessName: "ghost", CPU: 7, Memory: 64}},
+	}
+	w := patchProcesses(t, deviceID, delta)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var ghost models.DeviceProcess
+	if err := database.DB.Where("deviceid = ? AND pid = ?", deviceID, 999).
+		First(&ghost).Error; err != nil {
+		t.Fatalf("expected unknown updated PID to be inserted: %v", err)
+	}
+	if ghost.ProcessName != "ghost" {
+		t.Errorf("expected inserted process name ghost, got %q", ghost.ProcessName)
+	}
+}
+
+func TestPatchProcessListInvalidDeviceID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "not-a-uuid"}}
+	c.Request, _ = http.NewRequest("PATCH", "/devices/not-a-uuid/processes", bytes.NewBufferString("{}"))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	PatchProcessList(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
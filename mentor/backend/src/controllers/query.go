@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultQueryLimit is how many rows a DSL query returns when unspecified.
+	DefaultQueryLimit = 100
+	// MaxQueryLimit bounds a single DSL query result.
+	MaxQueryLimit = 1000
+)
+
+// QueryFilter is one field condition in a DSL query.
+type QueryFilter struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// QuerySort orders DSL query results by one whitelisted field.
+type QuerySort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// QueryRequest is the constrained filter spec accepted by the query endpoint.
+type QueryRequest struct {
+	Resource string        `json:"resource"`
+	Filters  []QueryFilter `json:"filters"`
+	Sort     *QuerySort    `json:"sort,omitempty"`
+	Limit    int           `json:"limit"`
+}
+
+// queryOperators maps DSL operators to SQL. Only these comparisons are
+// allowed; the operator string is never taken from user input directly.
+var queryOperators = map[string]string{
+	"eq":   "=",
+	"ne":   "<>",
+	"lt":   "<",
+	"lte":  "<=",
+	"gt":   ">",
+	"gte":  ">=",
+	"like": "LIKE",
+}
+
+// queryableResources whitelists, per resource, the JSON field names clients
+// may filter and sort on, mapped to the actual column names. Fields outside
+// the whitelist are rejected, which is what keeps the DSL injection-safe.
+var queryableResources = map[string]map[string]string{
+	"devices": {
+		"deviceid":    "deviceid",
+		"device_name": "device_name",
+		"device_type": "device_type",
+		"os":          "os",
+		"is_online":   "is_online",
+		"last_seen":   "last_seen",
+	},
+	"metrics": {
+		"deviceid":  "deviceid",
+		"timestamp": "timestamp",
+		"cpu_usage": "cpu_usage",
+		"cpu_temp":  "cpu_temp",
+	},
+	"alerts": {
+		"deviceid":   "deviceid",
+		"timestamp":  "timestamp",
+		"level":      "level",
+		"alert_type": "alert_type",
+	},
+}
+
+// buildQuery translates a validated request into GORM conditions. Separated
+// from the handler so the translation is testable without HTTP.
+func buildQuery(req QueryRequest) (*gorm.DB, error) {
+	fields, ok := queryableResources[req.Resource]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource %q", req.Resource)
+	}
+
+	query := database.Read().Session(&gorm.Session{})
+	for _, filter := range req.Filters {
+		column, ok := fields[strings.ToLower(filter.Field)]
+		if !ok {
+			return nil, fmt.Errorf("field %q is not queryable on %s", filter.Field, req.Resource)
+		}
+		sqlOp, ok := queryOperators[strings.ToLower(filter.Op)]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %q", filter.Op)
+		}
+		query = query.Where(fmt.Sprintf("%s %s ?", column, sqlOp), filter.Value)
+	}
+
+	if req.Sort != nil {
+		column, ok := fields[strings.ToLower(req.Sort.Field)]
+		if !ok {
+			return nil, fmt.Errorf("field %q is not sortable on %s", req.Sort.Field, req.Resource)
+		}
+		direction := "asc"
+		if req.Sort.Desc {
+			direction = "desc"
+		}
+		query = query.Order(column + " " + direction)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultQueryLimit
+	}
+	if limit > MaxQueryLimit {
+		return nil, fmt.Errorf("limit exceeds maximum of %d", MaxQueryLimit)
+	}
+	return query.Limit(limit), nil
+}
+
+// Query runs a constrained filter spec against one resource
+// @Summary Query devices, metrics, or alerts
+// @Description Run a JSON filter spec (resource, field filters with operators, sort, limit) against a strict field and operator whitelist. Meant for power users who would otherwise need many specialized endpoints.
+// @Tags query
+// @Accept json
+// @Produce json
+// @Param query body controllers.QueryRequest true "Filter spec"
+// @Success 200 {array} interface{}
+// @Failure 400 {object} map[string]string "Bad request - unknown resource, field, or operator"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /query [post]
+func Query(c *gin.Context) {
+	var req QueryRequest
+	if err := c.BindJSON(&req); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid request body: "+err.Error())
+		return
+	}
+
+	query, err := buildQuery(req)
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	var findErr error
+	switch req.Resource {
+	case "devices":
+		results := make([]models.Device, 0)
+		if findErr = query.Find(&results).Error; findErr == nil {
+			c.JSON(http.StatusOK, results)
+		}
+	case "metrics":
+		results := make([]models.DeviceMetric, 0)
+		if findErr = query.Find(&results).Error; findErr == nil {
+			c.JSON(http.StatusOK, results)
+		}
+	case "alerts":
+		results := make([]models.DeviceAlert, 0)
+		if findErr = query.Find(&results).Error; findErr == nil {
+			c.JSON(http.StatusOK, results)
+		}
+	}
+	if findErr != nil {
+		RespondInternalError(c, findErr)
+	}
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func runQuery(t *testing.T, req QueryRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal query: %v", err)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/query", bytes.NewBuffer(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	Query(c)
+	return w
+}
+
+func TestQueryDevicesWithFilterAndSort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	for _, d := range []models.Device{
+		{DeviceID: uuid.New(), DeviceName: "web-1", DeviceType: "server"},
+		{DeviceID: uuid.New(), DeviceName: "web-2", DeviceType: "server"},
+		{DeviceID: uuid.New(), DeviceName: "kiosk-1", DeviceType: "desktop"},
+	} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+
+	w := runQuery(t, QueryRequest{
+		Resource: "devices",
+		Filters:  []QueryFilter{{Field: "device_type", Op: "eq", Value: "server"}},
+		Sort:     &QuerySort{Field: "device_name", Desc: true},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var devices []models.Device
+	if err := json.Unmarshal(w.Body.Bytes(), &devices); err != nil {
+		t.Fatalf("failed to unmarshal devices: %v", err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("expected 2 servers, got %d", len(devices))
+	}
+	if devices[0].DeviceName != "web-2" || devices[1].DeviceName != "web-1" {
+		t.Errorf("unexpected sort order: %s, %s", devices[0].DeviceName, devices[1].DeviceName)
+	}
+
+	w = runQuery(t, QueryRequest{
+		Resource: "devices",
+		Filters:  []QueryFilter{{Field: "device_name", Op: "like", Value: "web-%"}},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for like query, got %d", w.Code)
+	}
+}
+
+func TestQueryRejectsOutOfWhitelist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	cases := []QueryRequest{
+		{Resource: "users"},
+		{Resource: "devices", Filters: []QueryFilter{{Field: "password", Op: "eq", Value: "x"}}},
+		{Resource: "devices", Filters: []QueryFilter{{Field: "device_name", Op: "regexp", Value: "x"}}},
+		{Resource: "devices", Sort: &QuerySort{Field: "1; DROP TABLE devices"}},
+		{Resource: "metrics", Limit: MaxQueryLimit + 1},
+	}
+	for _, req := range cases {
+		if w := runQuery(t, req); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %+v, got %d", req, w.Code)
+		}
+	}
+}
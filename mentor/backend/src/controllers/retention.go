@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+)
+
+// metricRetentionDays returns the fleet-wide metric retention window in days
+// from METRIC_RETENTION_DAYS. Zero (the default) disables the purge job.
+func metricRetentionDays() int {
+	raw := os.Getenv("METRIC_RETENTION_DAYS")
+	if raw == "" {
+		return 0
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0
+	}
+	return days
+}
+
+// effectiveRetentionDays selects the retention window for one device: its own
+// RetentionDays override when set, otherwise the fleet default.
+func effectiveRetentionDays(device models.Device, defaultDays int) int {
+	if device.RetentionDays > 0 {
+		return device.RetentionDays
+	}
+	return defaultDays
+}
+
+// PurgeExpiredMetrics deletes metrics older than each device's retention
+// window and returns how many rows were removed. Devices with a RetentionDays
+// override are purged against their own cutoff; everything else uses the
+// fleet default. A zero default disables the job entirely.
+func PurgeExpiredMetrics() (int64, error) {
+	defaultDays := metricRetentionDays()
+	if defaultDays == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	var overridden []models.Device
+	if err := database.DB.Select("deviceid", "retention_days").
+		Where("retention_days > 0").
+		Find(&overridden).Error; err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	overriddenIDs := make([]interface{}, 0, len(overridden))
+	for _, device := range overridden {
+		cutoff := now.AddDate(0, 0, -effectiveRetentionDays(device, defaultDays))
+		result := database.DB.Where("deviceid = ? AND timestamp < ?", device.DeviceID, cutoff).
+			Delete(&models.DeviceMetric{})
+		if result.Error != nil {
+			return purged, result.Error
+		}
+		purged += result.RowsAffected
+		overriddenIDs = append(overriddenIDs, device.DeviceID)
+	}
+
+	defaultQuery := database.DB.Where("timestamp < ?", now.AddDate(0, 0, -defaultDays))
+	if len(overriddenIDs) > 0 {
+		defaultQuery = defaultQuery.Where("deviceid NOT IN ?", overriddenIDs)
+	}
+	result := defaultQuery.Delete(&models.DeviceMetric{})
+	if result.Error != nil {
+		return purged, result.Error
+	}
+	return purged + result.RowsAffected, nil
+}
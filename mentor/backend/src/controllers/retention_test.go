@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestEffectiveRetentionDays(t *testing.T) {
+	if got := effectiveRetentionDays(models.Device{}, 7); got != 7 {
+		t.Errorf("expected fleet default 7 without override, got %d", got)
+	}
+	if got := effectiveRetentionDays(models.Device{RetentionDays: 30}, 7); got != 30 {
+		t.Errorf("expected override 30, got %d", got)
+	}
+}
+
+func TestPurgeExpiredMetricsHonorsOverride(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_RETENTION_DAYS", "7")
+
+	// One device keeps the fleet default, one retains data for 30 days.
+	plain := models.Device{DeviceID: uuid.New(), DeviceName: "plain", DeviceType: "server"}
+	forensic := models.Device{DeviceID: uuid.New(), DeviceName: "forensic", DeviceType: "server", RetentionDays: 30}
+	for _, d := range []models.Device{plain, forensic} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+
+	// Metrics 10 days old fall outside the 7-day default but inside the
+	// 30-day override; a 40-day-old metric expires everywhere.
+	for _, m := range []models.DeviceMetric{
+		{MetricID: uuid.New(), DeviceID: plain.DeviceID, Timestamp: time.Now().AddDate(0, 0, -10)},
+		{MetricID: uuid.New(), DeviceID: plain.DeviceID, Timestamp: time.Now().Add(-time.Hour)},
+		{MetricID: uuid.New(), DeviceID: forensic.DeviceID, Timestamp: time.Now().AddDate(0, 0, -10)},
+		{MetricID: uuid.New(), DeviceID: forensic.DeviceID, Timestamp: time.Now().AddDate(0, 0, -40)},
+	} {
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to create metric: %v", err)
+		}
+	}
+
+	purged, err := PurgeExpiredMetrics()
+	if err != nil {
+		t.Fatalf("PurgeExpiredMetrics failed: %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("expected 2 metrics purged, got %d", purged)
+	}
+
+	var plainCount, forensicCount int64
+	if err := database.DB.Model(&models.DeviceMetric{}).Where("deviceid = ?", plain.DeviceID).Count(&plainCount).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	if err := database.DB.Model(&models.DeviceMetric{}).Where("deviceid = ?", forensic.DeviceID).Count(&forensicCount).Error; err != nil {
+		t.Fatalf("failed to count metrics: %v", err)
+	}
+	if plainCount != 1 {
+		t.Errorf("expected default device to keep only its recent metric, got %d", plainCount)
+	}
+	if forensicCount != 1 {
+		t.Errorf("expected overridden device to keep its 10-day-old metric, got %d", forensicCount)
+	}
+}
+
+func TestPurgeExpiredMetricsDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("METRIC_RETENTION_DAYS", "")
+
+	deviceID := uuid.New()
+	old := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now().AddDate(0, 0, -365)}
+	if err := database.DB.Create(&old).Error; err != nil {
+		t.Fatalf("failed to create metric: %v", err)
+	}
+
+	purged, err := PurgeExpiredMetrics()
+	if err != nil {
+		t.Fatalf("PurgeExpiredMetrics failed: %v", err)
+	}
+	if purged != 0 {
+		t.Errorf("expected no purge when retention is unset, got %d", purged)
+	}
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FieldSchema describes one model field for dynamic form builders.
+type FieldSchema struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// schemaModels maps the exposed schema name to a model instance. Kept in sync
+// with the migration list in the database package.
+var schemaModels = map[string]interface{}{
+	"device":                models.Device{},
+	"device_metric":         models.DeviceMetric{},
+	"device_process":        models.DeviceProcess{},
+	"device_activity":       models.DeviceActivity{},
+	"device_alert":          models.DeviceAlert{},
+	"device_remote_command": models.DeviceRemoteCommand{},
+	"device_screenshot":     models.DeviceScreenshot{},
+	"device_log":            models.DeviceLog{},
+	"device_config":         models.DeviceConfig{},
+	"device_note":           models.DeviceNote{},
+	"device_group":          models.DeviceGroup{},
+	"user":                  models.User{},
+}
+
+var (
+	schemaOnce   sync.Once
+	schemaResult map[string][]FieldSchema
+)
+
+var (
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// fieldTypeName maps a Go type onto the coarse type vocabulary the frontend
+// understands.
+func fieldTypeName(t reflect.Type) string {
+	switch t {
+	case uuidType:
+		return "uuid"
+	case timeType:
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// reflectModelSchema derives the field metadata for one model struct.
+func reflectModelSchema(model interface{}) []FieldSchema {
+	t := reflect.TypeOf(model)
+	fields := make([]FieldSchema, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			// Untagged fields (relationship slices) keep their Go name
+			name = field.Name
+		}
+
+		fieldType := field.Type
+		required := true
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+			required = false
+		}
+		// Columns the database fills in (generated IDs, timestamps) and
+		// collections are not required from callers
+		if strings.Contains(field.Tag.Get("gorm"), "default:") ||
+			fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map {
+			required = false
+		}
+
+		fields = append(fields, FieldSchema{
+			Name:     name,
+			Type:     fieldTypeName(fieldType),
+			Required: required,
+		})
+	}
+	return fields
+}
+
+// GetSchema returns reflected field metadata for every model
+// @Summary Get model schemas
+// @Description Return field names, types, and required-ness for each model, derived by reflection, so dynamic UIs can build forms without hardcoding the data model. The result is computed once and cached.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} map[string][]controllers.FieldSchema
+// @Router /schema [get]
+func GetSchema(c *gin.Context) {
+	schemaOnce.Do(func() {
+		schemaResult = make(map[string][]FieldSchema, len(schemaModels))
+		for name, model := range schemaModels {
+			schemaResult[name] = reflectModelSchema(model)
+		}
+	})
+
+	c.JSON(http.StatusOK, schemaResult)
+}
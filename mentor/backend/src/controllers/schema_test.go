@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetSchema(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/schema", nil)
+
+	GetSchema(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var schemas map[string][]FieldSchema
+	if err := json.Unmarshal(w.Body.Bytes(), &schemas); err != nil {
+		t.Fatalf("failed to unmarshal schemas: %v", err)
+	}
+
+	device, ok := schemas["device"]
+	if !ok {
+		t.Fatalf("expected device schema, got models: %v", len(schemas))
+	}
+
+	fields := map[string]FieldSchema{}
+	for _, f := range device {
+		fields[f.Name] = f
+	}
+
+	expected := map[string]string{
+		"deviceid":    "uuid",
+		"device_name": "string",
+		"last_seen":   "time",
+		"is_online":   "boolean",
+		"version":     "integer",
+	}
+	for name, wantType := range expected {
+		f, ok := fields[name]
+		if !ok {
+			t.Errorf("device schema missing field %q", name)
+			continue
+		}
+		if f.Type != wantType {
+			t.Errorf("field %q: expected type %q, got %q", name, wantType, f.Type)
+		}
+	}
+
+	// Generated and defaulted columns are not required from callers
+	if fields["deviceid"].Required {
+		t.Error("deviceid has a database default and should not be required")
+	}
+	if !fields["device_name"].Required {
+		t.Error("device_name should be required")
+	}
+
+	// Metrics are exposed too, with float fields as numbers
+	metric, ok := schemas["device_metric"]
+	if !ok {
+		t.Fatal("expected device_metric schema")
+	}
+	for _, f := range metric {
+		if f.Name == "cpu_usage" && f.Type != "number" {
+			t.Errorf("cpu_usage: expected type number, got %q", f.Type)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestStoreScreenshotDedupByChecksum(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_DEDUP", "true")
+
+	deviceID := uuid.New()
+	first := `{"deviceid": "` + deviceID.String() + `", "path": "shots/a.png", "checksum": "abc123"}`
+
+	w := postScreenshot(t, first)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for first screenshot, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var stored models.DeviceScreenshot
+	if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("failed to unmarshal screenshot: %v", err)
+	}
+
+	// Same bytes again: the existing metadata comes back and no new row is stored
+	duplicate := `{"deviceid": "` + deviceID.String() + `", "path": "shots/b.png", "checksum": "abc123"}`
+	w = postScreenshot(t, duplicate)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for duplicate, got %d", w.Code)
+	}
+	var returned models.DeviceScreenshot
+	if err := json.Unmarshal(w.Body.Bytes(), &returned); err != nil {
+		t.Fatalf("failed to unmarshal duplicate response: %v", err)
+	}
+	if returned.ScreenshotID != stored.ScreenshotID || returned.Path != "shots/a.png" {
+		t.Errorf("expected existing screenshot back, got %+v", returned)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count screenshots: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 stored screenshot, got %d", count)
+	}
+
+	// Different content is stored normally
+	changed := `{"deviceid": "` + deviceID.String() + `", "path": "shots/c.png", "checksum": "def456"}`
+	if w := postScreenshot(t, changed); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for changed content, got %d", w.Code)
+	}
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count screenshots: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 stored screenshots after content change, got %d", count)
+	}
+}
+
+func TestStoreScreenshotDedupOffByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_DEDUP", "")
+
+	deviceID := uuid.New()
+	body := `{"deviceid": "` + deviceID.String() + `", "path": "shots/x.png", "checksum": "same"}`
+	for i := 0; i < 2; i++ {
+		if w := postScreenshot(t, body); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 with dedup off, got %d", w.Code)
+		}
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count screenshots: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected both screenshots stored with dedup off, got %d", count)
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceScreenshotStorageUnavailable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// No MinIO client configured: storage health checks fail in this mode
+	s3.SetClient(nil)
+
+	deviceID := uuid.New()
+	shot := models.DeviceScreenshot{
+		ScreenshotID: uuid.New(),
+		DeviceID:     deviceID,
+		Path:         "screenshots/degraded-test.png",
+		Resolution:   "1920x1080",
+		Size:         1024,
+	}
+	if err := database.DB.Create(&shot).Error; err != nil {
+		t.Fatalf("failed to seed screenshot: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/screenshots", nil)
+
+	GetDeviceScreenshot(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var resp []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(resp) != 1 {
+		t.Fatalf("expected 1 screenshot, got %d", len(resp))
+	}
+
+	item := resp[0]
+	if item["storage_unavailable"] != true {
+		t.Errorf("expected storage_unavailable=true, got %v", item["storage_unavailable"])
+	}
+	if item["cached"] != false {
+		t.Errorf("expected cached=false with no cached URL, got %v", item["cached"])
+	}
+	if item["screenshot_url"] != "" {
+		t.Errorf("expected empty screenshot_url in degraded mode, got %v", item["screenshot_url"])
+	}
+}
+
+func TestHealthCheckReadyReportsDegradedScreenshots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	s3.SetClient(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/health/ready", nil)
+
+	HealthCheckReady(c)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 with storage down, got %d", w.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	degraded, ok := resp["degraded_features"].([]interface{})
+	if !ok || len(degraded) == 0 || degraded[0] != "screenshots" {
+		t.Errorf("expected degraded_features to list screenshots, got %v", resp["degraded_features"])
+	}
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultScreenshotDownloadLimit is how many screenshots a bulk download
+	// includes when the caller does not specify a limit
+	DefaultScreenshotDownloadLimit = 20
+	// MaxScreenshotDownloadLimit bounds a single bulk download so one request
+	// cannot stream an unbounded amount of object storage
+	MaxScreenshotDownloadLimit = 100
+)
+
+// fetchScreenshotObject retrieves screenshot bytes from object storage. It is
+// a variable so tests can substitute an in-memory store for MinIO.
+var fetchScreenshotObject = s3.GetObject
+
+// DownloadDeviceScreenshots streams the latest screenshots for a device as a ZIP
+// @Summary Download device screenshots as ZIP
+// @Description Stream a ZIP archive containing the object bytes of the latest N screenshots for a device, newest first. Objects that cannot be fetched are skipped and recorded in a manifest.txt entry inside the archive.
+// @Tags devices
+// @Produce application/zip
+// @Param id path string true "Device ID (UUID)"
+// @Param limit query int false "Number of screenshots to include (default 20, max 100)"
+// @Success 200 {file} binary "ZIP archive of screenshots"
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID or limit"
+// @Failure 404 {object} map[string]string "No screenshots found for device"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/screenshots/download [get]
+func DownloadDeviceScreenshots(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	limit := DefaultScreenshotDownloadLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+	if limit > MaxScreenshotDownloadLimit {
+		limit = MaxScreenshotDownloadLimit
+	}
+
+	shots := make([]models.DeviceScreenshot, 0)
+	if err := database.DB.Where("deviceid = ?", deviceID).
+		Order("timestamp desc").
+		Limit(limit).
+		Find(&shots).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if len(shots) == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "no screenshots found for device")
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=device-%s-screenshots.zip", deviceID))
+	c.Status(http.StatusOK)
+
+	// Write archive entries straight to the response so memory use stays flat
+	// regardless of how many screenshots are included
+	zw := zip.NewWriter(c.Writer)
+	manifest := ""
+	for i, shot := range shots {
+		obj, err := fetchScreenshotObject(shot.Path)
+		if err != nil {
+			// Missing or unreadable objects are skipped, not fatal: the manifest
+			// records the failure so the caller knows the archive is partial
+			manifest += fmt.Sprintf("%s: fetch failed: %v\n", shot.Path, err)
+			continue
+		}
+
+		// Prefix with the position so repeated basenames stay unique in the archive
+		entry, err := zw.Create(fmt.Sprintf("%03d-%s", i, path.Base(shot.Path)))
+		if err == nil {
+			_, err = io.Copy(entry, obj)
+		}
+		obj.Close()
+		if err != nil {
+			// The response is already streaming; record the failure and move on
+			manifest += fmt.Sprintf("%s: write failed: %v\n", shot.Path, err)
+			continue
+		}
+		manifest += fmt.Sprintf("%s: ok\n", shot.Path)
+	}
+
+	if entry, err := zw.Create("manifest.txt"); err == nil {
+		if _, err := io.WriteString(entry, manifest); err != nil {
+			fmt.Printf("Failed to write screenshot manifest: %v\n", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		fmt.Printf("Failed to finalize screenshot archive: %v\n", err)
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestDownloadDeviceScreenshots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	// Substitute an in-memory object store for MinIO
+	objects := map[string][]byte{
+		"shots/a.png": []byte("png-bytes-a"),
+		"shots/b.png": []byte("png-bytes-b"),
+	}
+	original := fetchScreenshotObject
+	fetchScreenshotObject = func(filename string) (io.ReadCloser, error) {
+		data, ok := objects[filename]
+		if !ok {
+			return nil, fmt.Errorf("object %q not found", filename)
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	t.Cleanup(func() { fetchScreenshotObject = original })
+
+	deviceID := uuid.New()
+	base := time.Now().Add(-time.Hour)
+	paths := []string{"shots/a.png", "shots/b.png", "shots/missing.png"}
+	for i, p := range paths {
+		shot := models.DeviceScreenshot{
+			ScreenshotID: uuid.New(),
+			DeviceID:     deviceID,
+			Timestamp:    base.Add(time.Duration(i) * time.Minute),
+			Path:         p,
+		}
+		if err := database.DB.Create(&shot).Error; err != nil {
+			t.Fatalf("failed to seed screenshot: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/screenshots/download", nil)
+
+	DownloadDeviceScreenshots(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("expected application/zip content type, got %q", ct)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to open response as zip: %v", err)
+	}
+
+	entries := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open zip entry %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		entries[f.Name] = string(data)
+	}
+
+	// Two fetchable objects plus the manifest; the missing one is skipped
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 zip entries, got %d: %v", len(entries), entries)
+	}
+	// Newest first: b.png was seeded last
+	if entries["000-b.png"] != "png-bytes-b" || entries["001-a.png"] != "png-bytes-a" {
+		t.Errorf("unexpected archive contents: %v", entries)
+	}
+
+	manifest := entries["manifest.txt"]
+	if !strings.Contains(manifest, "shots/a.png: ok") || !strings.Contains(manifest, "shots/b.png: ok") {
+		t.Errorf("manifest missing ok entries: %q", manifest)
+	}
+	if !strings.Contains(manifest, "shots/missing.png: fetch failed") {
+		t.Errorf("manifest missing failed-fetch entry: %q", manifest)
+	}
+}
+
+func TestDownloadDeviceScreenshotsLimitAndErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	original := fetchScreenshotObject
+	fetchScreenshotObject = func(filename string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("data")), nil
+	}
+	t.Cleanup(func() { fetchScreenshotObject = original })
+
+	deviceID := uuid.New()
+	for i := 0; i < 3; i++ {
+		shot := models.DeviceScreenshot{
+			ScreenshotID: uuid.New(),
+			DeviceID:     deviceID,
+			Timestamp:    time.Now().Add(time.Duration(i) * time.Second),
+			Path:         fmt.Sprintf("shots/%d.png", i),
+		}
+		if err := database.DB.Create(&shot).Error; err != nil {
+			t.Fatalf("failed to seed screenshot: %v", err)
+		}
+	}
+
+	// Limit restricts the archive to the newest N screenshots
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/screenshots/download?limit=2", nil)
+
+	DownloadDeviceScreenshots(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatalf("failed to open response as zip: %v", err)
+	}
+	if len(zr.File) != 3 { // 2 screenshots + manifest
+		t.Errorf("expected 2 screenshots plus manifest, got %d entries", len(zr.File))
+	}
+
+	// Invalid limit is rejected
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/screenshots/download?limit=-1", nil)
+
+	DownloadDeviceScreenshots(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid limit, got %d", w.Code)
+	}
+
+	// A device without screenshots is a 404
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+uuid.New().String()+"/screenshots/download", nil)
+
+	DownloadDeviceScreenshots(c)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for device without screenshots, got %d", w.Code)
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func listScreenshots(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/screenshots"+query, nil)
+
+	GetDeviceScreenshot(c)
+	return w
+}
+
+func decodeScreenshotList(t *testing.T, w *httptest.ResponseRecorder) []map[string]interface{} {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return items
+}
+
+func TestGetDeviceScreenshotFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	seed := []models.DeviceScreenshot{
+		{ScreenshotID: uuid.New(), DeviceID: deviceID, Path: "a.png", Resolution: "1920x1080", Size: 500000},
+		{ScreenshotID: uuid.New(), DeviceID: deviceID, Path: "b.png", Resolution: "1920x1080", Size: 120},
+		{ScreenshotID: uuid.New(), DeviceID: deviceID, Path: "c.png", Resolution: "800x600", Size: 90000},
+	}
+	for i := range seed {
+		if err := database.DB.Create(&seed[i]).Error; err != nil {
+			t.Fatalf("failed to seed screenshot: %v", err)
+		}
+	}
+
+	// Resolution filter
+	items := decodeScreenshotList(t, listScreenshots(t, deviceID, "?resolution=1920x1080"))
+	if len(items) != 2 {
+		t.Errorf("expected 2 screenshots at 1920x1080, got %d", len(items))
+	}
+
+	// Size filters skip the tiny (likely corrupt) capture
+	items = decodeScreenshotList(t, listScreenshots(t, deviceID, "?min_size=1000"))
+	if len(items) != 2 {
+		t.Errorf("expected 2 screenshots above 1000 bytes, got %d", len(items))
+	}
+	for _, item := range items {
+		if item["path"] == "b.png" {
+			t.Error("tiny screenshot not filtered out")
+		}
+	}
+
+	// Combined filters
+	items = decodeScreenshotList(t, listScreenshots(t, deviceID, "?resolution=1920x1080&min_size=1000&max_size=600000"))
+	if len(items) != 1 || items[0]["path"] != "a.png" {
+		t.Errorf("expected only a.png, got %+v", items)
+	}
+}
+
+func TestGetDeviceScreenshotFilterValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	cases := []string{
+		"?resolution=huge",
+		"?resolution=0x100",
+		"?min_size=-1",
+		"?max_size=abc",
+		"?min_size=100&max_size=10",
+	}
+	for _, q := range cases {
+		if w := listScreenshots(t, deviceID, q); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %q, got %d", q, w.Code)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PinScreenshot pins or unpins one screenshot against retention
+// @Summary Pin or unpin a screenshot
+// @Description Mark the screenshot as forensic evidence so the retention job never purges it, or release it back to normal retention with pinned=false. Screenshots linked to an alert are already retained without pinning.
+// @Tags screenshots
+// @Produce json
+// @Param id path string true "Screenshot ID (UUID)"
+// @Param pinned query bool false "Desired pinned state" default(true)
+// @Success 200 {object} models.DeviceScreenshot
+// @Failure 400 {object} map[string]string "Bad request - invalid ID or pinned value"
+// @Failure 404 {object} map[string]string "Screenshot not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /screenshots/{id}/pin [post]
+func PinScreenshot(c *gin.Context) {
+	screenshotID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid screenshot ID")
+		return
+	}
+
+	pinned, err := strconv.ParseBool(c.DefaultQuery("pinned", "true"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "pinned must be true or false")
+		return
+	}
+
+	var screenshot models.DeviceScreenshot
+	if err := database.DB.First(&screenshot, "screenshotid = ?", screenshotID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "screenshot not found")
+			return
+		}
+		RespondInternalError(c, err)
+		return
+	}
+
+	screenshot.Pinned = pinned
+	if err := database.DB.Model(&screenshot).Update("pinned", pinned).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, screenshot)
+}
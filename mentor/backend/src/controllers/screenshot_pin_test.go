@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func pinScreenshot(t *testing.T, screenshotID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: screenshotID}}
+	c.Request = httptest.NewRequest("POST", "/screenshots/"+screenshotID+"/pin"+query, nil)
+	PinScreenshot(c)
+	return w
+}
+
+func TestPinScreenshot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	screenshot := models.DeviceScreenshot{
+		ScreenshotID: uuid.New(),
+		DeviceID:     uuid.New(),
+		Timestamp:    time.Now(),
+	}
+	if err := database.DB.Create(&screenshot).Error; err != nil {
+		t.Fatalf("failed to create screenshot: %v", err)
+	}
+
+	if w := pinScreenshot(t, screenshot.ScreenshotID.String(), ""); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 pinning, got %d: %s", w.Code, w.Body.String())
+	}
+	var stored models.DeviceScreenshot
+	database.DB.First(&stored, "screenshotid = ?", screenshot.ScreenshotID)
+	if !stored.Pinned {
+		t.Error("expected screenshot pinned after default pin call")
+	}
+
+	if w := pinScreenshot(t, screenshot.ScreenshotID.String(), "?pinned=false"); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 unpinning, got %d", w.Code)
+	}
+	database.DB.First(&stored, "screenshotid = ?", screenshot.ScreenshotID)
+	if stored.Pinned {
+		t.Error("expected screenshot unpinned after pinned=false")
+	}
+}
+
+func TestPinScreenshotNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := pinScreenshot(t, uuid.New().String(), ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown screenshot, got %d", w.Code)
+	}
+}
+
+func TestPurgeExpiredScreenshotsKeepsPinnedAndLinked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_RETENTION_DAYS", "7")
+
+	deviceID := uuid.New()
+	alertID := uuid.New()
+	old := time.Now().AddDate(0, 0, -10)
+
+	routine := models.DeviceScreenshot{ScreenshotID: uuid.New(), DeviceID: deviceID, Timestamp: old}
+	pinned := models.DeviceScreenshot{ScreenshotID: uuid.New(), DeviceID: deviceID, Timestamp: old, Pinned: true}
+	linked := models.DeviceScreenshot{ScreenshotID: uuid.New(), DeviceID: deviceID, Timestamp: old, AlertID: &alertID}
+	recent := models.DeviceScreenshot{ScreenshotID: uuid.New(), DeviceID: deviceID, Timestamp: time.Now()}
+	for _, s := range []models.DeviceScreenshot{routine, pinned, linked, recent} {
+		if err := database.DB.Create(&s).Error; err != nil {
+			t.Fatalf("failed to create screenshot: %v", err)
+		}
+	}
+
+	purged, err := PurgeExpiredScreenshots()
+	if err != nil {
+		t.Fatalf("PurgeExpiredScreenshots failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected exactly the routine capture purged, got %d", purged)
+	}
+
+	var remaining []models.DeviceScreenshot
+	database.DB.Where("deviceid = ?", deviceID).Find(&remaining)
+	kept := map[uuid.UUID]bool{}
+	for _, s := range remaining {
+		kept[s.ScreenshotID] = true
+	}
+	if kept[routine.ScreenshotID] {
+		t.Error("expected the old unpinned capture purged")
+	}
+	for name, id := range map[string]uuid.UUID{
+		"pinned": pinned.ScreenshotID, "linked": linked.ScreenshotID, "recent": recent.ScreenshotID,
+	} {
+		if !kept[id] {
+			t.Errorf("expected the %s capture to survive the purge", name)
+		}
+	}
+}
+
+func TestPurgeExpiredScreenshotsDisabledByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_RETENTION_DAYS", "")
+
+	screenshot := models.DeviceScreenshot{
+		ScreenshotID: uuid.New(),
+		DeviceID:     uuid.New(),
+		Timestamp:    time.Now().AddDate(0, -6, 0),
+	}
+	if err := database.DB.Create(&screenshot).Error; err != nil {
+		t.Fatalf("failed to create screenshot: %v", err)
+	}
+
+	if purged, err := PurgeExpiredScreenshots(); err != nil || purged != 0 {
+		t.Errorf("expected no-op without a retention window, got purged=%d err=%v", purged, err)
+	}
+}
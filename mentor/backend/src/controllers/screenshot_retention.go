@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+	"mentor-backend/s3"
+)
+
+// screenshotRetentionDays returns the screenshot retention window in days
+// from SCREENSHOT_RETENTION_DAYS. Zero (the default) disables the purge job.
+func screenshotRetentionDays() int {
+	raw := os.Getenv("SCREENSHOT_RETENTION_DAYS")
+	if raw == "" {
+		return 0
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 0 {
+		return 0
+	}
+	return days
+}
+
+// PurgeExpiredScreenshots deletes screenshots older than the retention window
+// and returns how many rows were removed. Pinned captures and captures linked
+// to an alert are forensic evidence and survive the purge regardless of age.
+// A zero window disables the job entirely.
+func PurgeExpiredScreenshots() (int64, error) {
+	days := screenshotRetentionDays()
+	if days == 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+	const expired = "timestamp < ? AND NOT pinned AND alertid IS NULL"
+
+	// Collect object names before their rows disappear
+	var paths []string
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where(expired, cutoff).
+		Pluck("path", &paths).Error; err != nil {
+		return 0, err
+	}
+
+	result := database.DB.Where(expired, cutoff).Delete(&models.DeviceScreenshot{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	// Rows are gone; remove the backing objects best-effort
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if err := s3.RemoveObject(p); err != nil {
+			fmt.Printf("Failed to remove expired screenshot object %s: %v\n", p, err)
+		}
+	}
+	return result.RowsAffected, nil
+}
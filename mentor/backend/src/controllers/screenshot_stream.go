@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"mentor-backend/models"
+	"mentor-backend/s3"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// screenshotHub fans stored screenshots out to SSE subscribers per device.
+// Publishes never block: slow or stalled subscribers miss frames rather than
+// holding up the ingest path.
+type screenshotHub struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan gin.H]struct{}
+}
+
+// screenshotStream is the process-wide hub feeding screenshot SSE streams
+var screenshotStream = &screenshotHub{
+	subscribers: make(map[uuid.UUID]map[chan gin.H]struct{}),
+}
+
+// Subscribe registers a new subscriber channel for a device's screenshots
+func (h *screenshotHub) Subscribe(deviceID uuid.UUID) chan gin.H {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan gin.H, 8)
+	if h.subscribers[deviceID] == nil {
+		h.subscribers[deviceID] = make(map[chan gin.H]struct{})
+	}
+	h.subscribers[deviceID][ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes a subscriber channel and releases its resources
+func (h *screenshotHub) Unsubscribe(deviceID uuid.UUID, ch chan gin.H) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if subs, found := h.subscribers[deviceID]; found {
+		delete(subs, ch)
+		if len(subs) == 0 {
+			delete(h.subscribers, deviceID)
+		}
+	}
+	close(ch)
+}
+
+// Publish delivers a frame to every subscriber of the device without blocking
+func (h *screenshotHub) Publish(deviceID uuid.UUID, frame gin.H) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[deviceID] {
+		select {
+		case ch <- frame:
+		default:
+			// Subscriber is not keeping up; drop the frame for them
+		}
+	}
+}
+
+// publishScreenshot builds the SSE frame (metadata plus presigned URL) for a
+// freshly stored screenshot and broadcasts it to that device's subscribers
+func publishScreenshot(s models.DeviceScreenshot) {
+	screenshotStream.Publish(s.DeviceID, gin.H{
+		"screenshotid":   s.ScreenshotID,
+		"deviceid":       s.DeviceID,
+		"timestamp":      s.Timestamp,
+		"path":           s.Path,
+		"resolution":     s.Resolution,
+		"size":           s.Size,
+		"screenshot_url": s3.GeneratePresignedURL(s.Path),
+	})
+}
+
+// StreamDeviceScreenshots streams new screenshots for a device via SSE
+// @Summary Stream device screenshots
+// @Description Server-sent events stream emitting metadata and a presigned URL whenever a new screenshot is stored for the device
+// @Tags devices
+// @Produce text/event-stream
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {string} string "SSE stream of screenshot events"
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Router /devices/{id}/screenshots/stream [get]
+func StreamDeviceScreenshots(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	ch := screenshotStream.Subscribe(deviceID)
+	defer screenshotStream.Unsubscribe(deviceID, ch)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	clientGone := c.Request.Context().Done()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case frame, ok := <-ch:
+			if !ok {
+				return false
+			}
+			c.SSEvent("screenshot", frame)
+			return true
+		case <-clientGone:
+			return false
+		}
+	})
+}
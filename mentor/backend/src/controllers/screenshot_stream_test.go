@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestScreenshotHubPublishSubscribe(t *testing.T) {
+	deviceID := uuid.New()
+
+	ch := screenshotStream.Subscribe(deviceID)
+	defer screenshotStream.Unsubscribe(deviceID, ch)
+
+	screenshotStream.Publish(deviceID, gin.H{"path": "frame.png"})
+
+	select {
+	case frame := <-ch:
+		if frame["path"] != "frame.png" {
+			t.Errorf("expected frame path 'frame.png', got %v", frame["path"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a frame within 1s")
+	}
+
+	// Frames for other devices must not reach this subscriber
+	screenshotStream.Publish(uuid.New(), gin.H{"path": "other.png"})
+	select {
+	case frame := <-ch:
+		t.Errorf("received frame for another device: %v", frame)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStoreScreenshotEmitsToSubscriber(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	ch := screenshotStream.Subscribe(deviceID)
+	defer screenshotStream.Unsubscribe(deviceID, ch)
+
+	screenshot := models.DeviceScreenshot{
+		DeviceID:   deviceID,
+		Path:       "live/frame-1.png",
+		Resolution: "1920x1080",
+		Size:       2048,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	b, _ := json.Marshal(screenshot)
+	c.Request, _ = http.NewRequest("POST", "/devices/screenshots", io.NopCloser(bytes.NewReader(b)))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	StoreScreenshot(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	select {
+	case frame := <-ch:
+		if frame["path"] != "live/frame-1.png" {
+			t.Errorf("expected frame path 'live/frame-1.png', got %v", frame["path"])
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a frame after storing a screenshot")
+	}
+}
+
+func TestStreamDeviceScreenshotsInvalidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "not-a-uuid"}}
+	c.Request, _ = http.NewRequest("GET", "/devices/not-a-uuid/screenshots/stream", nil)
+
+	StreamDeviceScreenshots(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid device ID, got %d", w.Code)
+	}
+}
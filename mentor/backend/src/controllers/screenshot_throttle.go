@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// screenshotMinInterval returns the minimum spacing between stored screenshots
+// per device, from SCREENSHOT_MIN_INTERVAL_SECONDS. Zero (the default)
+// disables throttling so agents keep their existing behavior until a
+// deployment opts in.
+func screenshotMinInterval() time.Duration {
+	if raw := os.Getenv("SCREENSHOT_MIN_INTERVAL_SECONDS"); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return 0
+}
+
+// screenshotDedupEnabled reports whether identical consecutive captures (same
+// content checksum) are collapsed into one stored screenshot (SCREENSHOT_DEDUP
+// env). Off by default.
+func screenshotDedupEnabled() bool {
+	v := strings.ToLower(os.Getenv("SCREENSHOT_DEDUP"))
+	return v == "1" || v == "true" || v == "yes"
+}
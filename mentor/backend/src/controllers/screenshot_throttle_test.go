@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func postScreenshot(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/screenshots", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	StoreScreenshot(c)
+	return w
+}
+
+func TestStoreScreenshotThrottled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_MIN_INTERVAL_SECONDS", "60")
+
+	deviceID := uuid.New()
+	body := `{"deviceid": "` + deviceID.String() + `", "path": "shots/one.png"}`
+
+	w := postScreenshot(t, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for first screenshot, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// Second screenshot within the interval is throttled and not stored
+	w = postScreenshot(t, `{"deviceid": "`+deviceID.String()+`", "path": "shots/two.png"}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429 for throttled screenshot, got %d", w.Code)
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count screenshots: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 stored screenshot, got %d", count)
+	}
+
+	// A different device is unaffected by this device's throttle
+	other := uuid.New()
+	w = postScreenshot(t, `{"deviceid": "`+other.String()+`", "path": "shots/other.png"}`)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for other device, got %d", w.Code)
+	}
+}
+
+func TestStoreScreenshotNoThrottleByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+	t.Setenv("SCREENSHOT_MIN_INTERVAL_SECONDS", "")
+
+	deviceID := uuid.New()
+	for i := 0; i < 2; i++ {
+		w := postScreenshot(t, `{"deviceid": "`+deviceID.String()+`", "path": "shots/any.png"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 with throttling off, got %d", w.Code)
+		}
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.DeviceScreenshot{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		t.Fatalf("failed to count screenshots: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected both screenshots stored with throttling off, got %d", count)
+	}
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// ScreenshotCommandText is the reserved command text agents interpret as
+	// "capture and upload a screenshot now".
+	ScreenshotCommandText = "__screenshot__"
+	// ScreenshotCommandPriority puts on-demand captures ahead of regular
+	// queued commands in the pending queue.
+	ScreenshotCommandPriority = 100
+)
+
+// TriggerScreenshot requests an immediate screenshot from a device
+// @Summary Trigger an on-demand screenshot
+// @Description Queue a high-priority screenshot command for the device and forward it through the regular command pipeline. Returns the created command so the caller can poll for the resulting screenshot.
+// @Tags screenshots
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Success 200 {object} models.DeviceRemoteCommand
+// @Failure 400 {object} map[string]string "Bad request - invalid device ID"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/screenshot [post]
+func TriggerScreenshot(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	var count int64
+	if err := database.DB.Model(&models.Device{}).
+		Where("deviceid = ?", deviceID).
+		Count(&count).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	if count == 0 {
+		RespondError(c, http.StatusNotFound, ErrCodeNotFound, "device not found")
+		return
+	}
+
+	if err := checkDeviceCapability(deviceID, CapabilityScreenshots); err != nil {
+		if errors.Is(err, errCapabilityMissing) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			RespondInternalError(c, err)
+		}
+		return
+	}
+
+	cmd := models.DeviceRemoteCommand{
+		CommandID:   uuid.New(),
+		DeviceID:    deviceID,
+		CommandText: ScreenshotCommandText,
+		Status:      "pending",
+		Priority:    ScreenshotCommandPriority,
+		CreatedAt:   time.Now(),
+	}
+	if err := database.DB.Create(&cmd).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Forward like any other command so agents that poll via the devices
+	// backend receive it without waiting for the next pending-queue fetch
+	if devicesAPIURL := os.Getenv("DEVICES_API_URL"); devicesAPIURL != "" {
+		go func() {
+			if err := forwardCommand(devicesAPIURL, &cmd); err != nil {
+				fmt.Printf("Error forwarding screenshot command to devices backend: %v\n", err)
+				recordForwardStatus(cmd.CommandID, ForwardStatusFailed)
+				return
+			}
+			recordForwardStatus(cmd.CommandID, ForwardStatusSent)
+		}()
+	}
+
+	c.JSON(http.StatusOK, cmd)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func triggerScreenshot(t *testing.T, deviceID string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID+"/screenshot", nil)
+
+	TriggerScreenshot(c)
+	return w
+}
+
+func TestTriggerScreenshotQueuesHighPriorityCommand(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	device := models.Device{DeviceID: uuid.New(), DeviceName: "shot-target", DeviceType: "laptop"}
+	if err := database.DB.Create(&device).Error; err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+
+	w := triggerScreenshot(t, device.DeviceID.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var cmd models.DeviceRemoteCommand
+	if err := json.Unmarshal(w.Body.Bytes(), &cmd); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if cmd.CommandText != ScreenshotCommandText {
+		t.Errorf("expected command text %q, got %q", ScreenshotCommandText, cmd.CommandText)
+	}
+	if cmd.Priority != ScreenshotCommandPriority {
+		t.Errorf("expected priority %d, got %d", ScreenshotCommandPriority, cmd.Priority)
+	}
+	if cmd.Status != "pending" {
+		t.Errorf("expected pending status, got %q", cmd.Status)
+	}
+
+	var stored models.DeviceRemoteCommand
+	if err := database.DB.Where("commandid = ?", cmd.CommandID).First(&stored).Error; err != nil {
+		t.Fatalf("failed to load stored command: %v", err)
+	}
+	if stored.Priority != ScreenshotCommandPriority {
+		t.Errorf("expected stored priority %d, got %d", ScreenshotCommandPriority, stored.Priority)
+	}
+}
+
+func TestTriggerScreenshotUnknownDevice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	if w := triggerScreenshot(t, uuid.New().String()); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown device, got %d", w.Code)
+	}
+	if w := triggerScreenshot(t, "not-a-uuid"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for malformed ID, got %d", w.Code)
+	}
+}
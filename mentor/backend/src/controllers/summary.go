@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FleetSummary holds the counts dashboards render as overview cards.
+type FleetSummary struct {
+	TotalDevices    int64 `json:"total_devices"`
+	OnlineDevices   int64 `json:"online_devices"`
+	OfflineDevices  int64 `json:"offline_devices"`
+	PendingCommands int64 `json:"pending_commands"`
+	// CriticalAlerts counts unacknowledged critical-level alerts raised in
+	// the last 24 hours.
+	CriticalAlerts int64 `json:"critical_alerts"`
+}
+
+// GetFleetSummary returns fleet-wide overview counts
+// @Summary Get fleet summary
+// @Description Return the overview counts dashboards need in one call: total/online/offline devices, pending commands, and critical alerts from the last 24 hours. Online status uses the same last_seen threshold as the device list so the numbers agree.
+// @Tags dashboard
+// @Produce json
+// @Success 200 {object} controllers.FleetSummary
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /summary [get]
+func GetFleetSummary(c *gin.Context) {
+	var summary FleetSummary
+	db := database.Read()
+
+	if err := db.Model(&models.Device{}).Count(&summary.TotalDevices).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	threshold := time.Now().Add(-deviceOfflineThreshold())
+	if err := db.Model(&models.Device{}).
+		Where("last_seen > ?", threshold).
+		Count(&summary.OnlineDevices).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	summary.OfflineDevices = summary.TotalDevices - summary.OnlineDevices
+
+	if err := db.Model(&models.DeviceRemoteCommand{}).
+		Where("status = ?", "pending").
+		Count(&summary.PendingCommands).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	if err := db.Model(&models.DeviceAlert{}).
+		Where("level = ? AND timestamp >= ? AND NOT acknowledged", "critical", time.Now().Add(-24*time.Hour)).
+		Count(&summary.CriticalAlerts).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
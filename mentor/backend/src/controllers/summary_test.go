@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func getFleetSummary(t *testing.T) FleetSummary {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/summary", nil)
+
+	GetFleetSummary(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var summary FleetSummary
+	if err := json.Unmarshal(w.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("failed to unmarshal summary: %v", err)
+	}
+	return summary
+}
+
+func TestGetFleetSummary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	online := models.Device{DeviceID: uuid.New(), DeviceName: "online", DeviceType: "server", LastSeen: time.Now()}
+	offline := models.Device{DeviceID: uuid.New(), DeviceName: "offline", DeviceType: "server", LastSeen: time.Now().Add(-time.Hour)}
+	for _, d := range []models.Device{online, offline} {
+		if err := database.DB.Create(&d).Error; err != nil {
+			t.Fatalf("failed to create device: %v", err)
+		}
+	}
+
+	commands := []models.DeviceRemoteCommand{
+		{CommandID: uuid.New(), DeviceID: online.DeviceID, CommandText: "uptime", Status: "pending"},
+		{CommandID: uuid.New(), DeviceID: online.DeviceID, CommandText: "uptime", Status: "completed"},
+	}
+	for _, cmd := range commands {
+		if err := database.DB.Create(&cmd).Error; err != nil {
+			t.Fatalf("failed to create command: %v", err)
+		}
+	}
+
+	alerts := []models.DeviceAlert{
+		{AlertID: uuid.New(), DeviceID: online.DeviceID, Timestamp: time.Now(), Level: "critical", AlertType: "cpu"},
+		{AlertID: uuid.New(), DeviceID: online.DeviceID, Timestamp: time.Now(), Level: "warning", AlertType: "cpu"},
+		{AlertID: uuid.New(), DeviceID: online.DeviceID, Timestamp: time.Now().Add(-48 * time.Hour), Level: "critical", AlertType: "cpu"},
+	}
+	for _, alert := range alerts {
+		if err := database.DB.Create(&alert).Error; err != nil {
+			t.Fatalf("failed to create alert: %v", err)
+		}
+	}
+
+	summary := getFleetSummary(t)
+	if summary.TotalDevices != 2 {
+		t.Errorf("expected 2 total devices, got %d", summary.TotalDevices)
+	}
+	if summary.OnlineDevices != 1 || summary.OfflineDevices != 1 {
+		t.Errorf("expected 1 online and 1 offline, got %d/%d", summary.OnlineDevices, summary.OfflineDevices)
+	}
+	if summary.PendingCommands != 1 {
+		t.Errorf("expected 1 pending command, got %d", summary.PendingCommands)
+	}
+	if summary.CriticalAlerts != 1 {
+		t.Errorf("expected 1 critical alert in the last 24h, got %d", summary.CriticalAlerts)
+	}
+}
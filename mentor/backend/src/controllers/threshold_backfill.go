@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BackfillThresholdAlerts re-evaluates stored metrics against current thresholds
+// @Summary Backfill threshold alerts
+// @Description Re-run the device's current thresholds over stored metrics in the given window and create alerts, marked backfilled, for historical breaches. Metrics already covered by a backfilled alert of the same type and timestamp are skipped, so repeating a backfill is safe.
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param from query string true "Window start (RFC3339)"
+// @Param to query string true "Window end (RFC3339)"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 404 {object} map[string]string "No thresholds configured for the device"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/thresholds/backfill [post]
+func BackfillThresholdAlerts(c *gin.Context) {
+	deviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid device ID")
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("from"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "from parameter is required (RFC3339)")
+		return
+	}
+	to, err := time.Parse(time.RFC3339, c.Query("to"))
+	if err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "to parameter is required (RFC3339)")
+		return
+	}
+	if !to.After(from) {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, "to must be after from")
+		return
+	}
+	if err := checkQueryRange(from, to, "MAX_QUERY_RANGE_READ"); err != nil {
+		RespondError(c, http.StatusBadRequest, ErrCodeValidation, err.Error())
+		return
+	}
+
+	var config models.DeviceConfig
+	if err := database.DB.Where("deviceid = ?", deviceID).First(&config).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			RespondError(c, http.StatusNotFound, ErrCodeNotFound, "no thresholds configured for this device")
+		} else {
+			RespondInternalError(c, err)
+		}
+		return
+	}
+
+	var metrics []models.DeviceMetric
+	if err := database.DB.
+		Where("deviceid = ? AND timestamp >= ? AND timestamp <= ?", deviceID, from, to).
+		Order("timestamp asc").
+		Find(&metrics).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	// Existing backfilled alerts in the window, keyed by type and timestamp,
+	// guard against duplicate backfills
+	var existing []models.DeviceAlert
+	if err := database.DB.
+		Where("deviceid = ? AND backfilled AND timestamp >= ? AND timestamp <= ?", deviceID, from, to).
+		Find(&existing).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+	seen := make(map[string]bool, len(existing))
+	for _, alert := range existing {
+		seen[alert.AlertType+"|"+alert.Timestamp.UTC().Format(time.RFC3339Nano)] = true
+	}
+
+	created, skipped := 0, 0
+	for _, metric := range metrics {
+		for _, breach := range thresholdBreaches(&metric, &config) {
+			key := breach.AlertType + "|" + metric.Timestamp.UTC().Format(time.RFC3339Nano)
+			if seen[key] {
+				skipped++
+				continue
+			}
+			alert := models.DeviceAlert{
+				AlertID:    uuid.New(),
+				DeviceID:   deviceID,
+				Timestamp:  metric.Timestamp,
+				Level:      "warning",
+				AlertType:  breach.AlertType,
+				Message:    fmt.Sprintf("backfilled: %s %.2f exceeded threshold %.2f", breach.AlertType, breach.Value, breach.Threshold),
+				Value:      breach.Value,
+				Threshold:  breach.Threshold,
+				Backfilled: true,
+			}
+			if err := database.DB.Create(&alert).Error; err != nil {
+				RespondInternalError(c, err)
+				return
+			}
+			seen[key] = true
+			created++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"evaluated": len(metrics),
+		"created":   created,
+		"skipped":   skipped,
+	})
+}
+
+// thresholdBreach is one metric value exceeding its configured threshold.
+type thresholdBreach struct {
+	AlertType string
+	Value     float64
+	Threshold float64
+}
+
+// thresholdBreaches evaluates a metric against the device's thresholds.
+// Thresholds set to zero are treated as unconfigured.
+func thresholdBreaches(metric *models.DeviceMetric, config *models.DeviceConfig) []thresholdBreach {
+	breaches := make([]thresholdBreach, 0)
+	if config.CPUThreshold > 0 && metric.CPUUsage > config.CPUThreshold {
+		breaches = append(breaches, thresholdBreach{"cpu", metric.CPUUsage, config.CPUThreshold})
+	}
+	if config.MemoryThreshold > 0 {
+		if usage := metric.GetMemoryUsagePercent(); usage > config.MemoryThreshold {
+			breaches = append(breaches, thresholdBreach{"memory", usage, config.MemoryThreshold})
+		}
+	}
+	if config.DiskThreshold > 0 {
+		if usage := metric.GetDiskUsagePercent(); usage > config.DiskThreshold {
+			breaches = append(breaches, thresholdBreach{"disk", usage, config.DiskThreshold})
+		}
+	}
+	return breaches
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func backfillThresholds(t *testing.T, deviceID uuid.UUID, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("POST", "/devices/"+deviceID.String()+"/thresholds/backfill"+query, nil)
+
+	BackfillThresholdAlerts(c)
+	return w
+}
+
+func TestBackfillThresholdAlerts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	config := models.DeviceConfig{
+		ConfigID: uuid.New(), DeviceID: deviceID,
+		CPUThreshold: 80, DiskThreshold: 90,
+	}
+	if err := database.DB.Create(&config).Error; err != nil {
+		t.Fatalf("failed to seed config: %v", err)
+	}
+
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	metrics := []models.DeviceMetric{
+		{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: base, CPUUsage: 90},                                                // cpu breach
+		{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: base.Add(time.Minute), CPUUsage: 50, DiskTotal: 100, DiskUsed: 95}, // disk breach
+		{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: base.Add(2 * time.Minute), CPUUsage: 10},                           // clean
+	}
+	for i := range metrics {
+		if err := database.DB.Create(&metrics[i]).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	window := "?from=" + base.Add(-time.Minute).UTC().Format(time.RFC3339) +
+		"&to=" + base.Add(10*time.Minute).UTC().Format(time.RFC3339)
+
+	w := backfillThresholds(t, deviceID, window)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]int
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp["evaluated"] != 3 || resp["created"] != 2 {
+		t.Errorf("expected 3 evaluated and 2 created, got %v", resp)
+	}
+
+	var alerts []models.DeviceAlert
+	if err := database.DB.Where("deviceid = ?", deviceID).
+		Order("timestamp asc").
+		Find(&alerts).Error; err != nil {
+		t.Fatalf("failed to list alerts: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+	if alerts[0].AlertType != "cpu" || !alerts[0].Backfilled {
+		t.Errorf("expected backfilled cpu alert first, got %+v", alerts[0])
+	}
+	if alerts[1].AlertType != "disk" || !alerts[1].Backfilled {
+		t.Errorf("expected backfilled disk alert second, got %+v", alerts[1])
+	}
+
+	// Re-running the same backfill creates nothing new
+	w = backfillThresholds(t, deviceID, window)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on rerun, got %d, body: %s", w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal rerun response: %v", err)
+	}
+	if resp["created"] != 0 || resp["skipped"] != 2 {
+		t.Errorf("expected rerun to skip 2 duplicates, got %v", resp)
+	}
+}
+
+func TestBackfillThresholdAlertsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	if w := backfillThresholds(t, deviceID, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without window, got %d", w.Code)
+	}
+
+	// Valid window but no configured thresholds
+	window := "?from=" + time.Now().Add(-time.Hour).UTC().Format(time.RFC3339) + "&to=" + now
+	if w := backfillThresholds(t, deviceID, window); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 without device config, got %d", w.Code)
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxQueryRange is the largest time window a ranged query may request
+// unless overridden via MAX_QUERY_RANGE (or a per-endpoint-type variable).
+// This protects Postgres from accidental full-table scans like from=2020&to=now.
+const DefaultMaxQueryRange = 30 * 24 * time.Hour
+
+// parseRangeDuration parses a duration string, additionally accepting a "d"
+// suffix for days (e.g. "30d") since retention windows are usually day-sized
+func parseRangeDuration(raw string) (time.Duration, error) {
+	if strings.HasSuffix(raw, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// maxQueryRange returns the configured range cap for an endpoint type,
+// checking the type-specific variable first, then the global MAX_QUERY_RANGE
+func maxQueryRange(typeEnv string) time.Duration {
+	for _, key := range []string{typeEnv, "MAX_QUERY_RANGE"} {
+		if raw := os.Getenv(key); raw != "" {
+			if parsed, err := parseRangeDuration(raw); err == nil && parsed > 0 {
+				return parsed
+			}
+		}
+	}
+	return DefaultMaxQueryRange
+}
+
+// checkQueryRange validates that a requested [from, to] window fits within the
+// cap for the endpoint type. A zero "to" means "now"; a zero "from" means the
+// query is unbounded and is rejected against the cap only when "to" is far
+// enough back to matter (unbounded queries without from are limited elsewhere).
+func checkQueryRange(from, to time.Time, typeEnv string) error {
+	if from.IsZero() {
+		return nil
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	limit := maxQueryRange(typeEnv)
+	if to.Sub(from) > limit {
+		return fmt.Errorf("requested range %s exceeds the maximum of %s; narrow the window or use the aggregate endpoint",
+			to.Sub(from).Round(time.Minute), limit)
+	}
+	return nil
+}
+
+// parseLastParam parses the optional relative "last" query parameter (e.g. 1h,
+// 30m) and returns the cutoff time it expands to. Expanding on the server
+// avoids clock skew between browser and server. "last" is mutually exclusive
+// with explicit "from"/"to" parameters; supplying both is an error.
+func parseLastParam(c *gin.Context) (time.Time, bool, error) {
+	last := c.Query("last")
+	if last == "" {
+		return time.Time{}, false, nil
+	}
+
+	if c.Query("from") != "" || c.Query("to") != "" {
+		return time.Time{}, false, fmt.Errorf("last cannot be combined with from/to")
+	}
+
+	window, err := time.ParseDuration(last)
+	if err != nil || window <= 0 {
+		return time.Time{}, false, fmt.Errorf("invalid last parameter: must be a positive duration like 1h or 30m")
+	}
+
+	if limit := maxQueryRange("MAX_QUERY_RANGE_READ"); window > limit {
+		return time.Time{}, false, fmt.Errorf("requested range %s exceeds the maximum of %s; narrow the window or use the aggregate endpoint", window, limit)
+	}
+
+	return time.Now().Add(-window), true, nil
+}
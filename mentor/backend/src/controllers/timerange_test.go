@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetDeviceMetricLastWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	now := time.Now()
+
+	// One metric inside the last hour, one well outside it
+	recent := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-10 * time.Minute), CPUUsage: 42}
+	stale := models.DeviceMetric{MetricID: uuid.New(), DeviceID: deviceID, Timestamp: now.Add(-3 * time.Hour), CPUUsage: 13}
+	for _, m := range []models.DeviceMetric{recent, stale} {
+		if err := database.DB.Create(&m).Error; err != nil {
+			t.Fatalf("failed to seed metric: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/metrics?last=1h", nil)
+
+	GetDeviceMetric(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	var metrics []models.DeviceMetric
+	if err := json.Unmarshal(w.Body.Bytes(), &metrics); err != nil {
+		t.Fatalf("failed to unmarshal metrics: %v", err)
+	}
+	if len(metrics) != 1 {
+		t.Fatalf("expected 1 metric within the hour, got %d", len(metrics))
+	}
+	if metrics[0].MetricID != recent.MetricID {
+		t.Errorf("expected the recent metric, got %s", metrics[0].MetricID)
+	}
+}
+
+func TestLastParamMutuallyExclusiveWithFrom(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/metrics?last=1h&from=2024-01-01T00:00:00Z", nil)
+
+	GetDeviceMetric(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 when last is combined with from, got %d", w.Code)
+	}
+}
+
+func TestLastParamInvalidDuration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/alerts?last=one-hour", nil)
+
+	GetDeviceAlert(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid last duration, got %d", w.Code)
+	}
+}
+
+func TestMaxQueryRangeRejectsOverlongWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Default cap is 30 days; ask for roughly a year
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: uuid.New().String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/x/metrics?last=8760h", nil)
+
+	GetDeviceMetric(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for over-long last window, got %d", w.Code)
+	}
+}
+
+func TestMaxQueryRangeConfigurable(t *testing.T) {
+	t.Setenv("MAX_QUERY_RANGE", "90d")
+	if got := maxQueryRange("MAX_QUERY_RANGE_READ"); got != 90*24*time.Hour {
+		t.Errorf("expected 90d cap from MAX_QUERY_RANGE, got %v", got)
+	}
+
+	// Per-endpoint-type override wins over the global setting
+	t.Setenv("MAX_QUERY_RANGE_READ", "12h")
+	if got := maxQueryRange("MAX_QUERY_RANGE_READ"); got != 12*time.Hour {
+		t.Errorf("expected 12h cap from MAX_QUERY_RANGE_READ, got %v", got)
+	}
+}
+
+func TestCheckQueryRange(t *testing.T) {
+	now := time.Now()
+
+	if err := checkQueryRange(now.Add(-time.Hour), now, "MAX_QUERY_RANGE_READ"); err != nil {
+		t.Errorf("expected 1h range to pass, got: %v", err)
+	}
+	if err := checkQueryRange(now.Add(-60*24*time.Hour), now, "MAX_QUERY_RANGE_READ"); err == nil {
+		t.Error("expected 60d range to exceed the default 30d cap")
+	}
+	// A from without to is measured against now
+	if err := checkQueryRange(now.Add(-60*24*time.Hour), time.Time{}, "MAX_QUERY_RANGE_READ"); err == nil {
+		t.Error("expected open-ended 60d range to exceed the default cap")
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"mentor-backend/database"
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// trackUserSession reconciles the device's session history with the user seen
+// on the latest registration heartbeat. When the user changes, the open
+// session (if any) is closed and a new one started; an unchanged user leaves
+// the open session running. An empty user only closes the open session.
+func trackUserSession(deviceID uuid.UUID, user string) error {
+	var open models.UserSession
+	err := database.DB.Where("deviceid = ? AND ended_at IS NULL", deviceID).
+		Order("started_at desc").
+		Limit(1).
+		Find(&open).Error
+	if err != nil {
+		return err
+	}
+
+	hasOpen := open.SessionID != uuid.Nil
+	if hasOpen && open.User == user {
+		return nil
+	}
+
+	now := time.Now()
+	if hasOpen {
+		if err := database.DB.Model(&open).Update("ended_at", now).Error; err != nil {
+			return err
+		}
+	}
+	if user == "" {
+		return nil
+	}
+
+	session := models.UserSession{
+		SessionID: uuid.New(),
+		DeviceID:  deviceID,
+		User:      user,
+		StartedAt: now,
+	}
+	return database.DB.Create(&session).Error
+}
+
+// GetDeviceSessions returns the user session history for a device
+// @Summary Get device user sessions
+// @Description List who was logged in on the device and when, newest first. Sessions are derived from CurrentUser changes at registration heartbeats; an open session has no ended_at.
+// @Tags devices
+// @Produce json
+// @Param id path string true "Device ID (UUID)"
+// @Param limit query int false "Number of records to return" default(100)
+// @Success 200 {array} models.UserSession
+// @Failure 400 {object} map[string]string "Bad request - invalid limit parameter"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /devices/{id}/sessions [get]
+func GetDeviceSessions(c *gin.Context) {
+	limit := 100
+	if l := c.Query("limit"); l != "" {
+		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil || limit <= 0 {
+			RespondError(c, http.StatusBadRequest, ErrCodeValidation, "invalid limit parameter")
+			return
+		}
+	}
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		c.JSON(http.StatusOK, []models.UserSession{})
+		return
+	}
+	deviceID := c.Param("id")
+
+	sessions := make([]models.UserSession, 0)
+	if err := database.Read().Where("deviceid = ?", deviceID).
+		Order("started_at desc").
+		Limit(limit).
+		Find(&sessions).Error; err != nil {
+		RespondInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mentor-backend/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func registerDeviceJSON(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/devices/register", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	RegisterDevice(c)
+	return w
+}
+
+func getDeviceSessions(t *testing.T, deviceID uuid.UUID) []models.UserSession {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: deviceID.String()}}
+	c.Request, _ = http.NewRequest("GET", "/devices/"+deviceID.String()+"/sessions", nil)
+
+	GetDeviceSessions(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d, body: %s", w.Code, w.Body.String())
+	}
+	var sessions []models.UserSession
+	if err := json.Unmarshal(w.Body.Bytes(), &sessions); err != nil {
+		t.Fatalf("failed to unmarshal sessions: %v", err)
+	}
+	return sessions
+}
+
+func TestUserSessionTracking(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestDB(t)
+
+	deviceID := uuid.New()
+	register := func(user string) {
+		body := `{"deviceid":"` + deviceID.String() + `","device_name":"box","device_type":"server","current_user":"` + user + `"}`
+		if w := registerDeviceJSON(t, body); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 registering, got %d, body: %s", w.Code, w.Body.String())
+		}
+	}
+
+	register("alice")
+	sessions := getDeviceSessions(t, deviceID)
+	if len(sessions) != 1 || sessions[0].User != "alice" || sessions[0].EndedAt != nil {
+		t.Fatalf("expected one open session for alice, got %+v", sessions)
+	}
+
+	// Same user again: the open session keeps running.
+	register("alice")
+	if sessions = getDeviceSessions(t, deviceID); len(sessions) != 1 {
+		t.Fatalf("expected repeated heartbeat to not create sessions, got %d", len(sessions))
+	}
+
+	// User change: alice's session closes, bob's opens.
+	register("bob")
+	sessions = getDeviceSessions(t, deviceID)
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions after user change, got %d", len(sessions))
+	}
+	if sessions[0].User != "bob" || sessions[0].EndedAt != nil {
+		t.Errorf("expected open session for bob, got %+v", sessions[0])
+	}
+	if sessions[1].User != "alice" || sessions[1].EndedAt == nil {
+		t.Errorf("expected alice's session closed, got %+v", sessions[1])
+	}
+}
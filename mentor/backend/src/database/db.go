@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
@@ -19,6 +20,24 @@ import (
 
 var DB *gorm.DB
 
+// replicaDB is an optional read-replica connection. Nil unless
+// POSTGRES_REPLICA_HOST is configured; reads fall back to the primary.
+var replicaDB *gorm.DB
+
+// Read returns the connection read-only queries should use: the replica when
+// one is configured, otherwise the primary. Writes always go through DB.
+func Read() *gorm.DB {
+	if replicaDB != nil {
+		return replicaDB
+	}
+	return DB
+}
+
+// SetReplica overrides the replica connection; used by tests.
+func SetReplica(db *gorm.DB) {
+	replicaDB = db
+}
+
 // Configuration constants
 const (
 	// MinPasswordLength is the minimum required password length
@@ -149,21 +168,56 @@ func connectWithConfig() error {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
 
-	// Set connection pool parameters from environment or use sensible defaults
-	maxOpenConns := getEnvInt("DB_MAX_OPEN_CONNS", 25)
-	maxIdleConns := getEnvInt("DB_MAX_IDLE_CONNS", 5)
-	connMaxLifetime := getEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", 5)
-	connMaxIdleTime := getEnvInt("DB_CONN_MAX_IDLE_MINUTES", 5)
+	settings := poolSettingsFromEnv()
+	settings.apply(sqlDB)
 
-	sqlDB.SetMaxOpenConns(maxOpenConns)
-	sqlDB.SetMaxIdleConns(maxIdleConns)
-	sqlDB.SetConnMaxLifetime(time.Duration(connMaxLifetime) * time.Minute)
-	sqlDB.SetConnMaxIdleTime(time.Duration(connMaxIdleTime) * time.Minute)
-
-	log.Printf("Database connection successful (pool: max_open=%d, max_idle=%d)", maxOpenConns, maxIdleConns)
+	log.Printf("Database connection successful (pool: max_open=%d, max_idle=%d, conn_max_lifetime=%s, conn_max_idle_time=%s)",
+		settings.MaxOpenConns, settings.MaxIdleConns, settings.ConnMaxLifetime, settings.ConnMaxIdleTime)
 	return nil
 }
 
+// poolSettings holds the effective connection pool configuration.
+type poolSettings struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// poolSettingsFromEnv reads the connection pool parameters from the
+// environment with sensible defaults, and clamps the idle count to the open
+// count since sql.DB would silently do the same.
+func poolSettingsFromEnv() poolSettings {
+	settings := poolSettings{
+		MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxIdleTime: time.Duration(getEnvInt("DB_CONN_MAX_IDLE_MINUTES", 5)) * time.Minute,
+	}
+
+	// Lifetime is configured in seconds; the older minutes variable is still
+	// honored so existing deployments keep working.
+	if os.Getenv("DB_CONN_MAX_LIFETIME_SECONDS") != "" {
+		settings.ConnMaxLifetime = time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_SECONDS", 300)) * time.Second
+	} else {
+		settings.ConnMaxLifetime = time.Duration(getEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", 5)) * time.Minute
+	}
+
+	if settings.MaxIdleConns > settings.MaxOpenConns {
+		log.Printf("Warning: DB_MAX_IDLE_CONNS (%d) exceeds DB_MAX_OPEN_CONNS (%d), clamping to the open limit",
+			settings.MaxIdleConns, settings.MaxOpenConns)
+		settings.MaxIdleConns = settings.MaxOpenConns
+	}
+	return settings
+}
+
+// apply pushes the settings onto the underlying connection pool.
+func (s poolSettings) apply(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(s.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(s.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(s.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(s.ConnMaxIdleTime)
+}
+
 // connectWithRetry attempts to connect with exponential backoff retry logic.
 // This function implements resilience against transient database failures during startup,
 // such as when the database container is still initializing or during network issues.
@@ -207,6 +261,41 @@ func Connect() {
 	if err := migrate(DB); err != nil {
 		log.Fatalf("Database migration failed: %v", err)
 	}
+
+	// Attach the read replica, if configured. Failures are non-fatal: reads
+	// fall back to the primary connection.
+	connectReplica()
+}
+
+// connectReplica opens a second connection against POSTGRES_REPLICA_HOST so
+// dashboard reads stop contending with ingest writes on the primary. The
+// replica shares the primary's credentials and database name; the port can be
+// overridden with POSTGRES_REPLICA_PORT.
+func connectReplica() {
+	host := os.Getenv("POSTGRES_REPLICA_HOST")
+	if host == "" {
+		return
+	}
+
+	port := os.Getenv("POSTGRES_REPLICA_PORT")
+	if port == "" {
+		port = os.Getenv("POSTGRES_PORT")
+	}
+
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+		host, os.Getenv("POSTGRES_USER"), os.Getenv("POSTGRES_PASSWORD"), os.Getenv("POSTGRES_DB"), port)
+
+	replica, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		DisableForeignKeyConstraintWhenMigrating: true,
+		Logger:                                   logger.Default.LogMode(logger.Info),
+	})
+	if err != nil {
+		log.Printf("Warning: read replica connection failed, reads will use the primary: %v", err)
+		return
+	}
+
+	replicaDB = replica
+	log.Printf("Read replica connected: %s", host)
 }
 
 // HealthCheck verifies the database connection is alive and responsive.
@@ -264,11 +353,22 @@ func migrate(db *gorm.DB) error {
 	steps := []interface{}{
 		&models.Device{},
 		&models.DeviceMetric{},
+		&models.DeviceMetricRollup{},
 		&models.DeviceProcess{},
 		&models.DeviceActivity{},
 		&models.DeviceRemoteCommand{},
 		&models.DeviceScreenshot{},
 		&models.DeviceAlert{},
+		&models.DeviceLog{},
+		&models.DeviceConfig{},
+		&models.DeviceNote{},
+		&models.DeviceToken{},
+		&models.DeviceInventory{},
+		&models.CommandArchive{},
+		&models.UserSession{},
+		&models.DeviceDailyReport{},
+		&models.DeviceGroup{},
+		&models.DeviceGroupMember{},
 		&models.User{},
 	}
 	log.Println("Running database migrations...")
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolSettingsDefaults(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "")
+	t.Setenv("DB_MAX_IDLE_CONNS", "")
+	t.Setenv("DB_CONN_MAX_LIFETIME_SECONDS", "")
+	t.Setenv("DB_CONN_MAX_LIFETIME_MINUTES", "")
+
+	settings := poolSettingsFromEnv()
+	assert.Equal(t, 25, settings.MaxOpenConns)
+	assert.Equal(t, 5, settings.MaxIdleConns)
+	assert.Equal(t, 5*time.Minute, settings.ConnMaxLifetime)
+}
+
+func TestPoolSettingsFromEnv(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "40")
+	t.Setenv("DB_MAX_IDLE_CONNS", "10")
+	t.Setenv("DB_CONN_MAX_LIFETIME_SECONDS", "120")
+
+	settings := poolSettingsFromEnv()
+	assert.Equal(t, 40, settings.MaxOpenConns)
+	assert.Equal(t, 10, settings.MaxIdleConns)
+	assert.Equal(t, 2*time.Minute, settings.ConnMaxLifetime)
+}
+
+func TestPoolSettingsClampsIdleToOpen(t *testing.T) {
+	t.Setenv("DB_MAX_OPEN_CONNS", "4")
+	t.Setenv("DB_MAX_IDLE_CONNS", "10")
+
+	settings := poolSettingsFromEnv()
+	assert.Equal(t, 4, settings.MaxOpenConns)
+	assert.Equal(t, 4, settings.MaxIdleConns, "idle connections must not exceed the open limit")
+}
+
+func TestPoolSettingsAppliedToSQLDB(t *testing.T) {
+	db, err := SetupTestDB(t)
+	if err != nil {
+		t.Skipf("Skipping test - database not available: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database instance: %v", err)
+	}
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "7")
+	t.Setenv("DB_MAX_IDLE_CONNS", "3")
+	t.Setenv("DB_CONN_MAX_LIFETIME_SECONDS", "60")
+
+	poolSettingsFromEnv().apply(sqlDB)
+
+	// MaxOpenConnections is the only setting sql.DB reports back
+	assert.Equal(t, 7, sqlDB.Stats().MaxOpenConnections)
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestReadFallsBackToPrimary(t *testing.T) {
+	originalDB := DB
+	originalReplica := replicaDB
+	t.Cleanup(func() {
+		DB = originalDB
+		replicaDB = originalReplica
+	})
+
+	primary := &gorm.DB{}
+	DB = primary
+	SetReplica(nil)
+
+	if Read() != primary {
+		t.Error("expected Read() to return the primary when no replica is configured")
+	}
+}
+
+func TestReadUsesReplicaWhenConfigured(t *testing.T) {
+	originalDB := DB
+	originalReplica := replicaDB
+	t.Cleanup(func() {
+		DB = originalDB
+		replicaDB = originalReplica
+	})
+
+	primary := &gorm.DB{}
+	replica := &gorm.DB{}
+	DB = primary
+	SetReplica(replica)
+
+	if Read() != replica {
+		t.Error("expected Read() to return the replica when one is configured")
+	}
+}
+
+func TestConnectReplicaNoopWithoutHost(t *testing.T) {
+	originalReplica := replicaDB
+	t.Cleanup(func() { replicaDB = originalReplica })
+
+	t.Setenv("POSTGRES_REPLICA_HOST", "")
+	SetReplica(nil)
+	connectReplica()
+	if replicaDB != nil {
+		t.Error("expected no replica connection without POSTGRES_REPLICA_HOST")
+	}
+}
@@ -106,6 +106,11 @@ func SetupTestDB(t *testing.T, config ...DBConfig) (*gorm.DB, error) {
 			return
 		}
 
+		migrationError = baseConnection.AutoMigrate(&models.DeviceMetricRollup{})
+		if migrationError != nil {
+			return
+		}
+
 		migrationError = baseConnection.AutoMigrate(&models.DeviceProcess{})
 		if migrationError != nil {
 			return
@@ -131,6 +136,56 @@ func SetupTestDB(t *testing.T, config ...DBConfig) (*gorm.DB, error) {
 			return
 		}
 
+		migrationError = baseConnection.AutoMigrate(&models.DeviceLog{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceConfig{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceNote{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceGroup{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceToken{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceInventory{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.CommandArchive{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.UserSession{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceDailyReport{})
+		if migrationError != nil {
+			return
+		}
+
+		migrationError = baseConnection.AutoMigrate(&models.DeviceGroupMember{})
+		if migrationError != nil {
+			return
+		}
+
 		migrationError = baseConnection.AutoMigrate(&models.User{})
 	})
 
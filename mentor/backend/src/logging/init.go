@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// degradedState records whether structured logging is running in fallback
+// mode (stdout, stdlib formatting defaults) because initialization failed.
+var degradedState struct {
+	mu     sync.Mutex
+	reason string
+}
+
+func setDegraded(reason string) {
+	degradedState.mu.Lock()
+	defer degradedState.mu.Unlock()
+	degradedState.reason = reason
+}
+
+// Degraded reports whether the logger failed to initialize as configured and
+// why. A degraded logger still works — it falls back to stdout — but
+// operators should know structured output is not going where they pointed it.
+func Degraded() (bool, string) {
+	degradedState.mu.Lock()
+	defer degradedState.mu.Unlock()
+	return degradedState.reason != "", degradedState.reason
+}
+
+// InitLogger rebuilds the default logger from environment configuration.
+// With LOG_FILE set, structured output is appended to that file; an open
+// failure keeps the stdout fallback from package init, marks logging as
+// degraded so /admin/config surfaces it, and returns the error so the caller
+// can schedule a retry.
+func InitLogger() error {
+	path := os.Getenv("LOG_FILE")
+	if path == "" {
+		// Stdout is the configured target; nothing to fail.
+		setDegraded("")
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		setDegraded(fmt.Sprintf("falling back to stdout: %v", err))
+		return err
+	}
+
+	levelStr := os.Getenv("LOG_LEVEL")
+	if levelStr == "" {
+		levelStr = "INFO"
+	}
+	defaultLogger = NewLogger(Config{
+		Level:      levelStr,
+		JSONFormat: os.Getenv("LOG_FORMAT") == "json",
+		Output:     file,
+	})
+	setDegraded("")
+	return nil
+}
+
+// StartInitRetry re-attempts InitLogger on the given interval until it
+// succeeds, so a log target that was briefly unavailable at boot (e.g. a
+// volume still mounting) is picked up without a restart. Returns a stop
+// function for shutdown.
+func StartInitRetry(interval time.Duration) func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := InitLogger(); err == nil {
+					Info("Structured logging recovered")
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
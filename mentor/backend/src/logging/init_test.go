@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInitLoggerDegradesOnFailure(t *testing.T) {
+	prev := defaultLogger
+	t.Cleanup(func() {
+		defaultLogger = prev
+		setDegraded("")
+	})
+
+	t.Setenv("LOG_FILE", filepath.Join(t.TempDir(), "missing-subdir", "app.log"))
+	if err := InitLogger(); err == nil {
+		t.Fatal("expected InitLogger to fail for an unwritable path")
+	}
+	degraded, reason := Degraded()
+	if !degraded || reason == "" {
+		t.Errorf("expected degraded state with a reason, got degraded=%v reason=%q", degraded, reason)
+	}
+
+	// A working target clears the degraded state.
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_FILE", path)
+	if err := InitLogger(); err != nil {
+		t.Fatalf("expected InitLogger to succeed, got %v", err)
+	}
+	if degraded, _ := Degraded(); degraded {
+		t.Error("expected degraded state cleared after successful init")
+	}
+	Info("structured logging test entry")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("expected the log entry written to the configured file")
+	}
+}
+
+func TestInitLoggerNoTargetIsNotDegraded(t *testing.T) {
+	t.Setenv("LOG_FILE", "")
+	if err := InitLogger(); err != nil {
+		t.Fatalf("expected stdout config to succeed, got %v", err)
+	}
+	if degraded, _ := Degraded(); degraded {
+		t.Error("expected stdout logging to not count as degraded")
+	}
+}
@@ -141,9 +141,19 @@ func (l *Logger) WithField(key string, value interface{}) *Logger {
 // log writes a log message at the given level
 func (l *Logger) log(level LogLevel, message string, fields map[string]interface{}) {
 	if level < l.level {
+		statDroppedByLevel.Add(1)
 		return
 	}
 
+	// Optional sampling keeps one in every N sub-WARNING messages; warnings
+	// and errors are never sampled away
+	if n := sampleEveryN(); n > 0 && level < WARNING {
+		if sampleCounter.Add(1)%n != 1 {
+			statDroppedBySampling.Add(1)
+			return
+		}
+	}
+
 	// Merge logger fields with message fields
 	allFields := make(map[string]interface{})
 	for k, v := range l.fields {
@@ -190,8 +200,11 @@ func (l *Logger) logJSON(level LogLevel, message string, fields map[string]inter
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if _, err := fmt.Fprintln(l.output, string(data)); err != nil {
+		statWriteErrors.Add(1)
 		log.Printf("Failed to write log entry: %v", err)
+		return
 	}
+	statEmitted[level].Add(1)
 }
 
 // logText writes a log message in human-readable text format
@@ -220,8 +233,11 @@ func (l *Logger) logText(level LogLevel, message string, fields map[string]inter
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if _, err := fmt.Fprintln(l.output, sb.String()); err != nil {
+		statWriteErrors.Add(1)
 		log.Printf("Failed to write log entry: %v", err)
+		return
 	}
+	statEmitted[level].Add(1)
 }
 
 // Debug logs a debug message
@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// Internal counters for the logger itself, so operators can tell whether
+// messages are being dropped by the level threshold or by sampling. All
+// counters are process-wide: derived loggers (WithFields) share them.
+var (
+	statEmitted           [4]atomic.Uint64 // indexed by LogLevel
+	statDroppedByLevel    atomic.Uint64
+	statDroppedBySampling atomic.Uint64
+	statWriteErrors       atomic.Uint64
+	sampleCounter         atomic.Uint64
+)
+
+// LoggerStats is a point-in-time snapshot of the logger's internal counters.
+type LoggerStats struct {
+	EmittedByLevel    map[string]uint64 `json:"emitted_by_level"`
+	DroppedByLevel    uint64            `json:"dropped_by_level"`
+	DroppedBySampling uint64            `json:"dropped_by_sampling"`
+	WriteErrors       uint64            `json:"write_errors"`
+}
+
+// Stats returns a snapshot of the logger's internal counters.
+func Stats() LoggerStats {
+	emitted := make(map[string]uint64, len(statEmitted))
+	for level := DEBUG; level <= ERROR; level++ {
+		emitted[level.String()] = statEmitted[level].Load()
+	}
+	return LoggerStats{
+		EmittedByLevel:    emitted,
+		DroppedByLevel:    statDroppedByLevel.Load(),
+		DroppedBySampling: statDroppedBySampling.Load(),
+		WriteErrors:       statWriteErrors.Load(),
+	}
+}
+
+// resetStats zeroes all counters; used by tests.
+func resetStats() {
+	for i := range statEmitted {
+		statEmitted[i].Store(0)
+	}
+	statDroppedByLevel.Store(0)
+	statDroppedBySampling.Store(0)
+	statWriteErrors.Store(0)
+	sampleCounter.Store(0)
+}
+
+// sampleEveryN returns the sampling rate from LOG_SAMPLE_EVERY_N: keep one in
+// every N sub-WARNING messages. Values below 2 (the default) disable sampling.
+func sampleEveryN() uint64 {
+	if raw := os.Getenv("LOG_SAMPLE_EVERY_N"); raw != "" {
+		if n, err := strconv.ParseUint(raw, 10, 64); err == nil && n > 1 {
+			return n
+		}
+	}
+	return 0
+}
+
+// WritePrometheus renders the logger counters in Prometheus text exposition
+// format for the /metrics endpoint.
+func WritePrometheus(w io.Writer) {
+	stats := Stats()
+	fmt.Fprintln(w, "# HELP logging_messages_emitted_total Log messages written, by level.")
+	fmt.Fprintln(w, "# TYPE logging_messages_emitted_total counter")
+	for level := DEBUG; level <= ERROR; level++ {
+		fmt.Fprintf(w, "logging_messages_emitted_total{level=%q} %d\n",
+			level.String(), stats.EmittedByLevel[level.String()])
+	}
+	fmt.Fprintln(w, "# HELP logging_messages_dropped_total Log messages dropped, by reason.")
+	fmt.Fprintln(w, "# TYPE logging_messages_dropped_total counter")
+	fmt.Fprintf(w, "logging_messages_dropped_total{reason=\"level\"} %d\n", stats.DroppedByLevel)
+	fmt.Fprintf(w, "logging_messages_dropped_total{reason=\"sampling\"} %d\n", stats.DroppedBySampling)
+	fmt.Fprintln(w, "# HELP logging_write_errors_total Failures writing log entries to the output.")
+	fmt.Fprintln(w, "# TYPE logging_write_errors_total counter")
+	fmt.Fprintf(w, "logging_write_errors_total %d\n", stats.WriteErrors)
+}
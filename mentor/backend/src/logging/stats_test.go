@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestStatsCountEmittedAndDropped(t *testing.T) {
+	resetStats()
+	t.Cleanup(resetStats)
+
+	var buf bytes.Buffer
+	logger := NewLogger(Config{Level: "INFO", Output: &buf})
+
+	logger.Debug("dropped by level")
+	logger.Info("emitted info")
+	logger.Warning("emitted warning")
+	logger.Error("emitted error")
+
+	stats := Stats()
+	if stats.DroppedByLevel != 1 {
+		t.Errorf("expected 1 dropped-by-level, got %d", stats.DroppedByLevel)
+	}
+	if stats.EmittedByLevel["INFO"] != 1 || stats.EmittedByLevel["WARNING"] != 1 || stats.EmittedByLevel["ERROR"] != 1 {
+		t.Errorf("unexpected emitted counts: %v", stats.EmittedByLevel)
+	}
+	if stats.EmittedByLevel["DEBUG"] != 0 {
+		t.Errorf("expected no emitted DEBUG messages, got %d", stats.EmittedByLevel["DEBUG"])
+	}
+}
+
+func TestStatsCountSamplingDrops(t *testing.T) {
+	resetStats()
+	t.Cleanup(resetStats)
+	t.Setenv("LOG_SAMPLE_EVERY_N", "2")
+
+	var buf bytes.Buffer
+	logger := NewLogger(Config{Level: "DEBUG", Output: &buf})
+
+	logger.Info("kept")
+	logger.Info("sampled away")
+	logger.Error("never sampled")
+
+	stats := Stats()
+	if stats.EmittedByLevel["INFO"] != 1 {
+		t.Errorf("expected 1 emitted INFO, got %d", stats.EmittedByLevel["INFO"])
+	}
+	if stats.DroppedBySampling != 1 {
+		t.Errorf("expected 1 dropped-by-sampling, got %d", stats.DroppedBySampling)
+	}
+	if stats.EmittedByLevel["ERROR"] != 1 {
+		t.Error("errors must never be sampled away")
+	}
+}
+
+func TestStatsCountWriteErrors(t *testing.T) {
+	resetStats()
+	t.Cleanup(resetStats)
+
+	logger := NewLogger(Config{Level: "INFO", Output: failingWriter{}})
+	logger.Info("goes nowhere")
+
+	stats := Stats()
+	if stats.WriteErrors != 1 {
+		t.Errorf("expected 1 write error, got %d", stats.WriteErrors)
+	}
+	if stats.EmittedByLevel["INFO"] != 0 {
+		t.Errorf("failed writes must not count as emitted, got %d", stats.EmittedByLevel["INFO"])
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	resetStats()
+	t.Cleanup(resetStats)
+
+	var logBuf bytes.Buffer
+	logger := NewLogger(Config{Level: "INFO", Output: &logBuf})
+	logger.Info("one")
+
+	var out strings.Builder
+	WritePrometheus(&out)
+	rendered := out.String()
+	if !strings.Contains(rendered, `logging_messages_emitted_total{level="INFO"} 1`) {
+		t.Errorf("expected emitted counter in output, got:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, `logging_messages_dropped_total{reason="sampling"} 0`) {
+		t.Errorf("expected sampling drop counter in output, got:\n%s", rendered)
+	}
+}
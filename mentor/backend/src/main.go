@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"mentor-backend/controllers"
 	"mentor-backend/database"
 	"mentor-backend/logging"
 	"mentor-backend/router"
@@ -40,8 +41,9 @@ import (
 // @description It provides query endpoints for frontends and management dashboards.
 // @description
 // @description ## Authentication
-// @description Currently, the API does not require authentication.
-// @description Authentication and authorization will be added in future releases.
+// @description When the API_KEYS environment variable is set, requests must carry a matching
+// @description X-API-Key header; health endpoints stay open for probes. Unset, the API is open
+// @description for local development.
 
 // @contact.name API Support
 // @contact.url https://github.com/mj-nehme/raqeem
@@ -99,6 +101,15 @@ func (a *App) setupRouter() *gin.Engine {
 func (a *App) Start() error {
 	logging.Info("Starting Mentor Backend application")
 
+	// Structured logging: a failed init (e.g. unwritable LOG_FILE) falls back
+	// to stdout, is reported via /admin/config, and keeps retrying so the
+	// target is picked up once it becomes available
+	stopLoggerRetry := func() {}
+	if err := logging.InitLogger(); err != nil {
+		log.Printf("Structured logging degraded, using stdout: %v", err)
+		stopLoggerRetry = logging.StartInitRetry(time.Minute)
+	}
+
 	// Setup database
 	if err := a.setupDatabase(); err != nil {
 		logging.Error("Failed to setup database", map[string]interface{}{
@@ -111,9 +122,33 @@ func (a *App) Start() error {
 	logging.Info("Initializing MinIO client")
 	s3.InitClient()
 
+	// Run the startup self-check so misconfigured dependencies surface now
+	// rather than on the first request hours later
+	if err := SelfCheck(); err != nil {
+		if failFastEnabled() {
+			logging.Error("Refusing to start due to failed self-check (FAIL_FAST enabled)", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return err
+		}
+		logging.Warning("Continuing startup despite failed self-check", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	// Setup router
 	a.setupRouter()
 
+	// Periodic maintenance (command archival etc.); individual jobs are
+	// opt-in via their own environment configuration
+	controllers.StartBackgroundJobs()
+
+	// Command expiry runs on its own, faster ticker; stopped on shutdown
+	stopCommandExpiry := controllers.StartCommandExpirySweeper()
+
+	// Write-ahead metric buffering (opt-in); flushed fully on shutdown below
+	controllers.StartMetricBuffer()
+
 	// Get port from environment
 	a.Port = os.Getenv("PORT")
 	if a.Port == "" {
@@ -156,6 +191,10 @@ func (a *App) Start() error {
 
 	logging.Info("Shutting down server...")
 
+	// Stop the expiry sweeper before the database connection goes away
+	stopCommandExpiry()
+	stopLoggerRetry()
+
 	// Create context with timeout for shutdown (10s for compatibility with master)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -168,6 +207,15 @@ func (a *App) Start() error {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Drain buffered metrics before the database connection goes away so a
+	// SIGTERM never loses accepted data
+	if err := controllers.ShutdownMetricBuffer(); err != nil {
+		logging.Error("Error flushing metric buffer", map[string]interface{}{
+			"error": err.Error(),
+		})
+		log.Printf("Error flushing metric buffer: %v", err)
+	}
+
 	// Close database connection
 	if err := database.Shutdown(); err != nil {
 		logging.Error("Error closing database", map[string]interface{}{
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the request header carrying the client's API key.
+const APIKeyHeader = "X-API-Key"
+
+// parseKeyList splits a comma-separated environment variable into trimmed,
+// non-empty keys.
+func parseKeyList(name string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(os.Getenv(name), ",") {
+		if trimmed := strings.TrimSpace(key); trimmed != "" {
+			keys = append(keys, trimmed)
+		}
+	}
+	return keys
+}
+
+// apiKeys parses the comma-separated API_KEYS environment variable. An empty
+// result means authentication is disabled, which keeps local development and
+// tests working without configuration.
+func apiKeys() []string {
+	return parseKeyList("API_KEYS")
+}
+
+// adminKeys parses ADMIN_API_KEYS, the subset of keys that grant an elevated
+// role. Admin keys should also appear in API_KEYS so they pass authentication.
+func adminKeys() []string {
+	return parseKeyList("ADMIN_API_KEYS")
+}
+
+// IsElevated reports whether the request presented an admin API key.
+// Controllers use it to decide between masked and full views of sensitive
+// fields. With no admin keys configured, no caller is elevated.
+func IsElevated(c *gin.Context) bool {
+	keys := adminKeys()
+	if len(keys) == 0 {
+		return false
+	}
+	return matchesAnyKey(c.GetHeader(APIKeyHeader), keys)
+}
+
+// matchesAnyKey compares the presented key against every configured key in
+// constant time so response timing leaks nothing about key contents.
+func matchesAnyKey(presented string, keys []string) bool {
+	matched := false
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(key)) == 1 {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// APIKeyAuth rejects requests lacking a valid X-API-Key header when API_KEYS
+// is configured. Health endpoints stay open so load balancers can probe the
+// service without credentials.
+func APIKeyAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys := apiKeys()
+		if len(keys) == 0 {
+			c.Next()
+			return
+		}
+		if strings.HasPrefix(c.Request.URL.Path, "/health") {
+			c.Next()
+			return
+		}
+
+		presented := c.GetHeader(APIKeyHeader)
+		if presented == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing API key"})
+			return
+		}
+		if !matchesAnyKey(presented, keys) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func requestWithKey(t *testing.T, path, key string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(APIKeyAuth())
+	engine.GET("/protected", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	engine.GET("/health", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyAuthDisabledWhenUnset(t *testing.T) {
+	t.Setenv("API_KEYS", "")
+	if w := requestWithKey(t, "/protected", ""); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 with auth disabled, got %d", w.Code)
+	}
+}
+
+func TestAPIKeyAuthEnforced(t *testing.T) {
+	t.Setenv("API_KEYS", "alpha, beta")
+
+	if w := requestWithKey(t, "/protected", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 without key, got %d", w.Code)
+	}
+	if w := requestWithKey(t, "/protected", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 with wrong key, got %d", w.Code)
+	}
+	for _, key := range []string{"alpha", "beta"} {
+		if w := requestWithKey(t, "/protected", key); w.Code != http.StatusOK {
+			t.Errorf("expected status 200 with key %q, got %d", key, w.Code)
+		}
+	}
+}
+
+func TestAPIKeyAuthExemptsHealth(t *testing.T) {
+	t.Setenv("API_KEYS", "alpha")
+	if w := requestWithKey(t, "/health", ""); w.Code != http.StatusOK {
+		t.Errorf("expected health endpoint open without key, got %d", w.Code)
+	}
+}
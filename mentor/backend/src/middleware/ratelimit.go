@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bucketIdleTTL is how long an untouched bucket survives before the next
+// cleanup pass drops it.
+const bucketIdleTTL = 10 * time.Minute
+
+// bucketCleanupInterval is how often idle buckets are swept from the map.
+const bucketCleanupInterval = time.Minute
+
+// ingestRatePerMinute reads INGEST_RATE_PER_MINUTE. Zero (or unset, or an
+// unparsable value) disables rate limiting entirely.
+func ingestRatePerMinute() int {
+	raw := os.Getenv("INGEST_RATE_PER_MINUTE")
+	if raw == "" {
+		return 0
+	}
+	rate, err := strconv.Atoi(raw)
+	if err != nil || rate < 0 {
+		fmt.Printf("Warning: invalid INGEST_RATE_PER_MINUTE %q, rate limiting disabled\n", raw)
+		return 0
+	}
+	return rate
+}
+
+// tokenBucket holds the remaining ingestion allowance for one device.
+// Tokens refill continuously at the configured per-minute rate up to a burst
+// of one minute's worth.
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// deviceRateLimiter keeps one token bucket per device ID. Idle buckets are
+// swept periodically so the map does not grow without bound as devices churn.
+type deviceRateLimiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+// ingestLimiter is the process-wide limiter shared by all ingestion routes
+var ingestLimiter = &deviceRateLimiter{buckets: make(map[string]*tokenBucket)}
+
+// Allow takes one token from the device's bucket if available. When the
+// bucket is empty it reports the whole seconds until the next token refills,
+// suitable for a Retry-After header.
+func (l *deviceRateLimiter) Allow(deviceID string, ratePerMinute int) (bool, int) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cleanupLocked(now)
+
+	bucket, ok := l.buckets[deviceID]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(ratePerMinute), lastFill: now}
+		l.buckets[deviceID] = bucket
+	}
+
+	refill := now.Sub(bucket.lastFill).Minutes() * float64(ratePerMinute)
+	bucket.tokens = math.Min(bucket.tokens+refill, float64(ratePerMinute))
+	bucket.lastFill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	secondsPerToken := 60.0 / float64(ratePerMinute)
+	retryAfter := int(math.Ceil((1 - bucket.tokens) * secondsPerToken))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	return false, retryAfter
+}
+
+// cleanupLocked drops buckets untouched for bucketIdleTTL. It runs at most
+// once per bucketCleanupInterval and assumes the caller holds the mutex.
+func (l *deviceRateLimiter) cleanupLocked(now time.Time) {
+	if now.Sub(l.lastCleanup) < bucketCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for deviceID, bucket := range l.buckets {
+		if now.Sub(bucket.lastFill) > bucketIdleTTL {
+			delete(l.buckets, deviceID)
+		}
+	}
+}
+
+// DeviceRateLimit throttles ingestion per device using the :id path
+// parameter as the bucket key. With INGEST_RATE_PER_MINUTE zero or unset the
+// middleware is a no-op, so existing deployments are unaffected until they
+// opt in.
+func DeviceRateLimit() gin.HandlerFunc {
+	return deviceRateLimit(ingestLimiter)
+}
+
+// deviceRateLimit is the injectable core of DeviceRateLimit so tests can run
+// against an isolated limiter instead of the shared one.
+func deviceRateLimit(limiter *deviceRateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rate := ingestRatePerMinute()
+		if rate == 0 {
+			c.Next()
+			return
+		}
+		deviceID := c.Param("id")
+		if deviceID == "" {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter := limiter.Allow(deviceID, rate)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "ingestion rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ingestRequest(t *testing.T, limiter *deviceRateLimiter, deviceID string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/devices/:id/logs", deviceRateLimit(limiter), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/devices/%s/logs", deviceID), nil)
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestDeviceRateLimitDisabledWhenUnset(t *testing.T) {
+	t.Setenv("INGEST_RATE_PER_MINUTE", "")
+	limiter := &deviceRateLimiter{buckets: make(map[string]*tokenBucket)}
+	for i := 0; i < 20; i++ {
+		if w := ingestRequest(t, limiter, "dev-a"); w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 with limiting disabled, got %d", w.Code)
+		}
+	}
+}
+
+func TestDeviceRateLimitEnforcedPerDevice(t *testing.T) {
+	t.Setenv("INGEST_RATE_PER_MINUTE", "3")
+	limiter := &deviceRateLimiter{buckets: make(map[string]*tokenBucket)}
+
+	for i := 0; i < 3; i++ {
+		if w := ingestRequest(t, limiter, "dev-a"); w.Code != http.StatusOK {
+			t.Fatalf("expected request %d within the budget to pass, got %d", i+1, w.Code)
+		}
+	}
+
+	w := ingestRequest(t, limiter, "dev-a")
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 over the budget, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on throttled responses")
+	}
+
+	// A different device has its own bucket and is unaffected
+	if w := ingestRequest(t, limiter, "dev-b"); w.Code != http.StatusOK {
+		t.Errorf("expected other devices to stay unthrottled, got %d", w.Code)
+	}
+}
+
+func TestDeviceRateLimitCleanupDropsIdleBuckets(t *testing.T) {
+	limiter := &deviceRateLimiter{buckets: make(map[string]*tokenBucket)}
+	limiter.Allow("dev-a", 10)
+
+	// Age the bucket past the idle TTL and force a cleanup pass
+	limiter.mu.Lock()
+	limiter.buckets["dev-a"].lastFill = time.Now().Add(-2 * bucketIdleTTL)
+	limiter.lastCleanup = time.Now().Add(-2 * bucketCleanupInterval)
+	limiter.mu.Unlock()
+
+	limiter.Allow("dev-b", 10)
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if _, ok := limiter.buckets["dev-a"]; ok {
+		t.Error("expected the idle bucket swept from the map")
+	}
+	if _, ok := limiter.buckets["dev-b"]; !ok {
+		t.Error("expected the active bucket retained")
+	}
+}
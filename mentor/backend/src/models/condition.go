@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Conditional command execution: a command may carry a small "field op value"
+// condition (e.g. "cpu_usage > 90") that is checked against the device's
+// latest metric before dispatch. Fields and operators are whitelisted; this is
+// deliberately not a general expression language.
+
+// conditionFields maps condition field names to metric accessors
+var conditionFields = map[string]func(*DeviceMetric) float64{
+	"cpu_usage":      func(m *DeviceMetric) float64 { return m.CPUUsage },
+	"cpu_temp":       func(m *DeviceMetric) float64 { return m.CPUTemp },
+	"memory_percent": func(m *DeviceMetric) float64 { return m.GetMemoryUsagePercent() },
+	"disk_percent":   func(m *DeviceMetric) float64 { return m.GetDiskUsagePercent() },
+}
+
+// conditionOperators is the whitelist of comparison operators for conditions
+var conditionOperators = map[string]func(a, b float64) bool{
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	"==": func(a, b float64) bool { return a == b },
+}
+
+// CommandCondition is a parsed conditional-execution check
+type CommandCondition struct {
+	Field    string
+	Operator string
+	Value    float64
+}
+
+// ParseCondition parses a "field op value" condition string, validating the
+// field and operator against the whitelists
+func ParseCondition(condition string) (*CommandCondition, error) {
+	parts := strings.Fields(strings.TrimSpace(condition))
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("condition must have the form 'field operator value' (e.g. 'cpu_usage > 90')")
+	}
+
+	field := strings.ToLower(parts[0])
+	if _, ok := conditionFields[field]; !ok {
+		return nil, fmt.Errorf("invalid condition field %q (must be: cpu_usage, cpu_temp, memory_percent, or disk_percent)", parts[0])
+	}
+
+	if _, ok := conditionOperators[parts[1]]; !ok {
+		return nil, fmt.Errorf("invalid condition operator %q (must be: >, >=, <, <=, or ==)", parts[1])
+	}
+
+	value, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition value %q: must be a number", parts[2])
+	}
+
+	return &CommandCondition{Field: field, Operator: parts[1], Value: value}, nil
+}
+
+// Evaluate checks the condition against a metric snapshot
+func (cc *CommandCondition) Evaluate(metric *DeviceMetric) bool {
+	return conditionOperators[cc.Operator](conditionFields[cc.Field](metric), cc.Value)
+}
+
+// ConditionMet reports whether the command's condition holds for the given
+// metric. Commands without a condition are always dispatchable.
+func (command *DeviceRemoteCommand) ConditionMet(metric *DeviceMetric) (bool, error) {
+	if strings.TrimSpace(command.Condition) == "" {
+		return true, nil
+	}
+	parsed, err := ParseCondition(command.Condition)
+	if err != nil {
+		return false, err
+	}
+	return parsed.Evaluate(metric), nil
+}
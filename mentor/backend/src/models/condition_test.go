@@ -0,0 +1,83 @@
+package models
+
+import "testing"
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		wantErr   bool
+	}{
+		{"valid cpu check", "cpu_usage > 90", false},
+		{"valid memory check", "memory_percent >= 80", false},
+		{"valid equality", "disk_percent == 100", false},
+		{"uppercase field normalized", "CPU_USAGE < 50", false},
+		{"unknown field", "uptime > 10", true},
+		{"unknown operator", "cpu_usage != 90", true},
+		{"non-numeric value", "cpu_usage > high", true},
+		{"too few parts", "cpu_usage >", true},
+		{"too many parts", "cpu_usage > 90 extra", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCondition(tt.condition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCondition(%q) error = %v, wantErr %v", tt.condition, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConditionMet(t *testing.T) {
+	metric := DeviceMetric{
+		CPUUsage:    95,
+		CPUTemp:     70,
+		MemoryTotal: 100,
+		MemoryUsed:  50,
+	}
+
+	met := DeviceRemoteCommand{CommandText: "restart", Condition: "cpu_usage > 90"}
+	ok, err := met.ConditionMet(&metric)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("expected condition 'cpu_usage > 90' to be met at 95% usage")
+	}
+
+	unmet := DeviceRemoteCommand{CommandText: "restart", Condition: "memory_percent >= 80"}
+	ok, err = unmet.ConditionMet(&metric)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected condition 'memory_percent >= 80' to be unmet at 50% usage")
+	}
+
+	unconditional := DeviceRemoteCommand{CommandText: "restart"}
+	ok, err = unconditional.ConditionMet(&metric)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("expected unconditional command to always be dispatchable")
+	}
+
+	invalid := DeviceRemoteCommand{CommandText: "restart", Condition: "bogus > 1"}
+	if _, err := invalid.ConditionMet(&metric); err == nil {
+		t.Error("expected error for invalid condition field")
+	}
+}
+
+func TestValidateRemoteCommandCondition(t *testing.T) {
+	valid := DeviceRemoteCommand{DeviceID: sampleUUID, CommandText: "restart", Condition: "cpu_usage > 90"}
+	if errs := valid.ValidateRemoteCommand(); len(errs) != 0 {
+		t.Errorf("expected no validation errors, got %v", errs)
+	}
+
+	invalid := DeviceRemoteCommand{DeviceID: sampleUUID, CommandText: "restart", Condition: "uptime > 10"}
+	if errs := invalid.ValidateRemoteCommand(); len(errs) == 0 {
+		t.Error("expected validation error for invalid condition field")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Device represents a monitored device.
@@ -18,6 +19,30 @@ type Device struct {
 	IPAddress      string    `json:"ip_address"`
 	MacAddress     string    `json:"mac_address"`
 	CurrentUser    string    `json:"current_user"`
+	HardwareID     string    `json:"hardware_id"`
+	// Version implements optimistic concurrency for operator edits: PATCH
+	// requests must present the current version and each update bumps it.
+	Version int `json:"version" gorm:"default:1"`
+	// Capabilities lists what the device supports (e.g. metrics, screenshots,
+	// commands), captured at registration. Empty means all capabilities so
+	// agents predating the field keep working.
+	Capabilities []string `json:"capabilities" gorm:"serializer:json;type:jsonb"`
+	// Tags are free-form operator labels (e.g. "prod", "finance") used to
+	// slice fleet-wide reports.
+	Tags []string `json:"tags" gorm:"serializer:json;type:jsonb"`
+	// RetentionDays overrides the global metric retention window for this
+	// device, letting high-value machines keep forensic data longer. Zero
+	// means use the fleet default.
+	RetentionDays int `json:"retention_days"`
+	// MergedInto points at the canonical device this record was folded into
+	// by the duplicate merge endpoint; set together with the soft-delete
+	// timestamp so merged duplicates disappear from listings but stay
+	// auditable.
+	MergedInto *uuid.UUID     `json:"merged_into,omitempty" gorm:"type:uuid"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+	// UpdatedAt is maintained by GORM on every write and drives the
+	// incremental /devices/changes feed.
+	UpdatedAt time.Time `json:"updated_at" gorm:"default:now()"`
 
 	// Relationships
 	Metrics     []DeviceMetric        `gorm:"foreignKey:DeviceID;constraint:OnDelete:CASCADE;"`
@@ -43,6 +68,31 @@ type DeviceMetric struct {
 	DiskUsed    uint64    `json:"disk_used"`
 	NetBytesIn  uint64    `json:"net_bytes_in"`
 	NetBytesOut uint64    `json:"net_bytes_out"`
+	// CounterReset marks samples where a monotonic counter (net bytes) dropped
+	// below the previous sample, indicating a reboot or counter wrap. Set
+	// server-side on ingest when reset detection is enabled.
+	CounterReset bool `json:"counter_reset"`
+}
+
+// DeviceMetricRollup stores pre-aggregated hourly metric statistics for a
+// device. Long-range queries read these instead of scanning raw metrics.
+type DeviceMetricRollup struct {
+	RollupID      uuid.UUID `json:"rollupid" gorm:"column:rollupid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID      uuid.UUID `json:"deviceid" gorm:"column:deviceid;uniqueIndex:idx_rollup_device_bucket"`
+	BucketStart   time.Time `json:"bucket_start" gorm:"uniqueIndex:idx_rollup_device_bucket"`
+	Samples       int64     `json:"samples"`
+	MinCPUUsage   float64   `json:"min_cpu_usage"`
+	MaxCPUUsage   float64   `json:"max_cpu_usage"`
+	AvgCPUUsage   float64   `json:"avg_cpu_usage"`
+	MinCPUTemp    float64   `json:"min_cpu_temp"`
+	MaxCPUTemp    float64   `json:"max_cpu_temp"`
+	AvgCPUTemp    float64   `json:"avg_cpu_temp"`
+	MinMemoryUsed float64   `json:"min_memory_used"`
+	MaxMemoryUsed float64   `json:"max_memory_used"`
+	AvgMemoryUsed float64   `json:"avg_memory_used"`
+	MinDiskUsed   float64   `json:"min_disk_used"`
+	MaxDiskUsed   float64   `json:"max_disk_used"`
+	AvgDiskUsed   float64   `json:"avg_disk_used"`
 }
 
 // DeviceProcess represents a running process.
@@ -78,6 +128,21 @@ type DeviceAlert struct {
 	Message   string    `json:"message"`
 	Value     float64   `json:"value"`
 	Threshold float64   `json:"threshold"`
+	// CorrelationID groups related alerts (e.g. a cascade from one incident)
+	// across devices. Empty when the caller does not correlate.
+	CorrelationID string `json:"correlation_id" gorm:"index"`
+	// Backfilled marks alerts generated retroactively by re-evaluating stored
+	// metrics against updated thresholds, as opposed to reported live.
+	Backfilled bool `json:"backfilled"`
+	// SnoozedUntil hides the alert from default listings until the time
+	// passes. A snoozed alert is still active, not resolved; it re-surfaces
+	// automatically once the snooze expires. Nil means not snoozed.
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+	// Acknowledgement marks an alert as handled by an operator so it stops
+	// counting against open-alert views; unlike a snooze it does not expire.
+	Acknowledged   bool      `json:"acknowledged"`
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+	AcknowledgedBy string    `json:"acknowledged_by"`
 }
 
 // DeviceRemoteCommand represents a command sent remotely.
@@ -85,11 +150,37 @@ type DeviceRemoteCommand struct {
 	CommandID   uuid.UUID `json:"commandid" gorm:"column:commandid;type:uuid;primaryKey;default:gen_random_uuid()"`
 	DeviceID    uuid.UUID `json:"deviceid" gorm:"column:deviceid"`
 	CommandText string    `json:"command_text"`
+	Condition   string    `json:"condition"`
 	Status      string    `json:"status"`
+	// ForwardStatus tracks delivery to the devices backend: pending (not yet
+	// sent), sent, or failed. Used to replay forwarding after outages.
+	ForwardStatus string `json:"forward_status" gorm:"default:pending"`
+	// ScheduledAt defers execution: pending commands with a future ScheduledAt
+	// are withheld from agents until due. Nil means run immediately.
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	// ExpiresAt bounds how long the command may wait for pickup, defaulting to
+	// creation (or scheduled) time plus the configured TTL. Expired pending
+	// commands are skipped by GetPendingCommands and swept to status
+	// "expired". Zero means no expiry, which grandfathers pre-existing rows.
+	ExpiresAt   time.Time `json:"expires_at"`
 	CreatedAt   time.Time `json:"created_at" gorm:"default:now()"`
 	CompletedAt time.Time `json:"completed_at"`
 	Result      string    `json:"result"`
-	ExitCode    int       `json:"exit_code"`
+	// CompressedResult holds the gzipped result when COMMAND_RESULT_COMPRESS
+	// is enabled at write time; Result is then empty in storage and
+	// reconstructed transparently on read. Never exposed over the API.
+	CompressedResult []byte `json:"-" gorm:"type:bytea"`
+	Compressed       bool   `json:"-"`
+	ExitCode         int    `json:"exit_code"`
+	// NackCount and LastNackReason track delivery failures reported by the
+	// agent (as opposed to execution failures). A nacked command is re-queued
+	// until the retry cap is reached.
+	NackCount      int    `json:"nack_count"`
+	LastNackReason string `json:"last_nack_reason"`
+	// Priority orders the pending queue: higher values are delivered first.
+	// Regular commands default to 0; system-generated urgent commands (e.g.
+	// on-demand screenshots) use a higher value.
+	Priority int `json:"priority"`
 }
 
 // DeviceScreenshot stores screen captures.
@@ -100,6 +191,135 @@ type DeviceScreenshot struct {
 	Path         string    `json:"path"`
 	Resolution   string    `json:"resolution"`
 	Size         int64     `json:"size"`
+	// Checksum is an optional content hash reported by the agent, used to
+	// dedup identical consecutive captures when SCREENSHOT_DEDUP is enabled.
+	Checksum string `json:"checksum"`
+	// AlertID optionally links the capture to the alert that triggered it.
+	// Linked captures are treated as evidence and skipped by retention.
+	AlertID *uuid.UUID `json:"alertid,omitempty" gorm:"column:alertid;type:uuid"`
+	// Pinned marks the capture as kept indefinitely by an operator,
+	// independent of any alert linkage.
+	Pinned bool `json:"pinned"`
+}
+
+// DeviceLog stores a structured log event reported by a device agent.
+type DeviceLog struct {
+	LogID     uuid.UUID              `json:"logid" gorm:"column:logid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID  uuid.UUID              `json:"deviceid" gorm:"column:deviceid"`
+	Timestamp time.Time              `json:"timestamp" gorm:"default:now()"`
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	Fields    map[string]interface{} `json:"fields" gorm:"serializer:json;type:jsonb"`
+}
+
+// DeviceNote is a free-form operator annotation on a device, e.g. "known
+// flaky PSU". Notes are append-only context, not configuration.
+type DeviceNote struct {
+	NoteID    uuid.UUID `json:"noteid" gorm:"column:noteid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID  uuid.UUID `json:"deviceid" gorm:"column:deviceid"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:now()"`
+}
+
+// InventoryItem is one entry in a device inventory snapshot, e.g. an
+// installed software package and its version.
+type InventoryItem struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// DeviceInventory stores a category of inventory (e.g. software) reported by
+// a device agent. One row per device and category, replaced on each report.
+type DeviceInventory struct {
+	InventoryID uuid.UUID       `json:"inventoryid" gorm:"column:inventoryid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID    uuid.UUID       `json:"deviceid" gorm:"column:deviceid;uniqueIndex:idx_inventory_device_category"`
+	Category    string          `json:"category" gorm:"uniqueIndex:idx_inventory_device_category"`
+	Items       []InventoryItem `json:"items" gorm:"serializer:json;type:jsonb"`
+	UpdatedAt   time.Time       `json:"updated_at" gorm:"default:now()"`
+}
+
+// DeviceToken is a per-device agent credential. Only the SHA-256 hash of the
+// issued token is stored; the clear token is returned once at issuance.
+// Revocation is a soft delete so audit history survives.
+type DeviceToken struct {
+	TokenID   uuid.UUID  `json:"tokenid" gorm:"column:tokenid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID  uuid.UUID  `json:"deviceid" gorm:"column:deviceid"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex"`
+	CreatedAt time.Time  `json:"created_at" gorm:"default:now()"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// CommandArchive indexes a batch of terminal commands exported to object
+// storage as NDJSON. The rows themselves are deleted from Postgres once the
+// batch is uploaded; reads go through the archive endpoint.
+type CommandArchive struct {
+	ArchiveID    uuid.UUID `json:"archiveid" gorm:"column:archiveid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	Path         string    `json:"path"`
+	CommandCount int       `json:"command_count"`
+	OldestAt     time.Time `json:"oldest_at"`
+	NewestAt     time.Time `json:"newest_at"`
+	CreatedAt    time.Time `json:"created_at" gorm:"default:now()"`
+}
+
+// DeviceGroup is a named, first-class grouping of devices for fleet
+// organization (e.g. a site, department, or canary ring).
+type DeviceGroup struct {
+	GroupID     uuid.UUID `json:"groupid" gorm:"column:groupid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name        string    `json:"name" gorm:"uniqueIndex"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:now()"`
+}
+
+// DeviceGroupMember joins devices to groups. A device can belong to many
+// groups; the composite unique index keeps membership idempotent.
+type DeviceGroupMember struct {
+	MemberID uuid.UUID `json:"memberid" gorm:"column:memberid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	GroupID  uuid.UUID `json:"groupid" gorm:"column:groupid;uniqueIndex:idx_group_device"`
+	DeviceID uuid.UUID `json:"deviceid" gorm:"column:deviceid;uniqueIndex:idx_group_device"`
+	AddedAt  time.Time `json:"added_at" gorm:"default:now()"`
+}
+
+// DeviceConfig stores server-driven agent configuration for a device.
+// Agents fetch this on startup instead of hardcoding poll intervals and thresholds.
+type DeviceConfig struct {
+	ConfigID                  uuid.UUID `json:"configid" gorm:"column:configid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID                  uuid.UUID `json:"deviceid" gorm:"column:deviceid;uniqueIndex"`
+	MetricIntervalSeconds     int       `json:"metric_interval_seconds"`
+	ScreenshotIntervalSeconds int       `json:"screenshot_interval_seconds"`
+	CPUThreshold              float64   `json:"cpu_threshold"`
+	MemoryThreshold           float64   `json:"memory_threshold"`
+	DiskThreshold             float64   `json:"disk_threshold"`
+	UpdatedAt                 time.Time `json:"updated_at" gorm:"default:now()"`
+}
+
+// UserSession records one span of a user being logged in on a device,
+// reconstructed from CurrentUser changes seen at registration heartbeats.
+// EndedAt is nil while the session is still the device's active one.
+type UserSession struct {
+	SessionID uuid.UUID  `json:"sessionid" gorm:"column:sessionid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID  uuid.UUID  `json:"deviceid" gorm:"column:deviceid;index"`
+	User      string     `json:"user"`
+	StartedAt time.Time  `json:"started_at" gorm:"default:now()"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+// DeviceDailyReport stores one precomputed daily summary per device. The
+// nightly job writes these so the reports view never has to aggregate raw
+// metrics on the fly.
+type DeviceDailyReport struct {
+	ReportID uuid.UUID `json:"reportid" gorm:"column:reportid;type:uuid;primaryKey;default:gen_random_uuid()"`
+	DeviceID uuid.UUID `json:"deviceid" gorm:"column:deviceid;uniqueIndex:idx_report_device_day"`
+	// Day is the UTC midnight of the day the report covers.
+	Day          time.Time `json:"day" gorm:"uniqueIndex:idx_report_device_day"`
+	AvgCPU       float64   `json:"avg_cpu"`
+	PeakCPU      float64   `json:"peak_cpu"`
+	AlertCount   int64     `json:"alert_count"`
+	CommandCount int64     `json:"command_count"`
+	// UptimeSeconds estimates how long the device was up from its metric
+	// samples, assuming one sample per reporting interval.
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	GeneratedAt   time.Time `json:"generated_at" gorm:"default:now()"`
 }
 
 // User represents a user linked to a device.
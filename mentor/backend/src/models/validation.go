@@ -51,6 +51,14 @@ var validAlertTypes = map[string]bool{
 	"security": true,
 }
 
+// Valid log levels for device log events
+var validLogLevels = map[string]bool{
+	"debug":   true,
+	"info":    true,
+	"warning": true,
+	"error":   true,
+}
+
 // Valid command statuses
 var validCommandStatuses = map[string]bool{
 	"pending":   true,
@@ -59,6 +67,12 @@ var validCommandStatuses = map[string]bool{
 	"failed":    true,
 }
 
+// IsValidCommandStatus reports whether status is one of the allowed command
+// statuses (case-insensitive).
+func IsValidCommandStatus(status string) bool {
+	return validCommandStatuses[strings.ToLower(status)]
+}
+
 // AllowedCommands is the whitelist of commands that can be executed on devices
 // This must match the whitelist in the devices backend for security
 var AllowedCommands = map[string]bool{
@@ -110,6 +124,27 @@ func (device *Device) GetFormattedType() string {
 	return caser.String(strings.ToLower(device.DeviceType))
 }
 
+// HasCapability reports whether the device supports the named capability.
+// Devices that declared no capabilities support everything, so fleets
+// registered before capability tracking keep full functionality.
+func (device *Device) HasCapability(name string) bool {
+	if len(device.Capabilities) == 0 {
+		return true
+	}
+	for _, capability := range device.Capabilities {
+		if strings.EqualFold(capability, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidDeviceType reports whether the given type is one of the known
+// device types.
+func IsValidDeviceType(deviceType string) bool {
+	return validDeviceTypes[strings.ToLower(deviceType)]
+}
+
 // -------------------- ALERTS --------------------
 
 // ValidateAlert validates alert fields and returns validation errors
@@ -140,6 +175,36 @@ func (alert *DeviceAlert) IsCritical() bool {
 	return strings.ToLower(alert.Level) == "critical"
 }
 
+// alertLevelRanks orders alert levels from least to most severe
+var alertLevelRanks = map[string]int{
+	"info":     1,
+	"warning":  2,
+	"error":    3,
+	"critical": 4,
+}
+
+// AlertLevelRank returns the severity rank of an alert level
+// (1=info .. 4=critical), or 0 for an unknown level.
+func AlertLevelRank(level string) int {
+	return alertLevelRanks[strings.ToLower(level)]
+}
+
+// AlertLevelsAtOrAbove returns all valid alert levels with severity greater
+// than or equal to the given level, or nil for an unknown level.
+func AlertLevelsAtOrAbove(level string) []string {
+	minRank := AlertLevelRank(level)
+	if minRank == 0 {
+		return nil
+	}
+	levels := make([]string, 0, len(alertLevelRanks))
+	for l, rank := range alertLevelRanks {
+		if rank >= minRank {
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
+
 // -------------------- METRICS --------------------
 
 // ValidateDeviceMetric validates metric fields and returns validation errors
@@ -185,6 +250,23 @@ func (metric *DeviceMetric) GetDiskUsagePercent() float64 {
 	return float64(metric.DiskUsed) / float64(metric.DiskTotal) * 100
 }
 
+// -------------------- LOGS --------------------
+
+// ValidateDeviceLog validates log event fields and returns validation errors
+func (logEvent *DeviceLog) ValidateDeviceLog() []string {
+	var errors []string
+
+	if !validLogLevels[strings.ToLower(logEvent.Level)] {
+		errors = append(errors, "invalid log level (must be: debug, info, warning, or error)")
+	}
+
+	if strings.TrimSpace(logEvent.Message) == "" {
+		errors = append(errors, "log message cannot be empty")
+	}
+
+	return errors
+}
+
 // -------------------- REMOTE COMMANDS --------------------
 
 // ValidateRemoteCommand validates command fields and returns validation errors
@@ -209,6 +291,13 @@ func (command *DeviceRemoteCommand) ValidateRemoteCommand() []string {
 		errors = append(errors, "invalid command status (must be: pending, running, completed, or failed)")
 	}
 
+	// Validate optional conditional-execution expression
+	if strings.TrimSpace(command.Condition) != "" {
+		if _, err := ParseCondition(command.Condition); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
 	return errors
 }
 
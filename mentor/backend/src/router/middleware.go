@@ -1,13 +1,58 @@
 package router
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// Default per-route timeouts. Reads should be snappy while exports and uploads
+// may legitimately take longer. Both are overridable via environment variables
+// in router setup.
+const (
+	// DefaultReadTimeout is the default deadline for normal query routes
+	DefaultReadTimeout = 10 * time.Second
+	// DefaultExportTimeout is the default deadline for export and upload routes
+	DefaultExportTimeout = 60 * time.Second
+)
+
+// TimeoutMiddleware wraps the request in a context with the given deadline and
+// returns 504 JSON when the handler does not finish in time. The deadline
+// propagates through the request context, so context-aware DB queries are
+// cancelled when it expires; the abandoned handler goroutine winds down on its
+// own once its queries fail.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		done := make(chan struct{})
+		panicChan := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicChan <- p
+				}
+			}()
+			c.Next()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case p := <-panicChan:
+			panic(p)
+		case <-ctx.Done():
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{"error": "request timed out"})
+		}
+	}
+}
+
 // CorrelationIDMiddleware adds a correlation ID to each request for tracing
 func CorrelationIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
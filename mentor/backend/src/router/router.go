@@ -3,8 +3,10 @@ package router
 import (
 	"mentor-backend/controllers"
 	"mentor-backend/logging"
+	"mentor-backend/middleware"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,12 +34,17 @@ func (r *Router) SetupAllRoutes() {
 	r.engine.Use(logging.RequestIDMiddleware())
 	// Add request logging middleware
 	r.engine.Use(logging.RequestLoggingMiddleware())
+	// API-key authentication; a no-op unless API_KEYS is configured
+	r.engine.Use(middleware.APIKeyAuth())
 
 	r.setupCORS()
 	r.setupSwagger()
 	r.setupHealthCheck()
 	r.setupActivityRoutes()
+	r.setupAlertRoutes()
+	r.setupAdminRoutes()
 	r.setupDeviceRoutes()
+	r.setupGroupRoutes()
 }
 
 // setupCORS configures CORS middleware
@@ -150,6 +157,13 @@ func (r *Router) setupHealthCheck() {
 
 	// Add a more detailed health check that validates dependencies
 	r.engine.GET("/health/ready", controllers.HealthCheckReady)
+
+	// Prometheus scrape target for the service's internal counters
+	r.engine.GET("/metrics", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+		logging.WritePrometheus(c.Writer)
+		controllers.WriteMetricBufferGauge(c.Writer)
+	})
 }
 
 // setupActivityRoutes configures activity-related routes
@@ -157,29 +171,130 @@ func (r *Router) setupActivityRoutes() {
 	r.engine.GET("/activities", controllers.ListActivities)
 }
 
+// routeTimeout returns a per-route timeout from the environment (in seconds)
+// or the provided default when unset or invalid.
+func routeTimeout(envKey string, fallback time.Duration) time.Duration {
+	if raw := os.Getenv(envKey); raw != "" {
+		if secs, err := strconv.Atoi(raw); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return fallback
+}
+
+// setupAlertRoutes configures alert-related routes (global, not per device)
+func (r *Router) setupAlertRoutes() {
+	read := r.engine.Group("", TimeoutMiddleware(routeTimeout("ROUTE_READ_TIMEOUT_SECONDS", DefaultReadTimeout)))
+	read.GET("/alerts", controllers.GetCorrelatedAlerts)
+	read.GET("/alerts/:id/context", controllers.GetAlertContext)
+	r.engine.POST("/alerts/:id/snooze", controllers.SnoozeAlert)
+	r.engine.PUT("/alerts/:id/acknowledge", controllers.AcknowledgeAlert)
+	read.GET("/dashboard/alerts/trend", controllers.GetAlertTrend)
+	read.GET("/summary", controllers.GetFleetSummary)
+
+	// Exports stream potentially large result sets; give them a longer deadline
+	export := r.engine.Group("", TimeoutMiddleware(routeTimeout("ROUTE_EXPORT_TIMEOUT_SECONDS", DefaultExportTimeout)))
+	export.GET("/alerts/export", controllers.RequireFeature(controllers.FeatureExports), controllers.ExportAlerts)
+}
+
+// setupAdminRoutes configures operator-facing administrative routes
+func (r *Router) setupAdminRoutes() {
+	r.engine.GET("/admin/config", controllers.GetAdminConfig)
+	r.engine.GET("/admin/stats/tables", controllers.GetTableStats)
+	r.engine.GET("/admin/orphans", controllers.GetOrphanedDevices)
+	r.engine.POST("/admin/orphans/register", controllers.RegisterOrphanedDevices)
+	r.engine.POST("/admin/forward/test", controllers.TestForwardingConfig)
+	r.engine.GET("/admin/devices/duplicates", controllers.GetDuplicateDevices)
+	r.engine.POST("/admin/devices/merge", controllers.MergeDevices)
+	r.engine.GET("/schema", controllers.GetSchema)
+}
+
+// setupGroupRoutes configures device group routes
+func (r *Router) setupGroupRoutes() {
+	r.engine.POST("/groups", controllers.CreateDeviceGroup)
+	r.engine.DELETE("/groups/:id", controllers.DeleteDeviceGroup)
+	r.engine.POST("/groups/:id/devices", controllers.AddDeviceToGroup)
+	r.engine.DELETE("/groups/:id/devices/:deviceid", controllers.RemoveDeviceFromGroup)
+
+	read := r.engine.Group("", TimeoutMiddleware(routeTimeout("ROUTE_READ_TIMEOUT_SECONDS", DefaultReadTimeout)))
+	read.GET("/groups", controllers.ListDeviceGroups)
+	read.GET("/groups/:id", controllers.GetDeviceGroup)
+	read.GET("/groups/:id/summary", controllers.GetDeviceGroupSummary)
+}
+
 // setupDeviceRoutes configures all device-related routes
 func (r *Router) setupDeviceRoutes() {
 	// Device ingestion endpoints (devices will POST data here)
 	r.engine.POST("/devices/register", controllers.RegisterDevice)
+	r.engine.POST("/devices/batch", controllers.BatchRegisterDevices)
+	r.engine.POST("/devices/import", controllers.ImportDevicesCSV)
+	r.engine.POST("/query", controllers.Query)
 	r.engine.POST("/devices/metrics", controllers.UpdateDeviceMetric)
+	r.engine.POST("/devices/metrics/batch", controllers.BatchUpdateDeviceMetrics)
 	r.engine.POST("/devices/processes", controllers.UpdateProcessList)
+	r.engine.PATCH("/devices/:id/processes", controllers.RequireDeviceToken(), controllers.PatchProcessList)
 	r.engine.POST("/devices/activity", controllers.Activity)
-	r.engine.POST("/devices/commands", controllers.CreateRemoteCommand)
-	r.engine.POST("/devices/screenshots", controllers.StoreScreenshot)
-
-	// Device query endpoints
-	r.engine.GET("/devices", controllers.ListDevices)
-	r.engine.GET("/devices/:id/metrics", controllers.GetDeviceMetric)
-	r.engine.GET("/devices/:id/processes", controllers.GetDeviceProcesses)
-	r.engine.GET("/devices/:id/activities", controllers.GetDeviceActivity)
-	r.engine.GET("/devices/:id/alerts", controllers.GetDeviceAlert)
-	r.engine.GET("/devices/:id/screenshots", controllers.GetDeviceScreenshot)
-	r.engine.GET("/devices/:id/commands/pending", controllers.GetPendingCommands)
-	r.engine.GET("/devices/:id/commands", controllers.GetDeviceCommands)
+	r.engine.POST("/devices/commands", controllers.RequireFeature(controllers.FeatureCommands), controllers.CreateRemoteCommand)
+	r.engine.POST("/devices/screenshots", controllers.RequireFeature(controllers.FeatureScreenshots), controllers.StoreScreenshot)
+	r.engine.POST("/devices/:id/screenshot", controllers.RequireFeature(controllers.FeatureScreenshots), controllers.TriggerScreenshot)
+	r.engine.POST("/devices/:id/logs", middleware.DeviceRateLimit(), controllers.RequireDeviceToken(), controllers.IngestDeviceLogs)
+	r.engine.POST("/devices/:id/inventory", middleware.DeviceRateLimit(), controllers.RequireDeviceToken(), controllers.UpdateDeviceInventory)
+	r.engine.POST("/devices/:id/token", controllers.IssueDeviceToken)
+	r.engine.DELETE("/devices/:id", controllers.DeleteDevice)
+	r.engine.DELETE("/devices/:id/token/:tokenid", controllers.RevokeDeviceToken)
+	r.engine.POST("/devices/:id/commands/reforward", controllers.RequireFeature(controllers.FeatureCommands), controllers.ReforwardDeviceCommands)
+	r.engine.POST("/devices/:id/metrics/replay", controllers.ReplayDeviceMetrics)
+
+	// Device query endpoints get a short deadline so slow queries fail fast
+	read := r.engine.Group("", TimeoutMiddleware(routeTimeout("ROUTE_READ_TIMEOUT_SECONDS", DefaultReadTimeout)))
+	read.GET("/devices", controllers.ListDevices)
+	read.GET("/devices/at-risk", controllers.GetDevicesAtRisk)
+	read.GET("/devices/sample", controllers.SampleDevices)
+	read.GET("/devices/top", controllers.GetTopDevices)
+	read.GET("/devices/changes", controllers.GetDeviceChanges)
+	read.GET("/devices/:id/overview", controllers.GetDeviceOverview)
+	read.GET("/devices/:id/metrics", controllers.GetDeviceMetric)
+	read.GET("/devices/:id/metrics/stats", controllers.GetDeviceMetricStats)
+	read.GET("/devices/:id/metrics/aggregate", controllers.GetDeviceMetricAggregate)
+	read.GET("/devices/:id/metrics/gaps", controllers.GetMetricGaps)
+	read.GET("/devices/:id/processes", controllers.GetDeviceProcesses)
+	read.GET("/devices/:id/processes/heavy", controllers.GetHeavyProcesses)
+	read.GET("/devices/:id/similar", controllers.GetSimilarDevices)
+	read.GET("/devices/:id/activities", controllers.GetDeviceActivity)
+	read.GET("/devices/:id/sessions", controllers.GetDeviceSessions)
+	read.GET("/devices/:id/logs", controllers.GetDeviceLogs)
+	read.GET("/devices/:id/alerts", controllers.GetDeviceAlert)
+	read.GET("/devices/:id/screenshots", controllers.GetDeviceScreenshot)
+	// SSE stream is long-lived, so it bypasses the read timeout group
+	r.engine.GET("/devices/:id/screenshots/stream", controllers.StreamDeviceScreenshots)
+	r.engine.POST("/screenshots/:id/pin", controllers.RequireFeature(controllers.FeatureScreenshots), controllers.PinScreenshot)
+	r.engine.GET("/dashboard/live", controllers.StreamDashboardGauges)
+	read.GET("/devices/:id/commands/pending", controllers.GetPendingCommands)
+	// Exports share the longer export deadline rather than the read timeout
+	export := r.engine.Group("", TimeoutMiddleware(routeTimeout("ROUTE_EXPORT_TIMEOUT_SECONDS", DefaultExportTimeout)))
+	export.GET("/devices/:id/commands/export", controllers.RequireFeature(controllers.FeatureExports), controllers.ExportDeviceCommands)
+	export.GET("/devices/:id/metrics/export", controllers.RequireFeature(controllers.FeatureExports), controllers.ExportDeviceMetrics)
+	export.GET("/devices/:id/screenshots/download", controllers.RequireFeature(controllers.FeatureScreenshots), controllers.DownloadDeviceScreenshots)
+	read.GET("/devices/:id/commands", controllers.GetDeviceCommands)
+	read.GET("/devices/:id/config", controllers.GetDeviceConfig)
+	r.engine.PUT("/devices/:id/config", controllers.SetDeviceConfig)
+	r.engine.PATCH("/devices/:id", controllers.PatchDevice)
+	r.engine.POST("/devices/:id/notes", controllers.RequireFeature(controllers.FeatureNotes), controllers.AddDeviceNote)
+	r.engine.POST("/devices/:id/purge", controllers.PurgeDeviceData)
+	read.GET("/devices/:id/notes", controllers.GetDeviceNotes)
+	read.GET("/devices/:id/ingest-stats", controllers.GetDeviceIngestStats)
+	read.GET("/devices/:id/reports", controllers.GetDeviceReports)
 
 	// Command and alert endpoints
-	r.engine.POST("/commands/status", controllers.UpdateCommandStatus)
-	r.engine.POST("/devices/:id/alerts", controllers.ReportAlert)
+	r.engine.POST("/commands/status", controllers.RequireFeature(controllers.FeatureCommands), controllers.UpdateCommandStatus)
+	r.engine.PUT("/commands/status/batch", controllers.RequireFeature(controllers.FeatureCommands), controllers.BatchUpdateCommandStatus)
+	r.engine.POST("/commands/:id/nack", controllers.RequireFeature(controllers.FeatureCommands), controllers.NackCommand)
+	r.engine.POST("/commands/validate", controllers.RequireFeature(controllers.FeatureCommands), controllers.ValidateCommand)
+	read.GET("/commands/stats", controllers.GetCommandStats)
+	read.GET("/commands/archived", controllers.GetArchivedCommands)
+	read.GET("/inventory/report", controllers.GetInventoryReport)
+	r.engine.POST("/devices/:id/alerts", middleware.DeviceRateLimit(), controllers.RequireDeviceToken(), controllers.ReportAlert)
+	r.engine.POST("/devices/:id/thresholds/backfill", controllers.BackfillThresholdAlerts)
 }
 
 // Run starts the HTTP server on the specified address
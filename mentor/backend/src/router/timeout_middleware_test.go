@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTimeoutMiddlewareExpires(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.GET("/slow", TimeoutMiddleware(50*time.Millisecond), func(c *gin.Context) {
+		select {
+		case <-time.After(2 * time.Second):
+			c.JSON(http.StatusOK, gin.H{"status": "done"})
+		case <-c.Request.Context().Done():
+			// Context-aware handlers observe the cancelled deadline and stop
+			return
+		}
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/slow", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", w.Code)
+	}
+	if body := w.Body.String(); body == "" {
+		t.Error("expected JSON error body on timeout")
+	}
+}
+
+func TestTimeoutMiddlewarePassesFastHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.GET("/fast", TimeoutMiddleware(time.Second), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "done"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/fast", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestRouteTimeoutFromEnv(t *testing.T) {
+	t.Setenv("ROUTE_READ_TIMEOUT_SECONDS", "3")
+	if got := routeTimeout("ROUTE_READ_TIMEOUT_SECONDS", DefaultReadTimeout); got != 3*time.Second {
+		t.Errorf("expected 3s from env, got %v", got)
+	}
+
+	t.Setenv("ROUTE_READ_TIMEOUT_SECONDS", "not-a-number")
+	if got := routeTimeout("ROUTE_READ_TIMEOUT_SECONDS", DefaultReadTimeout); got != DefaultReadTimeout {
+		t.Errorf("expected fallback %v for invalid env, got %v", DefaultReadTimeout, got)
+	}
+}
@@ -3,9 +3,11 @@ package s3
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/minio/minio-go/v7"
@@ -100,6 +102,34 @@ func SetClient(c *minio.Client) {
 	client = c
 }
 
+// presignTTL is how long generated presigned URLs remain valid
+const presignTTL = 1 * time.Hour
+
+// urlCache retains the last successful presigned URL per object so reads can
+// fall back to a possibly-still-valid link while MinIO is unreachable.
+var (
+	urlCacheMu sync.Mutex
+	urlCache   = map[string]cachedPresignedURL{}
+)
+
+type cachedPresignedURL struct {
+	url       string
+	expiresAt time.Time
+}
+
+// CachedPresignedURL returns the last presigned URL generated for filename if
+// it has not expired yet. Used as a degraded-mode fallback when MinIO is down.
+func CachedPresignedURL(filename string) (string, bool) {
+	urlCacheMu.Lock()
+	defer urlCacheMu.Unlock()
+
+	cached, ok := urlCache[filename]
+	if !ok || time.Now().After(cached.expiresAt) {
+		return "", false
+	}
+	return cached.url, true
+}
+
 func GeneratePresignedURL(filename string) string {
 	// Return empty string if client is not initialized (e.g., in tests)
 	if client == nil {
@@ -116,15 +146,109 @@ func GeneratePresignedURL(filename string) string {
 	reqParams.Set("response-content-disposition", "inline")
 
 	bucketName := GetBucketName()
-	presignedURL, err := client.PresignedGetObject(ctx, bucketName, filename, 1*time.Hour, reqParams)
+	presignedURL, err := client.PresignedGetObject(ctx, bucketName, filename, presignTTL, reqParams)
 	if err != nil {
 		log.Println("Error generating presigned URL:", err)
 		return ""
 	}
 
+	// Remember the URL (with a safety margin before presign expiry) so degraded
+	// reads can serve it while MinIO is unreachable
+	urlCacheMu.Lock()
+	urlCache[filename] = cachedPresignedURL{
+		url:       presignedURL.String(),
+		expiresAt: time.Now().Add(presignTTL - 10*time.Minute),
+	}
+	urlCacheMu.Unlock()
+
 	return presignedURL.String()
 }
 
+// GetObject opens the named object in the screenshot bucket for reading.
+// The caller must close the returned reader.
+func GetObject(filename string) (io.ReadCloser, error) {
+	if client == nil {
+		return nil, fmt.Errorf("MinIO client is not initialized")
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("object name is empty")
+	}
+
+	obj, err := client.GetObject(context.Background(), GetBucketName(), filename, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object %q: %v", filename, err)
+	}
+
+	// GetObject is lazy; Stat forces the first request so missing objects fail
+	// here instead of on the first Read
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, fmt.Errorf("failed to stat object %q: %v", filename, err)
+	}
+
+	return obj, nil
+}
+
+// PutObject uploads an object to the bucket. Used for server-generated
+// artifacts such as command archive batches.
+func PutObject(filename string, data io.Reader, size int64) error {
+	if client == nil {
+		return fmt.Errorf("MinIO client is not initialized")
+	}
+	if filename == "" {
+		return fmt.Errorf("object name is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if _, err := client.PutObject(ctx, GetBucketName(), filename, data, size, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to upload object %q: %v", filename, err)
+	}
+	return nil
+}
+
+// RemoveObject deletes the named object from the screenshot bucket. Used to
+// discard uploads the server decides not to keep (e.g. throttled screenshots).
+func RemoveObject(filename string) error {
+	if client == nil {
+		return fmt.Errorf("MinIO client is not initialized")
+	}
+	if filename == "" {
+		return fmt.Errorf("object name is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.RemoveObject(ctx, GetBucketName(), filename, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove object %q: %v", filename, err)
+	}
+	return nil
+}
+
+// CheckBucket verifies the configured screenshot bucket exists.
+// Used by the startup self-check to catch bucket misconfiguration early.
+func CheckBucket() error {
+	if client == nil {
+		return fmt.Errorf("MinIO client is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	bucketName := GetBucketName()
+	exists, err := client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket %q: %v", bucketName, err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist", bucketName)
+	}
+
+	return nil
+}
+
 // HealthCheck checks if the S3/MinIO connection is healthy
 func HealthCheck() error {
 	if client == nil {
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"mentor-backend/database"
+	"mentor-backend/logging"
+	"mentor-backend/s3"
+)
+
+// SelfCheckResult holds the outcome of one startup dependency check
+type SelfCheckResult struct {
+	Name     string
+	Critical bool
+	Err      error
+}
+
+// runSelfChecks verifies each startup dependency and returns per-check results.
+// Critical checks gate startup when FAIL_FAST is enabled; non-critical checks
+// only produce warnings since the service can degrade gracefully without them.
+func runSelfChecks() []SelfCheckResult {
+	checks := []SelfCheckResult{
+		{Name: "database", Critical: true, Err: database.HealthCheck()},
+		{Name: "minio", Critical: false, Err: s3.HealthCheck()},
+		{Name: "minio_bucket", Critical: false, Err: s3.CheckBucket()},
+	}
+
+	// Required configuration: without a port the server cannot bind at all
+	if os.Getenv("PORT") == "" {
+		checks = append(checks, SelfCheckResult{Name: "config_port", Critical: true, Err: ErrPortNotSet})
+	} else {
+		checks = append(checks, SelfCheckResult{Name: "config_port", Critical: true})
+	}
+
+	// Forwarding is optional but worth surfacing so operators notice when
+	// commands will be queued without ever reaching devices
+	if os.Getenv("DEVICES_API_URL") == "" {
+		checks = append(checks, SelfCheckResult{
+			Name:     "config_forwarding",
+			Critical: false,
+			Err:      fmt.Errorf("DEVICES_API_URL not set; commands will not be forwarded to devices"),
+		})
+	} else {
+		checks = append(checks, SelfCheckResult{Name: "config_forwarding", Critical: false})
+	}
+
+	return checks
+}
+
+// SelfCheck runs all startup checks, logs a clear report, and returns an error
+// describing the critical failures (nil when all critical checks pass).
+func SelfCheck() error {
+	var criticalFailures []string
+
+	for _, check := range runSelfChecks() {
+		if check.Err == nil {
+			logging.Info("Self-check passed", map[string]interface{}{
+				"check": check.Name,
+			})
+			continue
+		}
+
+		if check.Critical {
+			logging.Error("Self-check failed", map[string]interface{}{
+				"check":    check.Name,
+				"critical": true,
+				"error":    check.Err.Error(),
+			})
+			criticalFailures = append(criticalFailures, fmt.Sprintf("%s: %v", check.Name, check.Err))
+		} else {
+			logging.Warning("Self-check warning", map[string]interface{}{
+				"check":    check.Name,
+				"critical": false,
+				"error":    check.Err.Error(),
+			})
+		}
+	}
+
+	if len(criticalFailures) > 0 {
+		return fmt.Errorf("startup self-check failed: %s", strings.Join(criticalFailures, "; "))
+	}
+	return nil
+}
+
+// failFastEnabled reports whether FAIL_FAST is set to a truthy value
+func failFastEnabled() bool {
+	v := strings.ToLower(os.Getenv("FAIL_FAST"))
+	return v == "1" || v == "true" || v == "yes"
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"mentor-backend/database"
+	"mentor-backend/s3"
+)
+
+func TestSelfCheckReportsMissingDependencies(t *testing.T) {
+	// Simulate a boot with no database, no MinIO client, and no PORT
+	origDB := database.DB
+	database.DB = nil
+	t.Cleanup(func() { database.DB = origDB })
+	origClient := s3.GetClient()
+	s3.SetClient(nil)
+	t.Cleanup(func() { s3.SetClient(origClient) })
+	t.Setenv("PORT", "")
+
+	err := SelfCheck()
+	if err == nil {
+		t.Fatal("expected self-check to fail with missing dependencies")
+	}
+	if !strings.Contains(err.Error(), "database") {
+		t.Errorf("expected database failure in self-check error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "config_port") {
+		t.Errorf("expected missing port in self-check error, got: %v", err)
+	}
+	// MinIO is non-critical and must not appear among the critical failures
+	if strings.Contains(err.Error(), "minio") {
+		t.Errorf("expected MinIO to be a warning, not a critical failure, got: %v", err)
+	}
+}
+
+func TestRunSelfChecksMarksMinioNonCritical(t *testing.T) {
+	origClient := s3.GetClient()
+	s3.SetClient(nil)
+	t.Cleanup(func() { s3.SetClient(origClient) })
+
+	for _, check := range runSelfChecks() {
+		if strings.HasPrefix(check.Name, "minio") && check.Critical {
+			t.Errorf("expected check %q to be non-critical", check.Name)
+		}
+		if check.Name == "database" && !check.Critical {
+			t.Error("expected database check to be critical")
+		}
+	}
+}
+
+func TestSelfCheckPassesWithHealthyCriticals(t *testing.T) {
+	// A connected database plus PORT set satisfies all critical checks even
+	// when MinIO is unavailable
+	db, err := database.SetupTestDB(t)
+	if err != nil || db == nil {
+		t.Skip("test database not available")
+	}
+	origDB := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = origDB })
+	t.Setenv("PORT", "8080")
+
+	origClient := s3.GetClient()
+	s3.SetClient(nil)
+	t.Cleanup(func() { s3.SetClient(origClient) })
+
+	if err := SelfCheck(); err != nil {
+		t.Errorf("expected self-check to pass with healthy criticals, got: %v", err)
+	}
+}
+
+func TestFailFastEnabled(t *testing.T) {
+	for value, expected := range map[string]bool{"true": true, "1": true, "yes": true, "false": false, "": false} {
+		t.Setenv("FAIL_FAST", value)
+		if got := failFastEnabled(); got != expected {
+			t.Errorf("failFastEnabled() with FAIL_FAST=%q = %v, want %v", value, got, expected)
+		}
+	}
+}